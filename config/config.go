@@ -1,20 +1,123 @@
 package config
 
 import (
+	"encoding/base64"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Scheduler SchedulerConfig
+	Storage   StorageConfig
+	Sealing   SealingConfig
+	Outbox    OutboxConfig
+	Webhook   WebhookConfig
 }
 
 type ServerConfig struct {
 	Addr string
 }
 
+type SchedulerConfig struct {
+	// Interval Период проверки тендеров с истекшим сроком приема предложений
+	Interval time.Duration
+}
+
+// defaultSchedulerInterval используется, если SCHEDULER_INTERVAL не задан
+const defaultSchedulerInterval = time.Minute
+
+// StorageConfig хранилище файлов вложений
+type StorageConfig struct {
+	// Backend Реализация хранилища: local или s3
+	Backend string
+
+	// LocalDir Корневая директория для Backend = local
+	LocalDir string
+
+	S3 S3Config
+
+	// MaxAttachmentSize Максимальный размер одного вложения в байтах
+	MaxAttachmentSize int64
+}
+
+// S3Config S3-совместимое хранилище (AWS S3, MinIO и т.п.), адресация path-style
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+const (
+	defaultStorageBackend    = "local"
+	defaultStorageLocalDir   = "./data/attachments"
+	defaultS3Region          = "us-east-1"
+	defaultMaxAttachmentSize = 10 << 20
+)
+
+// SealingConfig шифрование содержимого предложений запечатанных тендеров
+type SealingConfig struct {
+	// Key Мастер-ключ, из которого выводятся ключи тендеров. Пустой - запечатанные тендеры недоступны
+	Key []byte
+}
+
+// sealingKeySize длина мастер-ключа AES-256
+const sealingKeySize = 32
+
+// OutboxConfig доставка доменных событий из outbox
+type OutboxConfig struct {
+	// Interval Период опроса outbox
+	Interval time.Duration
+
+	// BatchSize Максимальное число событий за один проход
+	BatchSize int
+
+	// MaxAttempts Число попыток доставки, после которого событие считается недоставленным
+	MaxAttempts int
+
+	// Retention Сколько хранятся доставленные события
+	Retention time.Duration
+}
+
+const (
+	defaultOutboxInterval    = time.Second
+	defaultOutboxBatchSize   = 100
+	defaultOutboxMaxAttempts = 10
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+)
+
+// WebhookConfig отправка событий по подпискам организаций
+type WebhookConfig struct {
+	// Interval Период проверки готовых доставок
+	Interval time.Duration
+
+	// BatchSize Сколько доставок отправляется параллельно за один проход
+	BatchSize int
+
+	// MaxAttempts Число попыток, после которого доставка переходит в DeadLetter
+	MaxAttempts int
+
+	// Timeout Таймаут одного запроса к получателю
+	Timeout time.Duration
+
+	// AllowPrivateNetworks Разрешить адреса подписок во внутренних сетях и на localhost. Только для разработки
+	AllowPrivateNetworks bool
+}
+
+const (
+	defaultWebhookInterval    = time.Second
+	defaultWebhookBatchSize   = 20
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookTimeout     = 10 * time.Second
+)
+
 type DatabaseConfig struct {
 	ConnString   string
 	Username     string
@@ -29,6 +132,76 @@ func Load() (Config, error) {
 		return Config{}, err
 	}
 
+	schedulerInterval := defaultSchedulerInterval
+	if v := os.Getenv("SCHEDULER_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return Config{}, fmt.Errorf("invalid SCHEDULER_INTERVAL: %q", v)
+		}
+		schedulerInterval = interval
+	}
+
+	maxAttachmentSize := int64(defaultMaxAttachmentSize)
+	if v := os.Getenv("ATTACHMENT_MAX_SIZE"); v != "" {
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || size <= 0 {
+			return Config{}, fmt.Errorf("invalid ATTACHMENT_MAX_SIZE: %q", v)
+		}
+		maxAttachmentSize = size
+	}
+
+	outboxInterval, err := getEnvDuration("OUTBOX_INTERVAL", defaultOutboxInterval)
+	if err != nil {
+		return Config{}, err
+	}
+	outboxRetention, err := getEnvDuration("OUTBOX_RETENTION", defaultOutboxRetention)
+	if err != nil {
+		return Config{}, err
+	}
+	outboxBatchSize, err := getEnvInt("OUTBOX_BATCH_SIZE", defaultOutboxBatchSize)
+	if err != nil {
+		return Config{}, err
+	}
+	outboxMaxAttempts, err := getEnvInt("OUTBOX_MAX_ATTEMPTS", defaultOutboxMaxAttempts)
+	if err != nil {
+		return Config{}, err
+	}
+
+	webhookInterval, err := getEnvDuration("WEBHOOK_INTERVAL", defaultWebhookInterval)
+	if err != nil {
+		return Config{}, err
+	}
+	webhookTimeout, err := getEnvDuration("WEBHOOK_TIMEOUT", defaultWebhookTimeout)
+	if err != nil {
+		return Config{}, err
+	}
+	webhookBatchSize, err := getEnvInt("WEBHOOK_BATCH_SIZE", defaultWebhookBatchSize)
+	if err != nil {
+		return Config{}, err
+	}
+	webhookMaxAttempts, err := getEnvInt("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts)
+	if err != nil {
+		return Config{}, err
+	}
+
+	webhookAllowPrivate := false
+	if v := os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid WEBHOOK_ALLOW_PRIVATE_NETWORKS: %q", v)
+		}
+		webhookAllowPrivate = allow
+	}
+
+	var sealingKey []byte
+	if v := os.Getenv("SEALED_BIDS_KEY"); v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(key) != sealingKeySize {
+			return Config{}, fmt.Errorf("invalid SEALED_BIDS_KEY: must be base64 of %d bytes", sealingKeySize)
+		}
+		sealingKey = key
+	}
+
 	return Config{
 		Server: ServerConfig{
 			Addr: os.Getenv("SERVER_ADDRESS"),
@@ -41,6 +214,71 @@ func Load() (Config, error) {
 			Port:         os.Getenv("POSTGRES_POST"),
 			DatabaseName: os.Getenv("POSTGRES_DATABASE"),
 		},
+		Scheduler: SchedulerConfig{
+			Interval: schedulerInterval,
+		},
+		Storage: StorageConfig{
+			Backend:  getEnvDefault("STORAGE_BACKEND", defaultStorageBackend),
+			LocalDir: getEnvDefault("STORAGE_LOCAL_DIR", defaultStorageLocalDir),
+			S3: S3Config{
+				Endpoint:  os.Getenv("S3_ENDPOINT"),
+				Region:    getEnvDefault("S3_REGION", defaultS3Region),
+				Bucket:    os.Getenv("S3_BUCKET"),
+				AccessKey: os.Getenv("S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("S3_SECRET_KEY"),
+			},
+			MaxAttachmentSize: maxAttachmentSize,
+		},
+		Sealing: SealingConfig{
+			Key: sealingKey,
+		},
+		Outbox: OutboxConfig{
+			Interval:    outboxInterval,
+			BatchSize:   outboxBatchSize,
+			MaxAttempts: outboxMaxAttempts,
+			Retention:   outboxRetention,
+		},
+		Webhook: WebhookConfig{
+			Interval:    webhookInterval,
+			BatchSize:   webhookBatchSize,
+			MaxAttempts: webhookMaxAttempts,
+			Timeout:     webhookTimeout,
+
+			AllowPrivateNetworks: webhookAllowPrivate,
+		},
 	}, nil
 
 }
+
+func getEnvDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// getEnvDuration положительная длительность из переменной окружения или def, если она не задана
+func getEnvDuration(key string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", key, v)
+	}
+	return d, nil
+}
+
+// getEnvInt положительное число из переменной окружения или def, если она не задана
+func getEnvInt(key string, def int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", key, v)
+	}
+	return n, nil
+}
@@ -0,0 +1,25 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type AttachmentRepository interface {
+	// CheckTenderUploader и CheckBidUploader проверяют право загрузки до передачи файла в хранилище.
+	// Create* повторяют проверку в своей транзакции
+	CheckTenderUploader(ctx context.Context, tenderId repos.TenderId, username repos.Username) error
+	CheckBidUploader(ctx context.Context, bidId repos.BidId, username repos.Username) error
+
+	CreateTenderAttachment(ctx context.Context, tenderId repos.TenderId, params repos.CreateAttachmentParams) (*models.Attachment, error)
+	GetTenderAttachments(ctx context.Context, tenderId repos.TenderId) ([]*models.Attachment, error)
+	GetTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId) (*models.Attachment, error)
+	DeleteTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId, username repos.Username) error
+
+	CreateBidAttachment(ctx context.Context, bidId repos.BidId, params repos.CreateAttachmentParams) (*models.Attachment, error)
+	GetBidAttachments(ctx context.Context, bidId repos.BidId) ([]*models.Attachment, error)
+	GetBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId) (*models.Attachment, error)
+	DeleteBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId, username repos.Username) error
+}
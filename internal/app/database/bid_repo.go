@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
@@ -9,29 +10,45 @@ import (
 
 type BidRepository interface {
 	CreateBid(ctx context.Context, params repos.CreateBidParams) (*models.Bid, error)
-	GetUserBids(ctx context.Context, params repos.GetUserBidsParams) ([]*models.Bid, error)
-	GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) ([]*models.Bid, error)
-	GetBidStatus(ctx context.Context, bidId repos.BidId, params repos.GetBidStatusParams) (repos.BidStatus, error)
+	GetUserBids(ctx context.Context, params repos.GetUserBidsParams) (*models.Page[*models.Bid], error)
+	GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) (*models.Page[*models.Bid], error)
+	GetBidsForRanking(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.Bid, error)
 	UpdateBidStatus(ctx context.Context, bidId repos.BidId, params repos.UpdateBidStatusParams) (*models.Bid, error)
 	EditBid(ctx context.Context, bidId repos.BidId, username repos.Username, params repos.EditBidParams) (*models.Bid, error)
 	GetBidsByUsername(ctx context.Context, username repos.Username) ([]*models.Bid, error)
 	GetBidByID(ctx context.Context, bidId repos.BidId) (*models.Bid, error)
+	GetBid(ctx context.Context, bidId repos.BidId, username repos.Username) (*models.Bid, error)
 
 	BidDecisionRepository
 	BidFeedbackRepository
 	BidVersionRepository
+	BidAuctionRepository
 }
 
 type BidDecisionRepository interface {
 	SubmitBidDecision(ctx context.Context, bidId repos.BidId, params repos.SubmitBidDecisionParams) (*models.Bid, error)
+	// GetBidDecisions голосование по предложению. Видно только ответственным организации тендера
+	GetBidDecisions(ctx context.Context, bidId repos.BidId, username repos.Username) (*models.BidDecisionTally, error)
 }
 
 type BidFeedbackRepository interface {
 	// Review == feedback
 	GetBidReviews(ctx context.Context, tenderId repos.TenderId, params repos.GetBidReviewsParams) ([]*models.BidReview, error)
-	SubmitBidFeedback(ctx context.Context, bidId repos.BidId, params repos.SubmitBidFeedbackParams) (*models.Bid, error)
+	CreateBidFeedback(ctx context.Context, bidId repos.BidId, username repos.Username, parentId *repos.BidReviewId, description repos.BidFeedback, rating *int32) (*models.BidFeedbackEntry, error)
+	EditBidFeedback(ctx context.Context, feedbackId repos.BidReviewId, username repos.Username, params repos.EditBidFeedbackParams, window time.Duration) (*models.BidFeedbackEntry, error)
+	DeleteBidFeedback(ctx context.Context, feedbackId repos.BidReviewId, username repos.Username, window time.Duration) error
+	GetBidFeedbackEntry(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId) (*models.BidFeedbackEntry, error)
+	GetBidFeedback(ctx context.Context, bidId repos.BidId, page repos.PageRequest) (*models.Page[*models.BidFeedbackEntry], error)
+	GetBidFeedbackVersions(ctx context.Context, feedbackId repos.BidReviewId, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.BidFeedbackVersion, error)
 }
 
 type BidVersionRepository interface {
 	RollbackBid(ctx context.Context, bidId repos.BidId, version int32, params repos.RollbackBidParams) (*models.Bid, error)
+	GetBidVersions(ctx context.Context, bidId repos.BidId, params repos.GetBidVersionsParams) ([]*models.BidVersionInfo, error)
+	GetBidVersion(ctx context.Context, bidId repos.BidId, version int32, username repos.Username) (*models.BidVersionInfo, error)
+}
+
+type BidAuctionRepository interface {
+	PlaceAuctionPrice(ctx context.Context, bidId repos.BidId, username repos.Username, price models.Money) (*models.Bid, error)
+	GetAuctionBids(ctx context.Context, tenderId repos.TenderId) ([]*models.Bid, error)
 }
@@ -6,6 +6,15 @@ type Database interface {
 	BidRepository
 	TenderRepository
 	UserRepository
+	OrganizationRepository
+	EmployeeRepository
+	AttachmentRepository
+	EvaluationRepository
+	QuestionRepository
+	InvitationRepository
+	ReputationRepository
+	OutboxRepository
+	WebhookRepository
 
 	HealthRepository
 }
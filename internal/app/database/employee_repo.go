@@ -0,0 +1,16 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type EmployeeRepository interface {
+	GetEmployees(ctx context.Context, params repos.GetEmployeesParams) ([]*models.Employee, error)
+	GetEmployee(ctx context.Context, username repos.Username) (*models.Employee, error)
+	CreateEmployee(ctx context.Context, params repos.CreateEmployeeParams) (*models.Employee, error)
+	EditEmployee(ctx context.Context, username repos.Username, params repos.EditEmployeeParams) (*models.Employee, error)
+	DeleteEmployee(ctx context.Context, username repos.Username) error
+}
@@ -0,0 +1,17 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type EvaluationRepository interface {
+	ReplaceTenderCriteria(ctx context.Context, tenderId repos.TenderId, username repos.Username, criteria []repos.CriterionParams) ([]*models.EvaluationCriterion, error)
+	GetTenderCriteria(ctx context.Context, tenderId repos.TenderId) ([]*models.EvaluationCriterion, error)
+
+	UpsertBidScores(ctx context.Context, bidId repos.BidId, username repos.Username, scores []repos.ScoreParams) ([]*models.BidScore, error)
+	GetBidScores(ctx context.Context, bidId repos.BidId, username repos.Username) ([]*models.BidScore, error)
+	GetTenderScores(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.BidScore, error)
+}
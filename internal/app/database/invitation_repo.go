@@ -0,0 +1,16 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type InvitationRepository interface {
+	CreateTenderInvitation(ctx context.Context, tenderId repos.TenderId, username repos.Username, organizationId *repos.OrganizationId, employee *repos.Username) (*models.TenderInvitation, error)
+	GetTenderInvitations(ctx context.Context, tenderId repos.TenderId, username repos.Username, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderInvitation, error)
+	DeleteTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, username repos.Username) error
+	GetUserInvitations(ctx context.Context, userId int, status *repos.InvitationStatus, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderInvitation, error)
+	RespondTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, userId int, status repos.InvitationStatus) (*models.TenderInvitation, error)
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type OrganizationRepository interface {
+	GetOrganizations(ctx context.Context, params repos.GetOrganizationsParams) ([]*models.Organization, error)
+	GetOrganization(ctx context.Context, organizationId repos.OrganizationId) (*models.Organization, error)
+	CreateOrganization(ctx context.Context, params repos.CreateOrganizationParams) (*models.Organization, error)
+	EditOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.EditOrganizationParams) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.DeleteOrganizationParams) error
+
+	GetResponsibles(ctx context.Context, organizationId repos.OrganizationId) ([]*models.Employee, error)
+	AddResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.AddResponsibleParams) error
+	RemoveResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.RemoveResponsibleParams) error
+}
@@ -0,0 +1,16 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+type OutboxRepository interface {
+	// DispatchOutbox передает deliver до limit готовых к доставке событий и отмечает результат.
+	// Возвращает число успешно доставленных событий
+	DispatchOutbox(ctx context.Context, limit int, maxAttempts int, deliver func(ctx context.Context, event models.DomainEvent) error) (int, error)
+	// PurgeDispatchedEvents удаляет события, доставленные раньше olderThan
+	PurgeDispatchedEvents(ctx context.Context, olderThan time.Time) (int64, error)
+}
@@ -0,0 +1,384 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+// attachmentOwner сущность, к которой прикладываются файлы: колонка владельца в attachments
+// и таблица его версий, где колонка владельца называется так же
+type attachmentOwner struct {
+	column   string
+	versions string
+}
+
+var (
+	tenderAttachments = attachmentOwner{column: "tender_id", versions: "tender_versions"}
+	bidAttachments    = attachmentOwner{column: "bid_id", versions: "bid_versions"}
+)
+
+// snapshotIds подзапрос с набором текущих вложений для снимка версии.
+// alias - алиас таблицы владельца в запросе снимка
+func (o attachmentOwner) snapshotIds(alias string) string {
+	return `ARRAY(SELECT a.id FROM attachments a WHERE a.` + o.column + ` = ` + alias + `.id AND a.deleted_at IS NULL ORDER BY a.created_at, a.id)`
+}
+
+const attachmentColumns = `a.id, a.tender_id, a.bid_id, a.file_name, a.content_type, a.size, a.sha256, e.username, a.created_at, a.storage_key`
+
+func scanAttachment(row interface{ Scan(dest ...any) error }) (*models.Attachment, error) {
+	var a models.Attachment
+	err := row.Scan(&a.Id, &a.TenderId, &a.BidId, &a.FileName, &a.ContentType, &a.Size, &a.Sha256, &a.UploadedBy, &a.CreatedAt, &a.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (p *Postgres) CheckTenderUploader(ctx context.Context, tenderId repos.TenderId, username repos.Username) error {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (p *Postgres) CreateTenderAttachment(ctx context.Context, tenderId repos.TenderId, params repos.CreateAttachmentParams) (*models.Attachment, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	uploaderId, err := p.checkTenderResponsible(ctx, tx, tenderId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	// Блокируем тендер, чтобы снимки версий с вложениями создавались последовательно
+	err = p.checkTenderVersion(ctx, tx, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error lock tender", zap.Error(err))
+		return nil, err
+	}
+
+	attachment, err := p.insertAttachment(ctx, tx, tenderAttachments, tenderId, uploaderId, params)
+	if err != nil {
+		p.logger.Error("Error insert tender attachment", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = p.snapshotTender(ctx, tx, tenderId, &uploaderId, nil)
+	if err != nil {
+		p.logger.Error("Error insert new version in tender_versions", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitTenderEvent(ctx, tx, models.EventTenderUpdated, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error emit tender event", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (p *Postgres) GetTenderAttachments(ctx context.Context, tenderId repos.TenderId) ([]*models.Attachment, error) {
+	return p.listAttachments(ctx, tenderAttachments, tenderId)
+}
+
+func (p *Postgres) GetTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId) (*models.Attachment, error) {
+	return p.getAttachment(ctx, tenderAttachments, tenderId, attachmentId)
+}
+
+func (p *Postgres) DeleteTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId, username repos.Username) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	editorId, err := p.checkTenderResponsible(ctx, tx, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	err = p.checkTenderVersion(ctx, tx, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error lock tender", zap.Error(err))
+		return err
+	}
+
+	err = p.softDeleteAttachment(ctx, tx, tenderAttachments, tenderId, attachmentId)
+	if err != nil {
+		p.logger.Error("Error delete tender attachment", zap.Error(err))
+		return err
+	}
+
+	_, err = p.snapshotTender(ctx, tx, tenderId, &editorId, nil)
+	if err != nil {
+		p.logger.Error("Error insert new version in tender_versions", zap.Error(err))
+		return err
+	}
+
+	err = p.emitTenderEvent(ctx, tx, models.EventTenderUpdated, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error emit tender event", zap.Error(err))
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) CheckBidUploader(ctx context.Context, bidId repos.BidId, username repos.Username) error {
+	_, err := p.checkBidEditor(ctx, p.db, bidId, username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (p *Postgres) CreateBidAttachment(ctx context.Context, bidId repos.BidId, params repos.CreateAttachmentParams) (*models.Attachment, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Блокируем предложение, чтобы снимки версий с вложениями создавались последовательно
+	_, err = p.getBid(ctx, tx, bidId, true)
+	if err != nil {
+		p.logger.Error("Error get bid", zap.Error(err))
+		return nil, err
+	}
+
+	uploaderId, err := p.checkBidEditor(ctx, tx, bidId, params.Username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	attachment, err := p.insertAttachment(ctx, tx, bidAttachments, bidId, uploaderId, params)
+	if err != nil {
+		p.logger.Error("Error insert bid attachment", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = p.snapshotBid(ctx, tx, bidId, &uploaderId, nil, nil)
+	if err != nil {
+		p.logger.Error("Error creating new current version", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitBidEvent(ctx, tx, models.EventBidUpdated, bidId, nil)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (p *Postgres) GetBidAttachments(ctx context.Context, bidId repos.BidId) ([]*models.Attachment, error) {
+	return p.listAttachments(ctx, bidAttachments, bidId)
+}
+
+func (p *Postgres) GetBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId) (*models.Attachment, error) {
+	return p.getAttachment(ctx, bidAttachments, bidId, attachmentId)
+}
+
+func (p *Postgres) DeleteBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId, username repos.Username) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.getBid(ctx, tx, bidId, true)
+	if err != nil {
+		p.logger.Error("Error get bid", zap.Error(err))
+		return err
+	}
+
+	editorId, err := p.checkBidEditor(ctx, tx, bidId, username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return err
+	}
+
+	err = p.softDeleteAttachment(ctx, tx, bidAttachments, bidId, attachmentId)
+	if err != nil {
+		p.logger.Error("Error delete bid attachment", zap.Error(err))
+		return err
+	}
+
+	_, err = p.snapshotBid(ctx, tx, bidId, &editorId, nil, nil)
+	if err != nil {
+		p.logger.Error("Error creating new current version", zap.Error(err))
+		return err
+	}
+
+	err = p.emitBidEvent(ctx, tx, models.EventBidUpdated, bidId, nil)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) insertAttachment(ctx context.Context, q querier, owner attachmentOwner, ownerId string, uploaderId int, params repos.CreateAttachmentParams) (*models.Attachment, error) {
+	attachment := models.Attachment{
+		FileName:    params.FileName,
+		ContentType: params.ContentType,
+		Size:        params.Size,
+		Sha256:      params.Sha256,
+		UploadedBy:  &params.Username,
+		StorageKey:  params.StorageKey,
+	}
+
+	err := q.QueryRowContext(ctx, `
+        INSERT INTO attachments (`+owner.column+`, file_name, content_type, size, sha256, storage_key, uploaded_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, tender_id, bid_id, created_at`,
+		ownerId, params.FileName, params.ContentType, params.Size, params.Sha256, params.StorageKey, uploaderId).Scan(
+		&attachment.Id, &attachment.TenderId, &attachment.BidId, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (p *Postgres) listAttachments(ctx context.Context, owner attachmentOwner, ownerId string) ([]*models.Attachment, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+attachmentColumns+`
+        FROM attachments a
+        LEFT JOIN employee e ON e.id = a.uploaded_by
+        WHERE a.`+owner.column+` = $1 AND a.deleted_at IS NULL
+        ORDER BY a.created_at, a.id`, ownerId)
+	if err != nil {
+		p.logger.Error("Error get list of attachments", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (p *Postgres) getAttachment(ctx context.Context, owner attachmentOwner, ownerId string, attachmentId repos.AttachmentId) (*models.Attachment, error) {
+	// id сравнивается как текст, чтобы некорректный идентификатор давал "не найдено", а не ошибку приведения к uuid
+	attachment, err := scanAttachment(p.db.QueryRowContext(ctx, `
+        SELECT `+attachmentColumns+`
+        FROM attachments a
+        LEFT JOIN employee e ON e.id = a.uploaded_by
+        WHERE a.`+owner.column+` = $1 AND a.id::text = $2 AND a.deleted_at IS NULL`, ownerId, attachmentId))
+	if err == sql.ErrNoRows {
+		return nil, ErrAttachmentNotFound
+	} else if err != nil {
+		p.logger.Error("Error get attachment", zap.Error(err))
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// softDeleteAttachment помечает вложение удаленным. Содержимое остается в хранилище,
+// потому что откат к более ранней версии может вернуть файл
+func (p *Postgres) softDeleteAttachment(ctx context.Context, q querier, owner attachmentOwner, ownerId string, attachmentId repos.AttachmentId) error {
+	res, err := q.ExecContext(ctx, `
+        UPDATE attachments
+        SET deleted_at = CURRENT_TIMESTAMP
+        WHERE `+owner.column+` = $1 AND id::text = $2 AND deleted_at IS NULL`, ownerId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// restoreAttachments приводит набор вложений к снимку версии:
+// файлы из снимка становятся актуальными, остальные помечаются удаленными
+func (p *Postgres) restoreAttachments(ctx context.Context, q querier, owner attachmentOwner, ownerId string, version int32) error {
+	_, err := q.ExecContext(ctx, `
+        UPDATE attachments a
+        SET deleted_at = CASE WHEN a.id = ANY(v.attachment_ids) THEN NULL
+            ELSE COALESCE(a.deleted_at, CURRENT_TIMESTAMP) END
+        FROM `+owner.versions+` v
+        WHERE v.`+owner.column+` = $1 AND v.version_number = $2 AND a.`+owner.column+` = $1`, ownerId, version)
+	return err
+}
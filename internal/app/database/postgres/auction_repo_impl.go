@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAuctionClosed       = errors.New("auction is not running")
+	ErrAuctionPriceTooHigh = errors.New("price does not beat the best price by auction step")
+)
+
+// tenderAuctionColumns параметры редукциона. У обычного тендера все колонки пустые
+func tenderAuctionColumns(alias string) string {
+	return alias + ".auction_starts_at, " + moneyColumns(alias, "auction_step") + ", " + alias + ".auction_extension_seconds"
+}
+
+// tenderAuction приемник колонок tenderAuctionColumns
+type tenderAuction struct {
+	startsAt  sql.NullTime
+	step      moneyValue
+	extension sql.NullInt32
+}
+
+func (a *tenderAuction) dest() []any {
+	return append([]any{&a.startsAt}, append(a.step.dest(), &a.extension)...)
+}
+
+func (a tenderAuction) model() *models.AuctionSettings {
+	if !a.startsAt.Valid {
+		return nil
+	}
+	return &models.AuctionSettings{
+		StartsAt:         a.startsAt.Time,
+		Step:             *a.step.model(),
+		ExtensionSeconds: a.extension.Int32,
+	}
+}
+
+// auctionArgs раскладывает параметры редукциона на аргументы запроса, nil - четыре NULL
+func auctionArgs(a *models.AuctionSettings) (startsAt, stepAmount, stepCurrency, extension any) {
+	if a == nil {
+		return nil, nil, nil, nil
+	}
+	return a.StartsAt, a.Step.Amount, a.Step.Currency, a.ExtensionSeconds
+}
+
+// PlaceAuctionPrice сохраняет новую цену предложения на торгах. Под блокировкой тендера проверяет,
+// что торги идут и цена ниже лучшей как минимум на шаг (первая цена - не выше бюджета),
+// и продлевает торги, если цена подана незадолго до окончания
+func (p *Postgres) PlaceAuctionPrice(ctx context.Context, bidId repos.BidId, username repos.Username, price models.Money) (*models.Bid, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	editorId, err := p.checkBidEditor(ctx, tx, bidId, username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	bid, err := p.getBid(ctx, tx, bidId, true)
+	if err != nil {
+		p.logger.Error("Error get bid", zap.Error(err))
+		return nil, err
+	}
+
+	// Цены участников сравниваются и меняются только под блокировкой тендера
+	var status repos.TenderStatus
+	var terms tenderTerms
+	var running bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT t.status, `+tenderTermsColumns("t")+`,
+			t.auction_starts_at <= CURRENT_TIMESTAMP AND t.bids_close_at > CURRENT_TIMESTAMP
+		FROM tenders t
+		WHERE t.id = $1
+		FOR UPDATE OF t`, bid.TenderId).Scan(append(append([]any{&status}, terms.dest()...), &running)...)
+	if err == sql.ErrNoRows {
+		err = ErrTenderNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error lock tender", zap.Error(err))
+		return nil, err
+	}
+
+	auction := terms.auction.model()
+	if auction == nil || status != repos.TenderStatusPublished || !running {
+		p.logger.Error("Auction is not running", zap.String("tenderId", bid.TenderId))
+		err = ErrAuctionClosed
+		return nil, err
+	}
+	if repos.BidStatus(bid.Status) != repos.BidStatusPublished {
+		p.logger.Error("Bid status changed", zap.String("status", string(bid.Status)))
+		err = ErrBidStatusChanged
+		return nil, err
+	}
+
+	// Суммы сравниваем в NUMERIC, чтобы не терять точность
+	budgetAmount, _ := moneyArgs(terms.budget.model())
+	var beats sql.NullBool
+	err = tx.QueryRowContext(ctx, `
+		SELECT $1::numeric <= COALESCE(MIN(b.price_amount) - $2::numeric, $3::numeric)
+		FROM bids b
+		WHERE b.tender_id = $4 AND b.status = $5 AND b.price_currency = $6`,
+		price.Amount, auction.Step.Amount, budgetAmount, bid.TenderId, repos.BidStatusPublished, auction.Step.Currency).Scan(&beats)
+	if err != nil {
+		p.logger.Error("Error compare auction price", zap.Error(err))
+		return nil, err
+	}
+	if beats.Valid && !beats.Bool {
+		p.logger.Error("Auction price too high", zap.String("price", price.Amount))
+		err = ErrAuctionPriceTooHigh
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bids
+		SET price_amount = $1, price_currency = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3`, price.Amount, price.Currency, bidId)
+	if err != nil {
+		p.logger.Error("Error update bid price", zap.Error(err))
+		return nil, err
+	}
+
+	bid.Version, err = p.snapshotBid(ctx, tx, bidId, &editorId, nil, nil)
+	if err != nil {
+		p.logger.Error("Error inserting bid version", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitBidEvent(ctx, tx, models.EventBidUpdated, bidId, nil)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auction_prices (tender_id, bid_id, price_amount, price_currency, placed_by)
+		VALUES ($1, $2, $3, $4, $5)`, bid.TenderId, bidId, price.Amount, price.Currency, editorId)
+	if err != nil {
+		p.logger.Error("Error insert auction price", zap.Error(err))
+		return nil, err
+	}
+
+	// Защита от "снайперов": поздняя цена сдвигает окончание торгов, чтобы остальные успели ответить
+	if auction.ExtensionSeconds > 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE tenders
+			SET bids_close_at = GREATEST(bids_close_at, CURRENT_TIMESTAMP + make_interval(secs => $2))
+			WHERE id = $1`, bid.TenderId, auction.ExtensionSeconds)
+		if err != nil {
+			p.logger.Error("Error extend auction", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	bid.Price = &price
+	return bid, nil
+}
+
+// GetAuctionBids участники торгов - опубликованные предложения тендера. Права проверяет сервис
+func (p *Postgres) GetAuctionBids(ctx context.Context, tenderId repos.TenderId) ([]*models.Bid, error) {
+	bids, err := p.getPublishedBids(ctx, p.db, tenderId)
+	if err != nil {
+		p.logger.Error("Error get auction bids", zap.Error(err))
+		return nil, err
+	}
+	return bids, nil
+}
@@ -0,0 +1,391 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrFeedbackNotFound    = errors.New("feedback not found for bid")
+	ErrNotFeedbackAuthor   = errors.New("not author of the feedback")
+	ErrFeedbackEditExpired = errors.New("feedback edit period expired")
+)
+
+// Текст и оценка удаленной записи остаются только в истории версий
+const feedbackColumns = `f.id, f.bid_id, f.parent_id, a.username, f.author_id,
+        CASE WHEN f.deleted_at IS NULL THEN f.description ELSE '' END,
+        CASE WHEN f.deleted_at IS NULL THEN f.rating END,
+        f.version, f.deleted_at IS NOT NULL, f.created_at, f.updated_at`
+
+const feedbackFrom = `
+        FROM bid_feedbacks f
+        LEFT JOIN employee a ON a.id = f.author_id`
+
+func feedbackDest(f *models.BidFeedbackEntry) []any {
+	return []any{&f.Id, &f.BidId, &f.ParentId, &f.AuthorUsername, &f.AuthorId, &f.Description, &f.Rating, &f.Version, &f.Deleted, &f.CreatedAt, &f.UpdatedAt}
+}
+
+// CreateBidFeedback оставляет отзыв или ответ в ветке отзыва parentId.
+// Отзыв оставляют только ответственные за тендер, права на ответ проверяет сервис
+func (p *Postgres) CreateBidFeedback(ctx context.Context, bidId repos.BidId, username repos.Username, parentId *repos.BidReviewId, description repos.BidFeedback, rating *int32) (*models.BidFeedbackEntry, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var tenderId repos.TenderId
+	err = tx.QueryRowContext(ctx, `SELECT tender_id FROM bids WHERE id = $1`, bidId).Scan(&tenderId)
+	if err == sql.ErrNoRows {
+		err = ErrBidNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error get bid tender", zap.Error(err))
+		return nil, err
+	}
+
+	var authorId int
+	if parentId == nil {
+		authorId, err = p.checkTenderResponsible(ctx, tx, tenderId, username)
+		if err != nil {
+			p.logger.Error("Error in check org responsible", zap.Error(err))
+			return nil, err
+		}
+	} else {
+		err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&authorId)
+		if err == sql.ErrNoRows {
+			err = ErrUserNotFound
+			return nil, err
+		} else if err != nil {
+			p.logger.Error("Error get feedback author", zap.Error(err))
+			return nil, err
+		}
+
+		// Ветка одноуровневая: ответ на ответ попадает в ветку исходного отзыва
+		var rootId repos.BidReviewId
+		err = tx.QueryRowContext(ctx, `
+            SELECT COALESCE(f.parent_id, f.id)
+            FROM bid_feedbacks f
+            WHERE f.id = $1 AND f.bid_id = $2 AND f.deleted_at IS NULL
+            FOR SHARE`, *parentId, bidId).Scan(&rootId)
+		if err == sql.ErrNoRows {
+			err = ErrFeedbackNotFound
+			return nil, err
+		} else if err != nil {
+			p.logger.Error("Error get parent feedback", zap.Error(err))
+			return nil, err
+		}
+		parentId = &rootId
+	}
+
+	var feedbackId repos.BidReviewId
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO bid_feedbacks (bid_id, author_id, parent_id, description, rating, created_at)
+        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+        RETURNING id`, bidId, authorId, parentId, description, rating).Scan(&feedbackId)
+	if err != nil {
+		p.logger.Error("Error insert new review", zap.Error(err))
+		return nil, err
+	}
+
+	result, err := p.commitFeedback(ctx, tx, feedbackId, authorId, models.EventFeedbackAdded)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EditBidFeedback правит текст или оценку. Править может только автор и только в течение window после создания
+func (p *Postgres) EditBidFeedback(ctx context.Context, feedbackId repos.BidReviewId, username repos.Username, params repos.EditBidFeedbackParams, window time.Duration) (*models.BidFeedbackEntry, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	userId, err := p.lockFeedbackForAuthor(ctx, tx, feedbackId, username, window)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE bid_feedbacks
+        SET description = COALESCE($1, description), rating = COALESCE($2, rating),
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3`, params.Description, params.Rating, feedbackId)
+	if err != nil {
+		p.logger.Error("Error update bid feedback", zap.Error(err))
+		return nil, err
+	}
+
+	result, err := p.commitFeedback(ctx, tx, feedbackId, userId, models.EventFeedbackEdited)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteBidFeedback помечает запись удаленной. Ответы в ветке и история версий сохраняются
+func (p *Postgres) DeleteBidFeedback(ctx context.Context, feedbackId repos.BidReviewId, username repos.Username, window time.Duration) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	userId, err := p.lockFeedbackForAuthor(ctx, tx, feedbackId, username, window)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE bid_feedbacks
+        SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP, version = version + 1
+        WHERE id = $1`, feedbackId)
+	if err != nil {
+		p.logger.Error("Error delete bid feedback", zap.Error(err))
+		return err
+	}
+
+	_, err = p.commitFeedback(ctx, tx, feedbackId, userId, models.EventFeedbackDeleted)
+	return err
+}
+
+// lockFeedbackForAuthor блокирует запись и проверяет, что ее меняет автор и срок правки не истек
+func (p *Postgres) lockFeedbackForAuthor(ctx context.Context, tx *sql.Tx, feedbackId repos.BidReviewId, username repos.Username, window time.Duration) (int, error) {
+	var authorId sql.NullInt64
+	var deleted, editable bool
+	err := tx.QueryRowContext(ctx, `
+        SELECT f.author_id, f.deleted_at IS NOT NULL, f.created_at > CURRENT_TIMESTAMP - make_interval(secs => $2)
+        FROM bid_feedbacks f
+        WHERE f.id = $1
+        FOR UPDATE`, feedbackId, window.Seconds()).Scan(&authorId, &deleted, &editable)
+	if err == sql.ErrNoRows {
+		return 0, ErrFeedbackNotFound
+	} else if err != nil {
+		p.logger.Error("Error lock bid feedback", zap.Error(err))
+		return 0, err
+	}
+	if deleted {
+		return 0, ErrFeedbackNotFound
+	}
+
+	var userId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotFound
+	} else if err != nil {
+		p.logger.Error("Error get user", zap.Error(err))
+		return 0, err
+	}
+	if !authorId.Valid || int(authorId.Int64) != userId {
+		return 0, ErrNotFeedbackAuthor
+	}
+	if !editable {
+		return 0, ErrFeedbackEditExpired
+	}
+
+	return userId, nil
+}
+
+// commitFeedback сохраняет текущее состояние записи новой версией, публикует событие eventType, читает запись и завершает транзакцию
+func (p *Postgres) commitFeedback(ctx context.Context, tx *sql.Tx, feedbackId repos.BidReviewId, editorId int, eventType models.DomainEventType) (*models.BidFeedbackEntry, error) {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO bid_feedback_versions (feedback_id, version, description, rating, deleted, editor_id)
+        SELECT id, version, description, rating, deleted_at IS NOT NULL, $2
+        FROM bid_feedbacks
+        WHERE id = $1`, feedbackId, editorId)
+	if err != nil {
+		p.logger.Error("Error snapshot bid feedback", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitFeedbackEvent(ctx, tx, eventType, feedbackId)
+	if err != nil {
+		p.logger.Error("Error emit feedback event", zap.Error(err))
+		return nil, err
+	}
+
+	feedback, err := p.getBidFeedbackEntry(ctx, tx, feedbackId)
+	if err != nil {
+		p.logger.Error("Error get bid feedback", zap.Error(err))
+		return nil, err
+	}
+
+	// Оценка есть только у отзыва, ответы на репутацию не влияют
+	if feedback.ParentId == nil {
+		err = p.refreshBidReputation(ctx, tx, feedback.BidId)
+		if err != nil {
+			p.logger.Error("Error refresh bidder reputation", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+func (p *Postgres) GetBidFeedbackEntry(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId) (*models.BidFeedbackEntry, error) {
+	feedback, err := p.getBidFeedbackEntry(ctx, p.db, feedbackId)
+	if err != nil {
+		if err != ErrFeedbackNotFound {
+			p.logger.Error("Error get bid feedback", zap.Error(err))
+		}
+		return nil, err
+	}
+	if feedback.BidId != bidId {
+		return nil, ErrFeedbackNotFound
+	}
+	return feedback, nil
+}
+
+func (p *Postgres) getBidFeedbackEntry(ctx context.Context, q querier, feedbackId repos.BidReviewId) (*models.BidFeedbackEntry, error) {
+	var feedback models.BidFeedbackEntry
+	err := q.QueryRowContext(ctx, `
+        SELECT `+feedbackColumns+feedbackFrom+`
+        WHERE f.id = $1`, feedbackId).Scan(feedbackDest(&feedback)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrFeedbackNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// GetBidFeedback страница отзывов на предложение, у каждого отзыва - вся его ветка ответов
+func (p *Postgres) GetBidFeedback(ctx context.Context, bidId repos.BidId, page repos.PageRequest) (*models.Page[*models.BidFeedbackEntry], error) {
+	from := feedbackFrom + `
+        WHERE f.bid_id = $1 AND f.parent_id IS NULL`
+	args := []any{bidId}
+
+	total, err := p.countTotal(ctx, page, from, args)
+	if err != nil {
+		p.logger.Error("Error count bid feedback", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+feedbackColumns+from+keysetCondition("f", page, &args)+`
+        ORDER BY `+pageOrder("f")+limitOffset(page, &args), args...)
+	if err != nil {
+		p.logger.Error("Error get list of bid feedback", zap.Error(err))
+		return nil, err
+	}
+	roots, err := p.scanFeedback(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Page[*models.BidFeedbackEntry]{Items: roots, TotalCount: total}
+	if len(roots) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(roots))
+	threads := make(map[repos.BidReviewId]*models.BidFeedbackEntry, len(roots))
+	for _, root := range roots {
+		ids = append(ids, root.Id)
+		threads[root.Id] = root
+	}
+
+	rows, err = p.db.QueryContext(ctx, `
+        SELECT `+feedbackColumns+feedbackFrom+`
+        WHERE f.parent_id = ANY($1::uuid[])
+        ORDER BY f.created_at, f.id`, pq.Array(ids))
+	if err != nil {
+		p.logger.Error("Error get bid feedback replies", zap.Error(err))
+		return nil, err
+	}
+	replies, err := p.scanFeedback(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		if root, ok := threads[*reply.ParentId]; ok {
+			root.Replies = append(root.Replies, reply)
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Postgres) scanFeedback(rows *sql.Rows) ([]*models.BidFeedbackEntry, error) {
+	defer rows.Close()
+
+	entries := []*models.BidFeedbackEntry{}
+	for rows.Next() {
+		var feedback models.BidFeedbackEntry
+		if err := rows.Scan(feedbackDest(&feedback)...); err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		entries = append(entries, &feedback)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (p *Postgres) GetBidFeedbackVersions(ctx context.Context, feedbackId repos.BidReviewId, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.BidFeedbackVersion, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT v.version, v.description, v.rating, v.deleted, e.username, v.created_at
+        FROM bid_feedback_versions v
+        LEFT JOIN employee e ON e.id = v.editor_id
+        WHERE v.feedback_id = $1
+        ORDER BY v.version DESC
+        LIMIT $2 OFFSET $3`, feedbackId, limit, offset)
+	if err != nil {
+		p.logger.Error("Error get list of feedback versions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []*models.BidFeedbackVersion{}
+	for rows.Next() {
+		var v models.BidFeedbackVersion
+		err := rows.Scan(&v.Version, &v.Description, &v.Rating, &v.Deleted, &v.EditorUsername, &v.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		versions = append(versions, &v)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return versions, nil
+}
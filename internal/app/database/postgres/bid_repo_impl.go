@@ -7,7 +7,7 @@ import (
 
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
-	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 
 	_ "github.com/lib/pq"
@@ -22,8 +22,32 @@ var (
 	ErrUserNotAllowed       = errors.New("user not allowed to view or update bid status")
 	ErrNoBidsForAuthor      = errors.New("no bids found for the author")
 	ErrNotAuthor            = errors.New("not author of the bid")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+
+	ErrBidAlreadyDecided        = errors.New("bid already approved or rejected")
+	ErrDecisionAlreadySubmitted = errors.New("decision already submitted by user")
+	ErrTenderAlreadyAwarded     = errors.New("tender already has an approved bid")
+	ErrTenderStatusChanged      = errors.New("tender status changed concurrently")
+	ErrBidStatusChanged         = errors.New("bid status changed concurrently")
+	ErrVersionConflict          = errors.New("version changed since it was read")
+
+	ErrEmployeeAlreadyExists  = errors.New("employee with this username already exists")
+	ErrAlreadyResponsible     = errors.New("employee is already responsible for organization")
+	ErrResponsibleNotFound    = errors.New("employee is not responsible for organization")
+	ErrLastResponsible        = errors.New("organization must keep at least one responsible")
+	ErrOrganizationHasTenders = errors.New("organization has tenders")
+	ErrOrganizationHasBids    = errors.New("organization has bids")
+	ErrEmployeeHasActivity    = errors.New("employee has bids, decisions or scores")
 )
 
+// maxDecisionQuorum верхняя граница кворума для одобрения предложения
+const maxDecisionQuorum = 3
+
+// bidAuthorIdColumn authorId предложения по контракту API: id организации для предложений от ее имени,
+// id сотрудника для предложений от пользователя. Таблица bids в запросе должна иметь алиас b
+const bidAuthorIdColumn = `CASE WHEN b.author_type = 'Organization' AND b.organization_id IS NOT NULL
+			THEN b.organization_id::text ELSE b.author_id::text END`
+
 func (p *Postgres) CreateBid(ctx context.Context, params repos.CreateBidParams) (*models.Bid, error) {
 	// Проверяем, существует ли тендер
 	exists, err := p.IsTenderExists(ctx, *params.TenderID)
@@ -68,13 +92,25 @@ func (p *Postgres) CreateBid(ctx context.Context, params repos.CreateBidParams)
 		}
 	}()
 
+	// Подать предложение от имени организации может только ее ответственный
+	if params.OrganizationID != nil {
+		_, err = p.checkOrganizationMember(ctx, tx, *params.OrganizationID, *params.CreatorUsername)
+		if err != nil {
+			p.logger.Error("Error in check org responsible", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	// Создаем новое предложение (bid)
 	bidQuery := `
-		INSERT INTO bids (name, description, status, tender_id, author_type, author_id, created_at, updated_at)
+		INSERT INTO bids AS b (name, description, status, tender_id, author_type, author_id, organization_id,
+			price_amount, price_currency, delivery_days, sealed_payload, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, 
 			(SELECT id FROM employee WHERE username = $6), 
-			CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		RETURNING id, name, description, status, tender_id, author_type, author_id, created_at`
+			$7, $8, $9, $10, $11, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING b.id, b.name, b.description, b.status, b.tender_id, b.author_type, ` + bidAuthorIdColumn + `, b.created_at, ` + bidTermsColumns("b")
+
+	priceAmount, priceCurrency := moneyArgs(params.Price)
 
 	row := tx.QueryRowContext(ctx, bidQuery,
 		*params.Name,
@@ -88,10 +124,16 @@ func (p *Postgres) CreateBid(ctx context.Context, params repos.CreateBidParams)
 			return "User"
 		}(),
 		*params.CreatorUsername,
+		params.OrganizationID,
+		priceAmount,
+		priceCurrency,
+		params.DeliveryDays,
+		params.SealedPayload,
 	)
 
 	bid := &models.Bid{}
-	err = row.Scan(
+	var terms bidTerms
+	err = row.Scan(append([]any{
 		&bid.Id,
 		&bid.Name,
 		&bid.Description,
@@ -100,28 +142,40 @@ func (p *Postgres) CreateBid(ctx context.Context, params repos.CreateBidParams)
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.CreatedAt,
-	)
+	}, terms.dest()...)...)
 	if err != nil {
 		p.logger.Error("Error scanning vals to bid", zap.Error(err))
 		return nil, err
 	}
+	terms.apply(bid)
 
-	versionQuery := `
-		INSERT INTO bid_versions (bid_id, version_number, author_id, status, created_at, is_current)
-		VALUES ($1, $2, (SELECT id FROM employee WHERE username = $3), $4, CURRENT_TIMESTAMP, TRUE)
-		RETURNING version_number`
+	var creatorId int
+	err = tx.QueryRowContext(ctx, `SELECT author_id FROM bids WHERE id = $1`, bid.Id).Scan(&creatorId)
+	if err != nil {
+		p.logger.Error("Error get bid author", zap.Error(err))
+		return nil, err
+	}
 
-	err = tx.QueryRowContext(ctx, versionQuery,
-		bid.Id,
-		1,
-		*params.CreatorUsername,
-		*params.Status,
-	).Scan(&bid.Version)
+	bid.Version, err = p.snapshotBid(ctx, tx, bid.Id, &creatorId, nil, nil)
 	if err != nil {
 		p.logger.Error("Error query add version in tx", zap.Error(err))
 		return nil, err
 	}
 
+	err = p.emitBidEvent(ctx, tx, models.EventBidCreated, bid.Id, nil)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return nil, err
+	}
+
+	if bid.Status == models.BidStatus(repos.BidStatusPublished) {
+		err = p.emitBidEvent(ctx, tx, models.EventBidSubmitted, bid.Id, nil)
+		if err != nil {
+			p.logger.Error("Error emit bid event", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	// Если все прошло успешно, фиксируем транзакцию
 	err = tx.Commit()
 	if err != nil {
@@ -132,7 +186,7 @@ func (p *Postgres) CreateBid(ctx context.Context, params repos.CreateBidParams)
 	return bid, nil
 }
 
-func (p *Postgres) GetUserBids(ctx context.Context, params repos.GetUserBidsParams) ([]*models.Bid, error) {
+func (p *Postgres) GetUserBids(ctx context.Context, params repos.GetUserBidsParams) (*models.Page[*models.Bid], error) {
 	// Проверяем, существует ли пользователь с указанным username
 	userID, err := p.GetUserIDByUsername(ctx, *params.Username)
 	if err != nil {
@@ -141,40 +195,62 @@ func (p *Postgres) GetUserBids(ctx context.Context, params repos.GetUserBidsPara
 	}
 
 	// Формируем запрос для получения списка предложений пользователя
-	bidQuery := `
-		SELECT b.id, b.name, b.description, b.status, b.author_type, b.author_id, b.created_at, v.version_number
+	// Предложения от организации видны всем ее ответственным, предложения от пользователя - только автору
+	from := `
 		FROM bids b
 		JOIN bid_versions v ON b.id = v.bid_id
-		WHERE b.author_id = $1 AND v.is_current = TRUE
-		LIMIT $2 OFFSET $3`
+		WHERE (
+			(b.organization_id IS NULL AND b.author_id = $1)
+			OR
+			EXISTS (
+				SELECT 1 FROM organization_responsible r
+				WHERE r.organization_id = b.organization_id AND r.user_id = $1
+			)
+		)
+		AND v.is_current = TRUE`
+	args := []any{userID}
+
+	total, err := p.countTotal(ctx, params.Page, from, args)
+	if err != nil {
+		p.logger.Error("Error count user bids", zap.Error(err))
+		return nil, err
+	}
+
+	bidQuery := `
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, ` + bidAuthorIdColumn + `, b.created_at, v.version_number, ` +
+		bidTermsColumns("b") + from + keysetCondition("b", params.Page, &args) + `
+		ORDER BY ` + pageOrder("b") + limitOffset(params.Page, &args)
 
-	rows, err := p.db.QueryContext(ctx, bidQuery, userID, *params.Limit, *params.Offset)
+	rows, err := p.db.QueryContext(ctx, bidQuery, args...)
 	if err != nil {
 		p.logger.Error("Error get list of user bids", zap.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
 
-	var bids []*models.Bid
+	page := &models.Page[*models.Bid]{Items: []*models.Bid{}, TotalCount: total}
 
 	// Обрабатываем полученные строки
 	for rows.Next() {
 		var bid models.Bid
-		err := rows.Scan(
+		var terms bidTerms
+		err := rows.Scan(append([]any{
 			&bid.Id,
 			&bid.Name,
 			&bid.Description,
 			&bid.Status,
+			&bid.TenderId,
 			&bid.AuthorType,
 			&bid.AuthorId,
 			&bid.CreatedAt,
 			&bid.Version,
-		)
+		}, terms.dest()...)...)
 		if err != nil {
 			p.logger.Error("Error rows.Scan()", zap.Error(err))
 			return nil, err
 		}
-		bids = append(bids, &bid)
+		terms.apply(&bid)
+		page.Items = append(page.Items, &bid)
 	}
 
 	// Проверяем ошибки после итераций
@@ -183,10 +259,10 @@ func (p *Postgres) GetUserBids(ctx context.Context, params repos.GetUserBidsPara
 		return nil, err
 	}
 
-	return bids, nil
+	return page, nil
 }
 
-func (p *Postgres) GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) ([]*models.Bid, error) {
+func (p *Postgres) GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) (*models.Page[*models.Bid], error) {
 	// Проверяем, существует ли тендер с указанным tenderId
 	exists, err := p.IsTenderExists(ctx, tenderId)
 	if err != nil {
@@ -205,34 +281,63 @@ func (p *Postgres) GetBidsForTender(ctx context.Context, tenderId repos.TenderId
 		return nil, ErrUserNotFound
 	}
 
+	var q repos.SearchQuery
+	if params.Q != nil {
+		q = *params.Q
+	}
+
 	// Формируем запрос для получения списка предложений для данного тендера
-	bidQuery := `
-		SELECT b.id, b.name, b.description, b.status, b.author_type, b.author_id, b.created_at, v.version_number
+	from := `
 		FROM bids b
 		JOIN bid_versions v ON b.id = v.bid_id
 		JOIN tenders t ON b.tender_id = t.id
+		CROSS JOIN LATERAL (SELECT ` + searchTsQuery(4) + ` AS query) s
 		WHERE b.tender_id = $1
+		AND ($4 = '' OR b.search_vector @@ s.query)
 		AND (
-			b.author_id = $2
+			(b.organization_id IS NULL AND b.author_id = $2)
+			OR
+			EXISTS (
+				SELECT 1 FROM organization_responsible r
+				WHERE r.organization_id = b.organization_id AND r.user_id = $2
+			)
 			OR
-			t.organization_id = (SELECT organization_id FROM organization_responsible WHERE user_id = $2)
+			(
+				b.status = ANY($3)
+				AND EXISTS (
+					SELECT 1 FROM organization_responsible r
+					WHERE r.organization_id = t.organization_id AND r.user_id = $2
+				)
+			)
 		)
-		AND v.is_current = TRUE
-		LIMIT $3 OFFSET $4
-	`
+		AND v.is_current = TRUE`
+	args := []any{tenderId, userID, pq.Array(params.TenderOwnerStatuses), q}
+
+	total, err := p.countTotal(ctx, params.Page, from, args)
+	if err != nil {
+		p.logger.Error("Error count bids for tender", zap.Error(err))
+		return nil, err
+	}
+
+	bidQuery := `
+		SELECT b.id, b.name, b.description, b.status, b.author_type, ` + bidAuthorIdColumn + `, b.created_at, v.version_number, ` +
+		bidTermsColumns("b") + `,` + searchMatchColumns("b", 4) + from + keysetCondition("b", params.Page, &args) + `
+		ORDER BY ` + searchOrder("b", 4) + limitOffset(params.Page, &args)
 
-	rows, err := p.db.QueryContext(ctx, bidQuery, tenderId, userID, params.Limit, params.Offset)
+	rows, err := p.db.QueryContext(ctx, bidQuery, args...)
 	if err != nil {
 		p.logger.Error("Error get list of bids for tender()", zap.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
 
-	var bids []*models.Bid
+	page := &models.Page[*models.Bid]{Items: []*models.Bid{}, TotalCount: total}
 
 	for rows.Next() {
 		var bid models.Bid
-		err := rows.Scan(
+		var terms bidTerms
+		var match searchMatch
+		dest := append([]any{
 			&bid.Id,
 			&bid.Name,
 			&bid.Description,
@@ -241,12 +346,16 @@ func (p *Postgres) GetBidsForTender(ctx context.Context, tenderId repos.TenderId
 			&bid.AuthorId,
 			&bid.CreatedAt,
 			&bid.Version,
-		)
+		}, terms.dest()...)
+		err := rows.Scan(append(dest, match.dest()...)...)
 		if err != nil {
 			p.logger.Error("Error rows.Scan()", zap.Error(err))
 			return nil, err
 		}
-		bids = append(bids, &bid)
+		terms.apply(&bid)
+		bid.TenderId = tenderId
+		bid.Match = match.model()
+		page.Items = append(page.Items, &bid)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -254,63 +363,89 @@ func (p *Postgres) GetBidsForTender(ctx context.Context, tenderId repos.TenderId
 		return nil, err
 	}
 
+	return page, nil
+}
+
+// GetBidsForRanking возвращает опубликованные предложения тендера, упорядоченные по валюте,
+// цене и сроку поставки. Предложения без цены идут в конце. Доступно только ответственным за тендер
+func (p *Postgres) GetBidsForRanking(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.Bid, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error check tender responsible", zap.Error(err))
+		return nil, err
+	}
+
+	bids, err := p.getPublishedBids(ctx, p.db, tenderId)
+	if err != nil {
+		p.logger.Error("Error get bids for ranking", zap.Error(err))
+		return nil, err
+	}
 	return bids, nil
 }
 
-func (p *Postgres) GetBidStatus(ctx context.Context, bidId repos.BidId, params repos.GetBidStatusParams) (repos.BidStatus, error) {
-	// Проверяем, существует ли предложение с данным bidId
-	var status repos.BidStatus
-	query := `SELECT status FROM bids WHERE id = $1`
-	err := p.db.QueryRowContext(ctx, query, bidId).Scan(&status)
-	if err == sql.ErrNoRows {
-		p.logger.Error("Bid not found")
-		return "", ErrBidNotFound
-	} else if err != nil {
-		p.logger.Error("Error get bid by id", zap.Error(err))
-		return "", err
+// getPublishedBids опубликованные предложения тендера: по валюте, цене, сроку поставки и времени подачи
+func (p *Postgres) getPublishedBids(ctx context.Context, q querier, tenderId repos.TenderId) ([]*models.Bid, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, `+bidAuthorIdColumn+`, b.created_at,
+			(SELECT COALESCE(MAX(version_number), 0) FROM bid_versions WHERE bid_id = b.id), `+bidTermsColumns("b")+`
+		FROM bids b
+		WHERE b.tender_id = $1 AND b.status = $2
+		ORDER BY b.price_currency NULLS LAST, b.price_amount NULLS LAST, b.delivery_days NULLS LAST, b.created_at, b.id`,
+		tenderId, repos.BidStatusPublished)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return status, nil
+	bids := []*models.Bid{}
+	for rows.Next() {
+		var bid models.Bid
+		var terms bidTerms
+		err := rows.Scan(append([]any{
+			&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.AuthorId, &bid.CreatedAt, &bid.Version},
+			terms.dest()...)...)
+		if err != nil {
+			return nil, err
+		}
+		terms.apply(&bid)
+		bids = append(bids, &bid)
+	}
+
+	return bids, rows.Err()
 }
 
 func (p *Postgres) UpdateBidStatus(ctx context.Context, bidId repos.BidId, params repos.UpdateBidStatusParams) (*models.Bid, error) {
-	var currentStatus repos.BidStatus
-	query := `SELECT status FROM bids WHERE id = $1`
-	err := p.db.QueryRowContext(ctx, query, bidId).Scan(&currentStatus)
-	if err == sql.ErrNoRows {
-		p.logger.Error("Bid not found")
-		return nil, ErrBidNotFound
-	} else if err != nil {
-		p.logger.Error("Error get bid by id", zap.Error(err))
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
 		return nil, err
 	}
 
-	var orgId uuid.UUID
-	userCheckQuery := `
-		SELECT org.organization_id 
-		FROM organization_responsible org 
-		JOIN tenders t ON org.organization_id = t.organization_id
-		JOIN bids b ON t.id = b.tender_id
-		JOIN employee e ON e.id = org.user_id
-		WHERE e.username = $1 AND b.id = $2`
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
-	err = p.db.QueryRowContext(ctx, userCheckQuery, params.Username, bidId).Scan(&orgId)
-	if err == sql.ErrNoRows {
-		p.logger.Error("User not allowed")
-		return nil, ErrUserNotAllowed
-	} else if err != nil {
-		p.logger.Error("Error user exists and check org responsible", zap.Error(err))
+	// Статус предложения меняет только его автор, решения владельца тендера идут через SubmitBidDecision
+	editorId, err := p.checkBidEditor(ctx, tx, bidId, params.Username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
 		return nil, err
 	}
 
+	// Обновляем только если статус не успели поменять с момента проверки перехода
 	updateQuery := `
-		UPDATE bids SET status = $1, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $2 RETURNING id, name, description, status, tender_id, author_type, author_id, created_at`
+		UPDATE bids b SET status = $1, updated_at = CURRENT_TIMESTAMP 
+		WHERE b.id = $2 AND b.status = $3
+		RETURNING b.id, b.name, b.description, b.status, b.tender_id, b.author_type, ` + bidAuthorIdColumn + `, b.created_at,
+			(SELECT COALESCE(MAX(version_number), 0) FROM bid_versions WHERE bid_id = $2), ` + bidTermsColumns("b")
 
-	row := p.db.QueryRowContext(ctx, updateQuery, params.Status, bidId)
+	row := tx.QueryRowContext(ctx, updateQuery, params.Status, bidId, params.CurrentStatus)
 
 	var bid models.Bid
-	err = row.Scan(
+	var terms bidTerms
+	err = row.Scan(append([]any{
 		&bid.Id,
 		&bid.Name,
 		&bid.Description,
@@ -319,49 +454,84 @@ func (p *Postgres) UpdateBidStatus(ctx context.Context, bidId repos.BidId, param
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.CreatedAt,
-	)
-	if err != nil {
+		&bid.Version,
+	}, terms.dest()...)...)
+	if err == sql.ErrNoRows {
+		p.logger.Error("Bid status changed concurrently")
+		err = ErrBidStatusChanged
+		return nil, err
+	} else if err != nil {
 		p.logger.Error("Error row.Scan()", zap.Error(err))
 		return nil, err
 	}
+	terms.apply(&bid)
 
-	// Если статус "Approved", то закрываем тендер
-	if params.Status == repos.BidStatus("Approved") {
-		closeTenderQuery := `UPDATE tenders SET status = 'Closed', updated_at = CURRENT_TIMESTAMP WHERE id = $1`
-		_, err = p.db.ExecContext(ctx, closeTenderQuery, bid.TenderId)
-		if err != nil {
-			p.logger.Error("Error update bid to Closed status", zap.Error(err))
-			return nil, err
-		}
+	// Смена статуса - новая версия: иначе If-Match, полученный до публикации или отмены, оставался бы действительным
+	bid.Version, err = p.snapshotBid(ctx, tx, bidId, &editorId, nil, nil)
+	if err != nil {
+		p.logger.Error("Error inserting bid version", zap.Error(err))
+		return nil, err
+	}
+
+	// Отзыв предложения учитывается в репутации автора
+	err = p.refreshBidReputation(ctx, tx, bidId)
+	if err != nil {
+		p.logger.Error("Error refresh bidder reputation", zap.Error(err))
+		return nil, err
+	}
+
+	eventType := models.EventBidUpdated
+	switch params.Status {
+	case repos.BidStatusPublished:
+		eventType = models.EventBidSubmitted
+	case repos.BidStatusCanceled:
+		eventType = models.EventBidCanceled
+	}
+	err = p.emitBidEvent(ctx, tx, eventType, bidId, &params.CurrentStatus)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
 	}
 
 	return &bid, nil
 }
 
 func (p *Postgres) EditBid(ctx context.Context, bidId repos.BidId, username repos.Username, params repos.EditBidParams) (*models.Bid, error) {
-	var bid models.Bid
-	var authorId repos.BidAuthorId
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
 	// Проверяем существование бида
-	err := p.db.QueryRowContext(ctx, `SELECT id, author_id, name, description, status, tender_id, author_type, created_at FROM bids WHERE id = $1`, bidId).Scan(
-		&bid.Id, &bid.AuthorId, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.CreatedAt)
+	bid, err := p.getBid(ctx, tx, bidId, true)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			p.logger.Error("Bid not found")
-			return nil, ErrBidNotFound
-		}
+		p.logger.Error("Error get bid", zap.Error(err))
 		return nil, err
 	}
 
-	err = p.db.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&authorId)
+	editorId, err := p.checkBidEditor(ctx, tx, bidId, username)
 	if err != nil {
-		p.logger.Error("User not found")
-		return nil, ErrUserNotFound
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
 	}
 
-	if bid.AuthorId != authorId {
-		p.logger.Error("Not author, declined")
-		return nil, ErrNotAuthor
+	if params.ExpectedVersion != nil && *params.ExpectedVersion != bid.Version {
+		p.logger.Error("Bid version conflict", zap.Int32("expected", *params.ExpectedVersion), zap.Int32("current", bid.Version))
+		err = ErrVersionConflict
+		return nil, err
 	}
 
 	if params.Name != nil {
@@ -370,34 +540,46 @@ func (p *Postgres) EditBid(ctx context.Context, bidId repos.BidId, username repo
 	if params.Description != nil {
 		bid.Description = *params.Description
 	}
+	if params.Price != nil {
+		bid.Price = params.Price
+	}
+	if params.DeliveryDays != nil {
+		bid.DeliveryDays = params.DeliveryDays
+	}
+	if params.SealedPayload != nil {
+		bid.SealedPayload = params.SealedPayload
+	}
 
-	_, err = p.db.ExecContext(ctx, `
+	priceAmount, priceCurrency := moneyArgs(bid.Price)
+	_, err = tx.ExecContext(ctx, `
         UPDATE bids 
-        SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $3`, bid.Name, bid.Description, bidId)
+        SET name = $1, description = $2, price_amount = $3, price_currency = $4, delivery_days = $5, sealed_payload = $6,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $7`, bid.Name, bid.Description, priceAmount, priceCurrency, bid.DeliveryDays, bid.SealedPayload, bidId)
 	if err != nil {
 		p.logger.Error("Error updating bid", zap.Error(err))
 		return nil, err
 	}
 
-	var versionNumber int32
-	err = p.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version_number), 0) + 1 FROM bid_versions WHERE bid_id = $1`, bidId).Scan(&versionNumber)
+	bid.Version, err = p.snapshotBid(ctx, tx, bidId, &editorId, nil, nil)
 	if err != nil {
-		p.logger.Error("Error fetching bid version", zap.Error(err))
+		p.logger.Error("Error inserting bid version", zap.Error(err))
 		return nil, err
 	}
 
-	_, err = p.db.ExecContext(ctx, `
-        INSERT INTO bid_versions (bid_id, version_number, author_id, status, created_at, is_current)
-        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, TRUE)`,
-		bidId, versionNumber, bid.AuthorId, bid.Status)
+	err = p.emitBidEvent(ctx, tx, models.EventBidUpdated, bidId, nil)
 	if err != nil {
-		p.logger.Error("Error inserting bid version", zap.Error(err))
+		p.logger.Error("Error emit bid event", zap.Error(err))
 		return nil, err
 	}
 
-	bid.Version = versionNumber
-	return &bid, nil
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return bid, nil
 }
 
 func (p *Postgres) GetBidsByUsername(ctx context.Context, username repos.Username) ([]*models.Bid, error) {
@@ -430,104 +612,325 @@ func (p *Postgres) GetBidsByUsername(ctx context.Context, username repos.Usernam
 }
 
 func (p *Postgres) GetBidByID(ctx context.Context, bidId repos.BidId) (*models.Bid, error) {
+	bid, err := p.getBid(ctx, p.db, bidId, false)
+	if err != nil {
+		if err != ErrBidNotFound {
+			p.logger.Error("Error get bid by id", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+func (p *Postgres) GetBid(ctx context.Context, bidId repos.BidId, username repos.Username) (*models.Bid, error) {
+	_, err := p.checkBidEditor(ctx, p.db, bidId, username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	return p.GetBidByID(ctx, bidId)
+}
+
+// getBid возвращает предложение вместе с номером текущей версии.
+// forUpdate блокирует строку предложения до конца транзакции.
+func (p *Postgres) getBid(ctx context.Context, q querier, bidId repos.BidId, forUpdate bool) (*models.Bid, error) {
 	var bid models.Bid
 
-	err := p.db.QueryRowContext(ctx, `SELECT id, name, description, status, tender_id, author_type, author_id, created_at FROM bids WHERE id = $1`, bidId).Scan(
-		&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.AuthorId, &bid.CreatedAt)
+	query := `
+		SELECT b.id, b.name, b.description, b.status, b.tender_id, b.author_type, ` + bidAuthorIdColumn + `, b.created_at,
+			(SELECT COALESCE(MAX(version_number), 0) FROM bid_versions WHERE bid_id = b.id), ` + bidTermsColumns("b") + `
+		FROM bids b
+		WHERE b.id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE OF b`
+	}
+
+	var terms bidTerms
+	err := q.QueryRowContext(ctx, query, bidId).Scan(append([]any{
+		&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.AuthorId, &bid.CreatedAt, &bid.Version},
+		terms.dest()...)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			p.logger.Error("Bid not found")
 			return nil, ErrBidNotFound
 		}
 		return nil, err
 	}
+	terms.apply(&bid)
 
 	return &bid, nil
 }
 
 func (p *Postgres) SubmitBidDecision(ctx context.Context, bidId repos.BidId, params repos.SubmitBidDecisionParams) (*models.Bid, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
 	var bid models.Bid
+	var organizationId repos.OrganizationId
 
-	err := p.db.QueryRowContext(ctx, `SELECT id, author_id, status, tender_id FROM bids WHERE id = $1`, bidId).Scan(&bid.Id, &bid.AuthorId, &bid.Status, &bid.TenderId)
+	// Блокируем строку предложения, чтобы параллельные решения считали кворум последовательно
+	err = tx.QueryRowContext(ctx, `
+		SELECT b.id, b.status, b.tender_id, t.organization_id
+		FROM bids b
+		JOIN tenders t ON t.id = b.tender_id
+		WHERE b.id = $1
+		FOR UPDATE OF b`, bidId).Scan(&bid.Id, &bid.Status, &bid.TenderId, &organizationId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			p.logger.Error("Bid not found")
-			return nil, ErrBidNotFound
+			err = ErrBidNotFound
+			return nil, err
 		}
+		p.logger.Error("Error get bid by id", zap.Error(err))
 		return nil, err
 	}
 
-	var organizationId repos.OrganizationId
-	err = p.db.QueryRowContext(ctx, `SELECT organization_id FROM tenders WHERE id = $1`, bid.TenderId).Scan(&organizationId)
+	var userId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, params.Username).Scan(&userId)
+	if err != nil {
+		p.logger.Error("User not found")
+		err = ErrUserNotFound
+		return nil, err
+	}
+
+	var isResponsible bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_responsible
+			WHERE user_id = $1 AND organization_id = $2
+		)`, userId, organizationId).Scan(&isResponsible)
+	if err != nil {
+		p.logger.Error("Error checking user authorization", zap.Error(err))
+		return nil, err
+	}
+	if !isResponsible {
+		p.logger.Error("User not authorized to make a decision")
+		err = ErrUserNotAllowed
+		return nil, err
+	}
+
+	if bid.Status == models.BidStatus("Approved") || bid.Status == models.BidStatus("Rejected") {
+		p.logger.Error("Bid already decided", zap.String("status", string(bid.Status)))
+		err = ErrBidAlreadyDecided
+		return nil, err
+	}
+	// Сервис проверяет статус до транзакции: автор мог успеть отменить предложение
+	if bid.Status != models.BidStatus(repos.BidStatusPublished) {
+		p.logger.Error("Bid is not published", zap.String("status", string(bid.Status)))
+		err = ErrBidStatusChanged
+		return nil, err
+	}
+
+	var decisionId int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bid_decisions (bid_id, author_id, decision, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (bid_id, author_id) DO NOTHING
+		RETURNING id`, bidId, userId, params.Decision).Scan(&decisionId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			p.logger.Error("Tender not found")
-			return nil, ErrTenderNotFound
+			p.logger.Error("Decision already submitted by user")
+			err = ErrDecisionAlreadySubmitted
+			return nil, err
 		}
+		p.logger.Error("Error insert bid decision", zap.Error(err))
 		return nil, err
 	}
 
-	var userId repos.BidAuthorId
-	err = p.db.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, params.Username).Scan(&userId)
+	tally, err := p.getBidDecisionTally(ctx, tx, bidId, organizationId)
 	if err != nil {
-		p.logger.Error("User not found")
-		return nil, ErrUserNotFound
+		p.logger.Error("Error get bid decision tally", zap.Error(err))
+		return nil, err
 	}
 
-	var orgId repos.OrganizationId
-	userCheckQuery := `
-		SELECT o.organization_id
-		FROM organization_responsible o
-		JOIN employee e ON o.user_id = e.id
-		WHERE e.id = $1 AND o.organization_id = $2`
+	// Одного отказа достаточно, чтобы отклонить предложение,
+	// одобрение - только при достижении кворума
+	var newStatus models.BidStatus
+	switch {
+	case tally.Rejected > 0:
+		newStatus = models.BidStatus("Rejected")
+	case tally.Approved >= tally.Quorum:
+		newStatus = models.BidStatus("Approved")
+	}
 
-	err = p.db.QueryRowContext(ctx, userCheckQuery, userId, organizationId).Scan(&orgId)
+	if newStatus != "" {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE bids SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, newStatus, bidId)
+		if err != nil {
+			p.logger.Error("Error updating bid status", zap.Error(err))
+			return nil, err
+		}
+
+		decision := models.BidDecision(newStatus)
+		_, err = p.snapshotBid(ctx, tx, bidId, &userId, &decision, nil)
+		if err != nil {
+			p.logger.Error("Error inserting bid version", zap.Error(err))
+			return nil, err
+		}
+
+		err = p.emitBidEvent(ctx, tx, models.EventBidDecided, bidId, nil)
+		if err != nil {
+			p.logger.Error("Error emit bid event", zap.Error(err))
+			return nil, err
+		}
+
+		// Тендер закрывается в той же транзакции, что и одобрение предложения
+		if newStatus == models.BidStatus("Approved") {
+			var tenderStatus repos.TenderStatus
+			err = tx.QueryRowContext(ctx, `
+				SELECT status FROM tenders WHERE id = $1 FOR UPDATE`, bid.TenderId).Scan(&tenderStatus)
+			if err != nil {
+				p.logger.Error("Error get tender status", zap.Error(err))
+				return nil, err
+			}
+
+			// Запечатанный тендер решается уже после закрытия, у него победитель тоже может быть только один
+			var awarded bool
+			err = tx.QueryRowContext(ctx, `
+				SELECT EXISTS(SELECT 1 FROM bids WHERE tender_id = $1 AND status = 'Approved' AND id <> $2)`, bid.TenderId, bidId).Scan(&awarded)
+			if err != nil {
+				p.logger.Error("Error check tender approved bids", zap.Error(err))
+				return nil, err
+			}
+			if awarded {
+				p.logger.Error("Tender already awarded", zap.String("tenderId", bid.TenderId))
+				err = ErrTenderAlreadyAwarded
+				return nil, err
+			}
+
+			if tenderStatus != repos.TenderStatusClosed {
+				_, err = tx.ExecContext(ctx, `
+					UPDATE tenders SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, repos.TenderStatusClosed, bid.TenderId)
+				if err != nil {
+					p.logger.Error("Error update tender to Closed status", zap.Error(err))
+					return nil, err
+				}
+
+				err = p.recordTenderTransition(ctx, tx, bid.TenderId, &tenderStatus, repos.TenderStatusClosed, &userId)
+				if err != nil {
+					p.logger.Error("Error record tender status transition", zap.Error(err))
+					return nil, err
+				}
+			}
+
+			err = p.refreshTenderReputation(ctx, tx, bid.TenderId)
+		} else {
+			err = p.refreshBidReputation(ctx, tx, bidId)
+		}
+		if err != nil {
+			p.logger.Error("Error refresh bidder reputation", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	var terms bidTerms
+	err = tx.QueryRowContext(ctx, `
+		SELECT b.id, `+bidAuthorIdColumn+`, b.name, b.description, b.status, b.tender_id, b.author_type, b.created_at,
+			(SELECT COALESCE(MAX(version_number), 0) FROM bid_versions WHERE bid_id = b.id), `+bidTermsColumns("b")+`
+		FROM bids b
+		WHERE b.id = $1`, bidId).Scan(append([]any{
+		&bid.Id, &bid.AuthorId, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorType, &bid.CreatedAt, &bid.Version},
+		terms.dest()...)...)
+	if err != nil {
+		p.logger.Error("Error get updated bid", zap.Error(err))
+		return nil, err
+	}
+	terms.apply(&bid)
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	bid.Decisions = tally
+	return &bid, nil
+}
+
+func (p *Postgres) GetBidDecisions(ctx context.Context, bidId repos.BidId, username repos.Username) (*models.BidDecisionTally, error) {
+	var tenderId repos.TenderId
+	var organizationId repos.OrganizationId
+	err := p.db.QueryRowContext(ctx, `
+		SELECT b.tender_id, t.organization_id
+		FROM bids b
+		JOIN tenders t ON t.id = b.tender_id
+		WHERE b.id = $1`, bidId).Scan(&tenderId, &organizationId)
 	if err == sql.ErrNoRows {
-		p.logger.Error("User not authorized to make a decision")
-		return nil, ErrUserNotAllowed
+		return nil, ErrBidNotFound
 	} else if err != nil {
-		p.logger.Error("Error checking user authorization", zap.Error(err))
+		p.logger.Error("Error get bid tender", zap.Error(err))
 		return nil, err
 	}
 
-	updateQuery := `
-		UPDATE bids 
-		SET status = $1, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $2 
-		RETURNING id, author_id, name, description, status, tender_id, author_type, created_at`
-
-	row := p.db.QueryRowContext(ctx, updateQuery, params.Decision, bidId)
+	_, err = p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
 
-	err = row.Scan(
-		&bid.Id,
-		&bid.AuthorId,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderId,
-		&bid.AuthorType,
-		&bid.CreatedAt,
-	)
+	tally, err := p.getBidDecisionTally(ctx, p.db, bidId, organizationId)
 	if err != nil {
-		p.logger.Error("Error updating bid status", zap.Error(err))
+		p.logger.Error("Error get bid decision tally", zap.Error(err))
 		return nil, err
 	}
+	return tally, nil
+}
 
-	var version int32
-	insertVersionQuery := `
-		INSERT INTO bid_versions (bid_id, version_number, status) 
-		VALUES ($1, (SELECT COALESCE(MAX(version_number), 0) + 1 FROM bid_versions WHERE bid_id = $1), $2)
-		RETURNING version_number`
+// getBidDecisionTally собирает решения ответственных по предложению и считает кворум:
+// min(maxDecisionQuorum, количество ответственных организации тендера)
+func (p *Postgres) getBidDecisionTally(ctx context.Context, q querier, bidId repos.BidId, organizationId repos.OrganizationId) (*models.BidDecisionTally, error) {
+	tally := &models.BidDecisionTally{
+		Votes: []models.BidMemberDecision{},
+	}
 
-	err = p.db.QueryRowContext(ctx, insertVersionQuery, bidId, params.Decision).Scan(&version)
+	err := q.QueryRowContext(ctx, `
+		SELECT LEAST($1, COUNT(*))
+		FROM organization_responsible
+		WHERE organization_id = $2`, maxDecisionQuorum, organizationId).Scan(&tally.Quorum)
 	if err != nil {
-		p.logger.Error("Error inserting bid version", zap.Error(err))
 		return nil, err
 	}
 
-	bid.Version = version
+	rows, err := q.QueryContext(ctx, `
+		SELECT e.username, d.decision, d.created_at
+		FROM bid_decisions d
+		JOIN employee e ON e.id = d.author_id
+		WHERE d.bid_id = $1
+		ORDER BY d.created_at`, bidId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	return &bid, nil
+	for rows.Next() {
+		var vote models.BidMemberDecision
+		if err := rows.Scan(&vote.Username, &vote.Decision, &vote.CreatedAt); err != nil {
+			return nil, err
+		}
+		switch vote.Decision {
+		case models.BidDecision("Approved"):
+			tally.Approved++
+		case models.BidDecision("Rejected"):
+			tally.Rejected++
+		}
+		tally.Votes = append(tally.Votes, vote)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tally, nil
 }
 
 func (p *Postgres) GetBidReviews(ctx context.Context, tenderId repos.TenderId, params repos.GetBidReviewsParams) ([]*models.BidReview, error) {
@@ -564,7 +967,7 @@ func (p *Postgres) GetBidReviews(ctx context.Context, tenderId repos.TenderId, p
 
 	// Валидируем пользователя как ответственного за организацию и владельца тендера
 	err = p.db.QueryRowContext(ctx, `
-        SELECT o.id 
+        SELECT e.id
         FROM organization_responsible r
         JOIN tenders t ON t.organization_id = r.organization_id
         JOIN employee e ON e.id = r.user_id
@@ -574,12 +977,13 @@ func (p *Postgres) GetBidReviews(ctx context.Context, tenderId repos.TenderId, p
 		return nil, ErrUserNotAllowed
 	}
 
-	// Получаем список отзывов на биды
+	// Получаем список отзывов на биды. Ответы в ветках и удаленные отзывы отзывами не считаются
 	rows, err := p.db.QueryContext(ctx, `
-        SELECT f.id, f.description, f.created_at 
+        SELECT f.id, f.description, f.rating, f.created_at 
         FROM bid_feedbacks f 
         JOIN bids b ON b.id = f.bid_id 
-        WHERE b.author_id = $1 AND b.tender_id = $2`, authorId, tenderId)
+        WHERE b.author_id = $1 AND b.tender_id = $2
+        AND f.parent_id IS NULL AND f.deleted_at IS NULL`, authorId, tenderId)
 	if err != nil {
 		p.logger.Error("Error get list of bid reviews", zap.Error(err))
 		return nil, err
@@ -588,7 +992,7 @@ func (p *Postgres) GetBidReviews(ctx context.Context, tenderId repos.TenderId, p
 
 	for rows.Next() {
 		var feedback models.BidReview
-		err := rows.Scan(&feedback.Id, &feedback.Description, &feedback.CreatedAt)
+		err := rows.Scan(&feedback.Id, &feedback.Description, &feedback.Rating, &feedback.CreatedAt)
 		if err != nil {
 			p.logger.Error("Error rows.Scan()", zap.Error(err))
 			return nil, err
@@ -598,135 +1002,3 @@ func (p *Postgres) GetBidReviews(ctx context.Context, tenderId repos.TenderId, p
 
 	return reviews, nil
 }
-
-func (p *Postgres) SubmitBidFeedback(ctx context.Context, bidId repos.BidId, params repos.SubmitBidFeedbackParams) (*models.Bid, error) {
-	var bidExists bool
-	var authorId repos.BidAuthorId
-	var bid models.Bid
-	var versionNumber int32
-
-	err := p.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidId).Scan(&bidExists)
-	if err != nil {
-		p.logger.Error("Error check if bid exists", zap.Error(err))
-		return nil, err
-	}
-	if !bidExists {
-		p.logger.Error("Bid not found")
-		return nil, ErrBidNotFound
-	}
-
-	err = p.db.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, params.Username).Scan(&authorId)
-	if err != nil {
-		p.logger.Error("User not found")
-		return nil, ErrUserNotFound
-	}
-
-	_, err = p.db.ExecContext(ctx, `
-        INSERT INTO bid_feedbacks (bid_id, author_id, description, created_at) 
-        VALUES ($1, $2, $3, CURRENT_TIMESTAMP)`, bidId, authorId, params.BidFeedback)
-	if err != nil {
-		p.logger.Error("Error insert new review", zap.Error(err))
-		return nil, err
-	}
-
-	err = p.db.QueryRowContext(ctx, `SELECT version_number FROM bid_versions WHERE bid_id = $1 AND is_current = TRUE`, bidId).Scan(&versionNumber)
-	if err != nil {
-		p.logger.Error("Error retrieving current bid version", zap.Error(err))
-		return nil, err
-	}
-
-	_, err = p.db.ExecContext(ctx, `UPDATE bids SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, bidId)
-	if err != nil {
-		p.logger.Error("Error update bid status", zap.Error(err))
-		return nil, err
-	}
-
-	err = p.db.QueryRowContext(ctx, `
-		SELECT id, name, description, status, tender_id, author_id, author_type, created_at
-		FROM bids WHERE id = $1`, bidId).Scan(
-		&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.AuthorId, &bid.AuthorType, &bid.CreatedAt)
-	if err != nil {
-		p.logger.Error("Error get updated bid", zap.Error(err))
-		return nil, err
-	}
-
-	bid.Version = versionNumber
-
-	return &bid, nil
-}
-
-func (p *Postgres) RollbackBid(ctx context.Context, bidId repos.BidId, version int32, params repos.RollbackBidParams) (*models.Bid, error) {
-	tx, err := p.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-
-	var bidExists bool
-	var bid models.Bid
-
-	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE id = $1)`, bidId).Scan(&bidExists)
-	if err != nil {
-		p.logger.Error("Error check if bid exists", zap.Error(err))
-		return nil, err
-	}
-	if !bidExists {
-		p.logger.Error("Bid not found")
-		return nil, ErrBidNotFound
-	}
-
-	err = tx.QueryRowContext(ctx, `
-        SELECT status 
-        FROM bid_versions 
-        WHERE bid_id = $1 AND version_number = $2`, bidId, version).Scan(&bid.Status)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			p.logger.Error("Version not found")
-			return nil, ErrVersionNotFound
-		}
-		p.logger.Error("Error check if version exists", zap.Error(err))
-		return nil, err
-	}
-
-	_, err = tx.ExecContext(ctx, `
-        UPDATE bids 
-        SET name = $1, description = $2, status = $3, updated_at = CURRENT_TIMESTAMP 
-        WHERE id = $4`, bid.Name, bid.Description, bid.Status, bidId)
-	if err != nil {
-		p.logger.Error("Error rollback to version", zap.Error(err))
-		return nil, err
-	}
-
-	_, err = tx.ExecContext(ctx, `
-        UPDATE bid_versions
-        SET is_current = FALSE
-        WHERE bid_id = $1 AND is_current = TRUE`, bidId)
-	if err != nil {
-		p.logger.Error("Error setting previous version to not current", zap.Error(err))
-		return nil, err
-	}
-
-	// Создаем новую версию как текущую
-	_, err = tx.ExecContext(ctx, `
-        INSERT INTO bid_versions (bid_id, version_number, author_id, status, created_at, is_current) 
-        VALUES ($1, 
-                (SELECT COALESCE(MAX(version_number), 0) + 1 FROM bid_versions WHERE bid_id = $1), 
-                (SELECT author_id FROM bids WHERE id = $1), 
-                $2, CURRENT_TIMESTAMP, TRUE)`,
-		bidId, bid.Status)
-	if err != nil {
-		p.logger.Error("Error creating new current version", zap.Error(err))
-		return nil, err
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
-	return &bid, nil
-}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// bidSnapshotColumns содержимое предложения, которое попадает в каждую версию.
+// Новое поле предложения достаточно добавить в bid_versions и в этот список.
+var bidSnapshotColumns = []string{"name", "description", "status", "tender_id", "author_type", "organization_id", "price_amount", "price_currency", "delivery_days", "sealed_payload"}
+
+// snapshotBid делает текущую версию неактуальной и сохраняет новую версию
+// с полным текущим содержимым предложения и набором его вложений. Должна вызываться в той же транзакции, что и изменение.
+func (p *Postgres) snapshotBid(ctx context.Context, q querier, bidId repos.BidId, editorId *int, decision *models.BidDecision, rolledBackFrom *int32) (int32, error) {
+	_, err := q.ExecContext(ctx, `
+        UPDATE bid_versions
+        SET is_current = FALSE
+        WHERE bid_id = $1 AND is_current = TRUE`, bidId)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := strings.Join(bidSnapshotColumns, ", ")
+	values := "b." + strings.Join(bidSnapshotColumns, ", b.")
+
+	var version int32
+	err = q.QueryRowContext(ctx, `
+        INSERT INTO bid_versions (bid_id, version_number, author_id, edited_by, decision, rolled_back_from, created_at, is_current, attachment_ids, `+columns+`)
+        SELECT b.id,
+            (SELECT COALESCE(MAX(version_number), 0) + 1 FROM bid_versions WHERE bid_id = b.id),
+            b.author_id, $2, $3, $4, CURRENT_TIMESTAMP, TRUE, `+bidAttachments.snapshotIds("b")+`, `+values+`
+        FROM bids b
+        WHERE b.id = $1
+        RETURNING version_number`, bidId, editorId, decision, rolledBackFrom).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (p *Postgres) RollbackBid(ctx context.Context, bidId repos.BidId, version int32, params repos.RollbackBidParams) (*models.Bid, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	bid, err := p.getBid(ctx, tx, bidId, true)
+	if err != nil {
+		p.logger.Error("Error get bid", zap.Error(err))
+		return nil, err
+	}
+
+	editorId, err := p.checkBidEditor(ctx, tx, bidId, params.Username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	if params.ExpectedVersion != nil && *params.ExpectedVersion != bid.Version {
+		p.logger.Error("Bid version conflict", zap.Int32("expected", *params.ExpectedVersion), zap.Int32("current", bid.Version))
+		err = ErrVersionConflict
+		return nil, err
+	}
+
+	var name models.BidName
+	var description models.BidDescription
+	var terms bidTerms
+	err = tx.QueryRowContext(ctx, `
+        SELECT v.name, v.description, `+bidTermsColumns("v")+`
+        FROM bid_versions v
+        WHERE v.bid_id = $1 AND v.version_number = $2`, bidId, version).Scan(append([]any{&name, &description}, terms.dest()...)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			p.logger.Error("Version not found")
+			err = ErrVersionNotFound
+			return nil, err
+		}
+		p.logger.Error("Error check if version exists", zap.Error(err))
+		return nil, err
+	}
+
+	// Откат восстанавливает содержимое и вложения предложения, статус меняется только через переходы жизненного цикла
+	priceAmount, priceCurrency := moneyArgs(terms.price.model())
+	_, err = tx.ExecContext(ctx, `
+        UPDATE bids 
+        SET name = $1, description = $2, price_amount = $3, price_currency = $4, delivery_days = $5, sealed_payload = $6,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $7`, name, description, priceAmount, priceCurrency, terms.deliveryDays, terms.sealed, bidId)
+	if err != nil {
+		p.logger.Error("Error rollback to version", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.restoreAttachments(ctx, tx, bidAttachments, bidId, version)
+	if err != nil {
+		p.logger.Error("Error restore bid attachments", zap.Error(err))
+		return nil, err
+	}
+
+	newVersion, err := p.snapshotBid(ctx, tx, bidId, &editorId, nil, &version)
+	if err != nil {
+		p.logger.Error("Error creating new current version", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitBidEvent(ctx, tx, models.EventBidUpdated, bidId, nil)
+	if err != nil {
+		p.logger.Error("Error emit bid event", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	bid.Name = name
+	bid.Description = description
+	terms.apply(bid)
+	bid.Version = newVersion
+	return bid, nil
+}
+
+// checkBidEditor проверяет, что пользователь действует от имени автора предложения:
+// для предложения от пользователя это сам автор, для предложения от организации - любой ее ответственный.
+// Возвращает id пользователя
+func (p *Postgres) checkBidEditor(ctx context.Context, q querier, bidId repos.BidId, username repos.Username) (int, error) {
+	var authorId int
+	var organizationId sql.NullString
+	err := q.QueryRowContext(ctx, `SELECT author_id, organization_id FROM bids WHERE id = $1`, bidId).Scan(&authorId, &organizationId)
+	if err == sql.ErrNoRows {
+		return 0, ErrBidNotFound
+	} else if err != nil {
+		return 0, err
+	}
+
+	if organizationId.Valid {
+		userId, err := p.checkOrganizationMember(ctx, q, organizationId.String, username)
+		if err == ErrUserNotAllowed {
+			return 0, ErrNotAuthor
+		}
+		return userId, err
+	}
+
+	var userId int
+	err = q.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotFound
+	} else if err != nil {
+		return 0, err
+	}
+
+	if userId != authorId {
+		return 0, ErrNotAuthor
+	}
+
+	return userId, nil
+}
+
+func (p *Postgres) GetBidVersions(ctx context.Context, bidId repos.BidId, params repos.GetBidVersionsParams) ([]*models.BidVersionInfo, error) {
+	_, err := p.checkBidEditor(ctx, p.db, bidId, params.Username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT v.version_number, v.name, v.description, v.status, v.decision,
+            e.username, v.created_at, v.is_current, v.rolled_back_from, v.attachment_ids, `+bidTermsColumns("v")+`
+        FROM bid_versions v
+        LEFT JOIN employee e ON e.id = v.edited_by
+        WHERE v.bid_id = $1
+        ORDER BY v.version_number DESC
+        LIMIT $2 OFFSET $3`, bidId, params.Limit, params.Offset)
+	if err != nil {
+		p.logger.Error("Error get list of bid versions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*models.BidVersionInfo
+	for rows.Next() {
+		var v models.BidVersionInfo
+		var terms bidTerms
+		err := rows.Scan(append([]any{&v.Version, &v.Name, &v.Description, &v.Status, &v.Decision,
+			&v.AuthorUsername, &v.CreatedAt, &v.IsCurrent, &v.RolledBackFrom, pq.Array(&v.Attachments)}, terms.dest()...)...)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		terms.applyVersion(&v)
+		versions = append(versions, &v)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (p *Postgres) GetBidVersion(ctx context.Context, bidId repos.BidId, version int32, username repos.Username) (*models.BidVersionInfo, error) {
+	_, err := p.checkBidEditor(ctx, p.db, bidId, username)
+	if err != nil {
+		p.logger.Error("Error check bid author", zap.Error(err))
+		return nil, err
+	}
+
+	var v models.BidVersionInfo
+	var terms bidTerms
+	err = p.db.QueryRowContext(ctx, `
+        SELECT v.version_number, v.name, v.description, v.status, v.decision,
+            e.username, v.created_at, v.is_current, v.rolled_back_from, v.attachment_ids, `+bidTermsColumns("v")+`
+        FROM bid_versions v
+        LEFT JOIN employee e ON e.id = v.edited_by
+        WHERE v.bid_id = $1 AND v.version_number = $2`, bidId, version).Scan(append([]any{
+		&v.Version, &v.Name, &v.Description, &v.Status, &v.Decision,
+		&v.AuthorUsername, &v.CreatedAt, &v.IsCurrent, &v.RolledBackFrom, pq.Array(&v.Attachments)}, terms.dest()...)...)
+	if err == sql.ErrNoRows {
+		p.logger.Error("Version not found")
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		p.logger.Error("Error get bid version", zap.Error(err))
+		return nil, err
+	}
+	terms.applyVersion(&v)
+
+	return &v, nil
+}
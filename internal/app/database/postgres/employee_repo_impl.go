@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+func (p *Postgres) GetEmployees(ctx context.Context, params repos.GetEmployeesParams) ([]*models.Employee, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at
+        FROM employee
+        ORDER BY username
+        LIMIT $1 OFFSET $2`, params.Limit, params.Offset)
+	if err != nil {
+		p.logger.Error("Error get employees", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []*models.Employee{}
+	for rows.Next() {
+		var employee models.Employee
+		err := rows.Scan(&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		employees = append(employees, &employee)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+func (p *Postgres) GetEmployee(ctx context.Context, username repos.Username) (*models.Employee, error) {
+	var employee models.Employee
+	err := p.db.QueryRowContext(ctx, `
+        SELECT id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at
+        FROM employee
+        WHERE username = $1`, username).Scan(
+		&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		p.logger.Error("Error get employee", zap.Error(err))
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+func (p *Postgres) CreateEmployee(ctx context.Context, params repos.CreateEmployeeParams) (*models.Employee, error) {
+	var employee models.Employee
+	err := p.db.QueryRowContext(ctx, `
+        INSERT INTO employee (username, first_name, last_name, created_at, updated_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        ON CONFLICT (username) DO NOTHING
+        RETURNING id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at`,
+		params.Username, params.FirstName, params.LastName).Scan(
+		&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrEmployeeAlreadyExists
+	} else if err != nil {
+		p.logger.Error("Error create employee", zap.Error(err))
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+func (p *Postgres) EditEmployee(ctx context.Context, username repos.Username, params repos.EditEmployeeParams) (*models.Employee, error) {
+	var employee models.Employee
+	err := p.db.QueryRowContext(ctx, `
+        UPDATE employee
+        SET first_name = COALESCE($1, first_name), last_name = COALESCE($2, last_name), updated_at = CURRENT_TIMESTAMP
+        WHERE username = $3
+        RETURNING id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at`,
+		params.FirstName, params.LastName, username).Scan(
+		&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		p.logger.Error("Error update employee", zap.Error(err))
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+func (p *Postgres) DeleteEmployee(ctx context.Context, username repos.Username) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var employeeId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1 FOR UPDATE`, username).Scan(&employeeId)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+		return err
+	} else if err != nil {
+		p.logger.Error("Error get employee", zap.Error(err))
+		return err
+	}
+
+	// Блокируем организации сотрудника, чтобы параллельное снятие ответственных
+	// не оставило какую-то из них без единого ответственного
+	var soleResponsible bool
+	err = tx.QueryRowContext(ctx, `
+        WITH orgs AS (
+            SELECT o.id
+            FROM organization o
+            JOIN organization_responsible r ON r.organization_id = o.id
+            WHERE r.user_id = $1
+            FOR UPDATE OF o
+        )
+        SELECT EXISTS(
+            SELECT 1 FROM orgs
+            WHERE (SELECT COUNT(*) FROM organization_responsible r WHERE r.organization_id = orgs.id) = 1)`,
+		employeeId).Scan(&soleResponsible)
+	if err != nil {
+		p.logger.Error("Error check employee organizations", zap.Error(err))
+		return err
+	}
+	if soleResponsible {
+		err = ErrLastResponsible
+		return err
+	}
+
+	// Предложения, решения, оценки, вопросы и отзывы удаляются каскадно вместе с сотрудником,
+	// поэтому удалять сотрудника с такой историей нельзя, как и организацию с тендерами
+	var hasActivity bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM bids WHERE author_id = $1)
+            OR EXISTS(SELECT 1 FROM bid_decisions WHERE author_id = $1)
+            OR EXISTS(SELECT 1 FROM bid_scores WHERE evaluator_id = $1)
+            OR EXISTS(SELECT 1 FROM bid_feedbacks WHERE author_id = $1)
+            OR EXISTS(SELECT 1 FROM tender_questions WHERE asker_id = $1)`,
+		employeeId).Scan(&hasActivity)
+	if err != nil {
+		p.logger.Error("Error check employee activity", zap.Error(err))
+		return err
+	}
+	if hasActivity {
+		err = ErrEmployeeHasActivity
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM employee WHERE id = $1`, employeeId)
+	if err != nil {
+		p.logger.Error("Error delete employee", zap.Error(err))
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCriterionNotFound = errors.New("criterion not found in tender")
+	ErrCriteriaScored    = errors.New("tender criteria already have scores")
+)
+
+const criterionColumns = `c.id, c.tender_id, c.name, c.description, c.kind, c.weight, c.max_score`
+
+const bidScoreColumns = `s.bid_id, s.criterion_id, e.username, s.score, s.comment, s.updated_at`
+
+func (p *Postgres) ReplaceTenderCriteria(ctx context.Context, tenderId repos.TenderId, username repos.Username, criteria []repos.CriterionParams) ([]*models.EvaluationCriterion, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.checkTenderResponsible(ctx, tx, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	// Блокируем тендер: оценки ставятся под разделяемой блокировкой тендера,
+	// поэтому между проверкой и заменой критериев новых оценок не появится
+	err = p.checkTenderVersion(ctx, tx, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error lock tender", zap.Error(err))
+		return nil, err
+	}
+
+	// Смена критериев после начала оценки обесценила бы уже выставленные оценки
+	var scored bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM bid_scores s
+			JOIN tender_criteria c ON c.id = s.criterion_id
+			WHERE c.tender_id = $1
+		)`, tenderId).Scan(&scored)
+	if err != nil {
+		p.logger.Error("Error check tender scores", zap.Error(err))
+		return nil, err
+	}
+	if scored {
+		p.logger.Error("Tender criteria already scored", zap.String("tenderId", tenderId))
+		err = ErrCriteriaScored
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM tender_criteria WHERE tender_id = $1`, tenderId)
+	if err != nil {
+		p.logger.Error("Error delete tender criteria", zap.Error(err))
+		return nil, err
+	}
+
+	for i, c := range criteria {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO tender_criteria (tender_id, name, description, kind, weight, max_score, position)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			tenderId, c.Name, c.Description, *c.Kind, c.Weight, *c.MaxScore, i)
+		if err != nil {
+			p.logger.Error("Error insert tender criterion", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	result, err := p.getTenderCriteria(ctx, tx, tenderId)
+	if err != nil {
+		p.logger.Error("Error get tender criteria", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *Postgres) GetTenderCriteria(ctx context.Context, tenderId repos.TenderId) ([]*models.EvaluationCriterion, error) {
+	criteria, err := p.getTenderCriteria(ctx, p.db, tenderId)
+	if err != nil {
+		p.logger.Error("Error get tender criteria", zap.Error(err))
+		return nil, err
+	}
+	return criteria, nil
+}
+
+func (p *Postgres) getTenderCriteria(ctx context.Context, q querier, tenderId repos.TenderId) ([]*models.EvaluationCriterion, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+criterionColumns+`
+		FROM tender_criteria c
+		WHERE c.tender_id = $1
+		ORDER BY c.position`, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []*models.EvaluationCriterion{}
+	for rows.Next() {
+		var c models.EvaluationCriterion
+		err := rows.Scan(&c.Id, &c.TenderId, &c.Name, &c.Description, &c.Kind, &c.Weight, &c.MaxScore)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, &c)
+	}
+
+	return criteria, rows.Err()
+}
+
+func (p *Postgres) UpsertBidScores(ctx context.Context, bidId repos.BidId, username repos.Username, scores []repos.ScoreParams) ([]*models.BidScore, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	tenderId, evaluatorId, err := p.checkBidEvaluator(ctx, tx, bidId, username, true)
+	if err != nil {
+		p.logger.Error("Error check bid evaluator", zap.Error(err))
+		return nil, err
+	}
+
+	for _, s := range scores {
+		// Критерий берем только из тендера этого предложения
+		var scoreId int
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO bid_scores (bid_id, criterion_id, evaluator_id, score, comment)
+			SELECT $1, c.id, $3, $4, $5
+			FROM tender_criteria c
+			WHERE c.id::text = $2 AND c.tender_id = $6
+			ON CONFLICT (bid_id, criterion_id, evaluator_id)
+			DO UPDATE SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = CURRENT_TIMESTAMP
+			RETURNING id`, bidId, s.CriterionId, evaluatorId, s.Score, s.Comment, tenderId).Scan(&scoreId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				p.logger.Error("Criterion not found", zap.String("criterionId", s.CriterionId))
+				err = ErrCriterionNotFound
+				return nil, err
+			}
+			p.logger.Error("Error upsert bid score", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	result, err := p.getBidScores(ctx, tx, `s.bid_id = $1`, bidId)
+	if err != nil {
+		p.logger.Error("Error get bid scores", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *Postgres) GetBidScores(ctx context.Context, bidId repos.BidId, username repos.Username) ([]*models.BidScore, error) {
+	_, _, err := p.checkBidEvaluator(ctx, p.db, bidId, username, false)
+	if err != nil {
+		p.logger.Error("Error check bid evaluator", zap.Error(err))
+		return nil, err
+	}
+
+	scores, err := p.getBidScores(ctx, p.db, `s.bid_id = $1`, bidId)
+	if err != nil {
+		p.logger.Error("Error get bid scores", zap.Error(err))
+		return nil, err
+	}
+	return scores, nil
+}
+
+// GetTenderScores оценки всех опубликованных предложений тендера
+func (p *Postgres) GetTenderScores(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.BidScore, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	scores, err := p.getBidScores(ctx, p.db, `s.bid_id IN (
+			SELECT id FROM bids WHERE tender_id = $1 AND status = 'Published'
+		)`, tenderId)
+	if err != nil {
+		p.logger.Error("Error get tender scores", zap.Error(err))
+		return nil, err
+	}
+	return scores, nil
+}
+
+// getBidScores оценки по условию where с единственным параметром $1
+func (p *Postgres) getBidScores(ctx context.Context, q querier, where string, arg any) ([]*models.BidScore, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+bidScoreColumns+`
+		FROM bid_scores s
+		JOIN tender_criteria c ON c.id = s.criterion_id
+		JOIN employee e ON e.id = s.evaluator_id
+		WHERE `+where+`
+		ORDER BY s.bid_id, c.position, e.username`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := []*models.BidScore{}
+	for rows.Next() {
+		var s models.BidScore
+		err := rows.Scan(&s.BidId, &s.CriterionId, &s.Evaluator, &s.Score, &s.Comment, &s.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, &s)
+	}
+
+	return scores, rows.Err()
+}
+
+// checkBidEvaluator оценивать предложение могут ответственные за организацию тендера.
+// lockTender берет разделяемую блокировку тендера, чтобы критерии не заменили, пока ставятся оценки
+func (p *Postgres) checkBidEvaluator(ctx context.Context, q querier, bidId repos.BidId, username repos.Username, lockTender bool) (repos.TenderId, int, error) {
+	query := `
+		SELECT t.id, t.organization_id
+		FROM bids b
+		JOIN tenders t ON t.id = b.tender_id
+		WHERE b.id = $1`
+	if lockTender {
+		query += ` FOR SHARE OF t`
+	}
+
+	var tenderId repos.TenderId
+	var organizationId repos.OrganizationId
+	err := q.QueryRowContext(ctx, query, bidId).Scan(&tenderId, &organizationId)
+	if err == sql.ErrNoRows {
+		return "", 0, ErrBidNotFound
+	} else if err != nil {
+		return "", 0, err
+	}
+
+	var userId int
+	err = q.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return "", 0, ErrUserNotFound
+	} else if err != nil {
+		return "", 0, err
+	}
+
+	var isResponsible bool
+	err = q.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_responsible
+			WHERE user_id = $1 AND organization_id = $2
+		)`, userId, organizationId).Scan(&isResponsible)
+	if err != nil {
+		return "", 0, err
+	}
+	if !isResponsible {
+		return "", 0, ErrUserNotAllowed
+	}
+
+	return tenderId, userId, nil
+}
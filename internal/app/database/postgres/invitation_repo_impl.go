@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation not found in tender")
+	ErrAlreadyInvited     = errors.New("organization or employee is already invited to tender")
+	ErrNotInvitee         = errors.New("user is not the invitee")
+)
+
+const invitationColumns = `i.id, i.tender_id, t.name, i.organization_id, ie.username, i.status, ib.username, rb.username, i.responded_at, i.created_at`
+
+const invitationFrom = `
+        FROM tender_invitations i
+        JOIN tenders t ON t.id = i.tender_id
+        LEFT JOIN employee ie ON ie.id = i.employee_id
+        LEFT JOIN employee ib ON ib.id = i.invited_by
+        LEFT JOIN employee rb ON rb.id = i.responded_by`
+
+// invitationInvitee условие "пользователь $n - приглашенный": лично или как ответственный приглашенной организации
+func invitationInvitee(n string) string {
+	return `(i.employee_id = $` + n + ` OR i.organization_id IN (SELECT r.organization_id FROM organization_responsible r WHERE r.user_id = $` + n + `))`
+}
+
+func invitationDest(i *models.TenderInvitation) []any {
+	return []any{&i.Id, &i.TenderId, &i.TenderName, &i.OrganizationId, &i.EmployeeUsername, &i.Status, &i.InvitedBy, &i.RespondedBy, &i.RespondedAt, &i.CreatedAt}
+}
+
+func (p *Postgres) CreateTenderInvitation(ctx context.Context, tenderId repos.TenderId, username repos.Username, organizationId *repos.OrganizationId, employee *repos.Username) (*models.TenderInvitation, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	inviterId, err := p.checkTenderResponsible(ctx, tx, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	var employeeId *int
+	if employee != nil {
+		var id int
+		err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, *employee).Scan(&id)
+		if err == sql.ErrNoRows {
+			err = ErrUserNotFound
+			return nil, err
+		} else if err != nil {
+			p.logger.Error("Error get invitee", zap.Error(err))
+			return nil, err
+		}
+		employeeId = &id
+	}
+	if organizationId != nil {
+		var exists bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM organization WHERE id = $1)`, *organizationId).Scan(&exists)
+		if err != nil {
+			p.logger.Error("Error check organization exists", zap.Error(err))
+			return nil, err
+		}
+		if !exists {
+			err = ErrOrganizationNotFound
+			return nil, err
+		}
+	}
+
+	var invitationId repos.InvitationId
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO tender_invitations (tender_id, organization_id, employee_id, invited_by)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT DO NOTHING
+        RETURNING id`, tenderId, organizationId, employeeId, inviterId).Scan(&invitationId)
+	if err == sql.ErrNoRows {
+		err = ErrAlreadyInvited
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error insert tender invitation", zap.Error(err))
+		return nil, err
+	}
+
+	invitation, err := p.getTenderInvitation(ctx, tx, tenderId, invitationId)
+	if err != nil {
+		p.logger.Error("Error get tender invitation", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (p *Postgres) GetTenderInvitations(ctx context.Context, tenderId repos.TenderId, username repos.Username, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderInvitation, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+invitationColumns+invitationFrom+`
+        WHERE i.tender_id = $1
+        ORDER BY i.created_at DESC, i.id
+        LIMIT $2 OFFSET $3`, tenderId, limit, offset)
+	if err != nil {
+		p.logger.Error("Error get list of tender invitations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	return p.scanInvitations(rows)
+}
+
+func (p *Postgres) DeleteTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, username repos.Username) error {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	res, err := p.db.ExecContext(ctx, `DELETE FROM tender_invitations WHERE id = $1 AND tender_id = $2`, invitationId, tenderId)
+	if err != nil {
+		p.logger.Error("Error delete tender invitation", zap.Error(err))
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// GetUserInvitations приглашения пользователя, в том числе адресованные организациям, за которые он отвечает
+func (p *Postgres) GetUserInvitations(ctx context.Context, userId int, status *repos.InvitationStatus, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderInvitation, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+invitationColumns+invitationFrom+`
+        WHERE `+invitationInvitee("1")+`
+        AND ($2::invitation_status IS NULL OR i.status = $2)
+        ORDER BY i.created_at DESC, i.id
+        LIMIT $3 OFFSET $4`, userId, status, limit, offset)
+	if err != nil {
+		p.logger.Error("Error get list of user invitations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	return p.scanInvitations(rows)
+}
+
+func (p *Postgres) RespondTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, userId int, status repos.InvitationStatus) (*models.TenderInvitation, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var invitee bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT `+invitationInvitee("3")+`
+        FROM tender_invitations i
+        WHERE i.id = $1 AND i.tender_id = $2
+        FOR UPDATE`, invitationId, tenderId, userId).Scan(&invitee)
+	if err == sql.ErrNoRows {
+		err = ErrInvitationNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error lock tender invitation", zap.Error(err))
+		return nil, err
+	}
+	if !invitee {
+		err = ErrNotInvitee
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE tender_invitations
+        SET status = $1, responded_by = $2, responded_at = CURRENT_TIMESTAMP
+        WHERE id = $3`, status, userId, invitationId)
+	if err != nil {
+		p.logger.Error("Error respond tender invitation", zap.Error(err))
+		return nil, err
+	}
+
+	invitation, err := p.getTenderInvitation(ctx, tx, tenderId, invitationId)
+	if err != nil {
+		p.logger.Error("Error get tender invitation", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (p *Postgres) getTenderInvitation(ctx context.Context, q querier, tenderId repos.TenderId, invitationId repos.InvitationId) (*models.TenderInvitation, error) {
+	var invitation models.TenderInvitation
+	err := q.QueryRowContext(ctx, `
+        SELECT `+invitationColumns+invitationFrom+`
+        WHERE i.id = $1 AND i.tender_id = $2`, invitationId, tenderId).Scan(invitationDest(&invitation)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvitationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (p *Postgres) scanInvitations(rows *sql.Rows) ([]*models.TenderInvitation, error) {
+	invitations := []*models.TenderInvitation{}
+	for rows.Next() {
+		var invitation models.TenderInvitation
+		if err := rows.Scan(invitationDest(&invitation)...); err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		invitations = append(invitations, &invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// moneyColumns колонки суммы и валюты с общим префиксом: price -> price_amount, price_currency
+func moneyColumns(alias, prefix string) string {
+	return alias + "." + prefix + "_amount, " + alias + "." + prefix + "_currency"
+}
+
+// moneyValue приемник колонок moneyColumns. NUMERIC читается строкой, чтобы не терять точность
+type moneyValue struct {
+	amount   sql.NullString
+	currency sql.NullString
+}
+
+func (m *moneyValue) dest() []any {
+	return []any{&m.amount, &m.currency}
+}
+
+func (m moneyValue) model() *models.Money {
+	if !m.amount.Valid {
+		return nil
+	}
+	return &models.Money{Amount: m.amount.String, Currency: m.currency.String}
+}
+
+// moneyArgs раскладывает сумму на аргументы запроса, nil - две NULL
+func moneyArgs(m *models.Money) (amount, currency any) {
+	if m == nil {
+		return nil, nil
+	}
+	return m.Amount, m.Currency
+}
+
+// bidTermsColumns цена и срок поставки предложения, а у запечатанного предложения - его зашифрованное содержимое
+func bidTermsColumns(alias string) string {
+	return moneyColumns(alias, "price") + ", " + alias + ".delivery_days, " + alias + ".sealed_payload"
+}
+
+// bidTerms приемник колонок bidTermsColumns
+type bidTerms struct {
+	price        moneyValue
+	deliveryDays *int32
+	sealed       []byte
+}
+
+func (b *bidTerms) dest() []any {
+	return append(b.price.dest(), &b.deliveryDays, &b.sealed)
+}
+
+func (b bidTerms) apply(bid *models.Bid) {
+	bid.Price = b.price.model()
+	bid.DeliveryDays = b.deliveryDays
+	bid.SealedPayload = b.sealed
+	bid.Sealed = b.sealed != nil
+}
+
+func (b bidTerms) applyVersion(v *models.BidVersionInfo) {
+	v.Price = b.price.model()
+	v.DeliveryDays = b.deliveryDays
+	v.SealedPayload = b.sealed
+	v.Sealed = b.sealed != nil
+}
+
+// tenderTermsColumns бюджет тендера, признак запечатанности, параметры редукциона и режим доступа
+func tenderTermsColumns(alias string) string {
+	return moneyColumns(alias, "budget") + ", " + tenderSealColumns(alias) + ", " + tenderAuctionColumns(alias) + ", " + alias + ".access"
+}
+
+// tenderTerms приемник колонок tenderTermsColumns
+type tenderTerms struct {
+	budget  moneyValue
+	seal    tenderSeal
+	auction tenderAuction
+	access  models.TenderAccess
+}
+
+func (t *tenderTerms) dest() []any {
+	return append(append(append(t.budget.dest(), t.seal.dest()...), t.auction.dest()...), &t.access)
+}
+
+func (t tenderTerms) apply(tender *models.Tender) {
+	tender.Budget = t.budget.model()
+	t.seal.apply(tender)
+	tender.Auction = t.auction.model()
+	tender.Access = t.access
+}
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+func (p *Postgres) GetOrganizations(ctx context.Context, params repos.GetOrganizationsParams) ([]*models.Organization, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT id, name, COALESCE(description, ''), type, created_at
+        FROM organization
+        ORDER BY name, id
+        LIMIT $1 OFFSET $2`, params.Limit, params.Offset)
+	if err != nil {
+		p.logger.Error("Error get organizations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*models.Organization{}
+	for rows.Next() {
+		var org models.Organization
+		err := rows.Scan(&org.Id, &org.Name, &org.Description, &org.Type, &org.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		organizations = append(organizations, &org)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (p *Postgres) GetOrganization(ctx context.Context, organizationId repos.OrganizationId) (*models.Organization, error) {
+	var org models.Organization
+	err := p.db.QueryRowContext(ctx, `
+        SELECT id, name, COALESCE(description, ''), type, created_at
+        FROM organization
+        WHERE id = $1`, organizationId).Scan(&org.Id, &org.Name, &org.Description, &org.Type, &org.CreatedAt)
+	if err != nil {
+		p.logger.Error("Organization not found", zap.Error(err))
+		return nil, ErrOrganizationNotFound
+	}
+
+	return &org, nil
+}
+
+func (p *Postgres) CreateOrganization(ctx context.Context, params repos.CreateOrganizationParams) (*models.Organization, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	creatorId, err := p.GetUserIDByUsername(ctx, *params.CreatorUsername)
+	if err != nil {
+		p.logger.Error("Error in get user id by username", zap.Error(err))
+		return nil, err
+	}
+
+	var org models.Organization
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO organization (name, description, type, created_at, updated_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id, name, COALESCE(description, ''), type, created_at`,
+		params.Name, params.Description, params.Type).Scan(&org.Id, &org.Name, &org.Description, &org.Type, &org.CreatedAt)
+	if err != nil {
+		p.logger.Error("Error create organization", zap.Error(err))
+		return nil, err
+	}
+
+	// Создатель становится первым ответственным, иначе организацией некому было бы управлять
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO organization_responsible (organization_id, user_id)
+        VALUES ($1, $2)`, org.Id, creatorId)
+	if err != nil {
+		p.logger.Error("Error add organization creator as responsible", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (p *Postgres) EditOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.EditOrganizationParams) (*models.Organization, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.checkOrganizationResponsible(ctx, tx, organizationId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	var org models.Organization
+	err = tx.QueryRowContext(ctx, `
+        UPDATE organization
+        SET name = COALESCE($1, name), description = COALESCE($2, description), type = COALESCE($3, type),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $4
+        RETURNING id, name, COALESCE(description, ''), type, created_at`,
+		params.Name, params.Description, params.Type, organizationId).Scan(&org.Id, &org.Name, &org.Description, &org.Type, &org.CreatedAt)
+	if err != nil {
+		p.logger.Error("Error update organization", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (p *Postgres) DeleteOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.DeleteOrganizationParams) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.checkOrganizationResponsible(ctx, tx, organizationId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	// Тендеры ссылаются на организацию без каскадного удаления: историю тендеров терять нельзя
+	var hasTenders bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM tenders WHERE organization_id = $1)`, organizationId).Scan(&hasTenders)
+	if err != nil {
+		p.logger.Error("Error check organization tenders", zap.Error(err))
+		return err
+	}
+	if hasTenders {
+		err = ErrOrganizationHasTenders
+		return err
+	}
+
+	// Предложения от имени организации тоже ссылаются на нее без каскада
+	var hasBids bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM bids WHERE organization_id = $1)`, organizationId).Scan(&hasBids)
+	if err != nil {
+		p.logger.Error("Error check organization bids", zap.Error(err))
+		return err
+	}
+	if hasBids {
+		err = ErrOrganizationHasBids
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM organization WHERE id = $1`, organizationId)
+	if err != nil {
+		p.logger.Error("Error delete organization", zap.Error(err))
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) GetResponsibles(ctx context.Context, organizationId repos.OrganizationId) ([]*models.Employee, error) {
+	_, err := p.GetOrganization(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.getResponsibles(ctx, p.db, organizationId)
+}
+
+func (p *Postgres) AddResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.AddResponsibleParams) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.checkOrganizationResponsible(ctx, tx, organizationId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	var employeeId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, params.Employee).Scan(&employeeId)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+		return err
+	} else if err != nil {
+		p.logger.Error("Error get employee", zap.Error(err))
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        INSERT INTO organization_responsible (organization_id, user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (organization_id, user_id) DO NOTHING`, organizationId, employeeId)
+	if err != nil {
+		p.logger.Error("Error add responsible", zap.Error(err))
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = ErrAlreadyResponsible
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) RemoveResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.RemoveResponsibleParams) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = p.checkOrganizationResponsible(ctx, tx, organizationId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        DELETE FROM organization_responsible r
+        USING employee e
+        WHERE e.id = r.user_id AND r.organization_id = $1 AND e.username = $2`, organizationId, params.Employee)
+	if err != nil {
+		p.logger.Error("Error remove responsible", zap.Error(err))
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = ErrResponsibleNotFound
+		return err
+	}
+
+	// Строка организации заблокирована, поэтому параллельные снятия не оставят ее без ответственных
+	var left int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM organization_responsible WHERE organization_id = $1`, organizationId).Scan(&left)
+	if err != nil {
+		p.logger.Error("Error count responsibles", zap.Error(err))
+		return err
+	}
+	if left == 0 {
+		err = ErrLastResponsible
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// checkOrganizationResponsible блокирует строку организации до конца транзакции
+// и проверяет, что пользователь является ее ответственным. Возвращает id пользователя
+func (p *Postgres) checkOrganizationResponsible(ctx context.Context, q querier, organizationId repos.OrganizationId, username repos.Username) (int, error) {
+	var id string
+	err := q.QueryRowContext(ctx, `SELECT id FROM organization WHERE id = $1 FOR UPDATE`, organizationId).Scan(&id)
+	if err != nil {
+		p.logger.Error("Organization not found", zap.Error(err))
+		return 0, ErrOrganizationNotFound
+	}
+
+	var userId int
+	var responsible bool
+	err = q.QueryRowContext(ctx, `
+        SELECT e.id, EXISTS(
+            SELECT 1 FROM organization_responsible r
+            WHERE r.user_id = e.id AND r.organization_id = $2)
+        FROM employee e
+        WHERE e.username = $1`, username, organizationId).Scan(&userId, &responsible)
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	if !responsible {
+		return 0, ErrUserNotAllowed
+	}
+
+	return userId, nil
+}
+
+func (p *Postgres) getResponsibles(ctx context.Context, q querier, organizationId repos.OrganizationId) ([]*models.Employee, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT e.id, e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, ''), e.created_at
+        FROM organization_responsible r
+        JOIN employee e ON e.id = r.user_id
+        WHERE r.organization_id = $1
+        ORDER BY e.username`, organizationId)
+	if err != nil {
+		p.logger.Error("Error get responsibles", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []*models.Employee{}
+	for rows.Next() {
+		var employee models.Employee
+		err := rows.Scan(&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		employees = append(employees, &employee)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return employees, nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+// maxOutboxBackoff верхняя граница паузы между попытками доставки события, в секундах
+const maxOutboxBackoff = 300
+
+// emitTenderEvent пишет событие тендера в outbox по его текущему состоянию.
+// Должна вызываться в той же транзакции, что и изменение тендера
+func (p *Postgres) emitTenderEvent(ctx context.Context, q querier, eventType models.DomainEventType, tenderId repos.TenderId, previous *repos.TenderStatus) error {
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO outbox_events (type, aggregate_type, aggregate_id, payload)
+        SELECT $1, $2, t.id::text, jsonb_strip_nulls(jsonb_build_object(
+            'tenderId', t.id,
+            'organizationId', t.organization_id,
+            'status', t.status,
+            'access', t.access,
+            'previousStatus', $4::text))
+        FROM tenders t
+        WHERE t.id = $3`, eventType, models.EventAggregateTender, tenderId, previous)
+	return err
+}
+
+// emitBidEvent пишет событие предложения в outbox по его текущему состоянию.
+// previous передается только при смене статуса
+func (p *Postgres) emitBidEvent(ctx context.Context, q querier, eventType models.DomainEventType, bidId repos.BidId, previous *repos.BidStatus) error {
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO outbox_events (type, aggregate_type, aggregate_id, payload)
+        SELECT $1, $2, b.id::text, jsonb_strip_nulls(jsonb_build_object(
+            'bidId', b.id,
+            'tenderId', b.tender_id,
+            'authorType', b.author_type,
+            'authorId', `+bidAuthorIdColumn+`,
+            'status', b.status,
+            'previousStatus', $4::text))
+        FROM bids b
+        WHERE b.id = $3`, eventType, models.EventAggregateBid, bidId, previous)
+	return err
+}
+
+// emitFeedbackEvent пишет событие отзыва в outbox. События отзывов упорядочены в рамках предложения
+func (p *Postgres) emitFeedbackEvent(ctx context.Context, q querier, eventType models.DomainEventType, feedbackId repos.BidReviewId) error {
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO outbox_events (type, aggregate_type, aggregate_id, payload)
+        SELECT $1, $2, f.bid_id::text, jsonb_strip_nulls(jsonb_build_object(
+            'feedbackId', f.id,
+            'bidId', f.bid_id,
+            'tenderId', b.tender_id,
+            'parentId', f.parent_id,
+            'rating', CASE WHEN f.deleted_at IS NULL THEN f.rating END,
+            'deleted', f.deleted_at IS NOT NULL))
+        FROM bid_feedbacks f
+        JOIN bids b ON b.id = f.bid_id
+        WHERE f.id = $3`, eventType, models.EventAggregateFeedback, feedbackId)
+	return err
+}
+
+// tenderTransitionEvent событие, которым публикуется переход статуса тендера
+func tenderTransitionEvent(to repos.TenderStatus) models.DomainEventType {
+	switch to {
+	case repos.TenderStatusPublished:
+		return models.EventTenderPublished
+	case repos.TenderStatusClosed:
+		return models.EventTenderClosed
+	case repos.TenderStatusCanceled:
+		return models.EventTenderCanceled
+	default:
+		return models.EventTenderStatusChanged
+	}
+}
+
+// DispatchOutbox доставляет пачку событий под блокировкой строк.
+// SKIP LOCKED позволяет нескольким инстансам разбирать outbox параллельно,
+// а событие не берется, пока не доставлено более раннее событие того же объекта, поэтому порядок внутри объекта сохраняется.
+// Неудачная попытка откладывает событие с экспоненциальной паузой, после maxAttempts попыток событие помечается failed
+func (p *Postgres) DispatchOutbox(ctx context.Context, limit int, maxAttempts int, deliver func(ctx context.Context, event models.DomainEvent) error) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT o.id, o.event_id, o.type, o.aggregate_type, o.aggregate_id, o.payload, o.created_at, o.attempts
+        FROM outbox_events o
+        WHERE o.dispatched_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= CURRENT_TIMESTAMP
+            AND NOT EXISTS (
+                SELECT 1 FROM outbox_events e
+                WHERE e.aggregate_id = o.aggregate_id AND e.id < o.id
+                    AND e.dispatched_at IS NULL AND e.failed_at IS NULL)
+        ORDER BY o.id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		p.logger.Error("Error select outbox events", zap.Error(err))
+		return 0, err
+	}
+
+	var events []models.DomainEvent
+	for rows.Next() {
+		var event models.DomainEvent
+		var occurredAt time.Time
+		var payload []byte
+		if err = rows.Scan(&event.Seq, &event.EventId, &event.Type, &event.AggregateType, &event.AggregateId, &payload, &occurredAt, &event.Attempts); err != nil {
+			rows.Close()
+			p.logger.Error("Error scan outbox event", zap.Error(err))
+			return 0, err
+		}
+		event.Payload = payload
+		event.OccurredAt = occurredAt.Format(time.RFC3339)
+		events = append(events, event)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		p.logger.Error("Error iterate outbox events", zap.Error(err))
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		deliverErr := deliver(ctx, event)
+		if deliverErr == nil {
+			_, err = tx.ExecContext(ctx, `
+                UPDATE outbox_events
+                SET dispatched_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL
+                WHERE id = $1`, event.Seq)
+			if err != nil {
+				p.logger.Error("Error mark outbox event dispatched", zap.Error(err))
+				return 0, err
+			}
+			delivered++
+			continue
+		}
+		// Отмена контекста - не ошибка получателя, попытка не засчитывается
+		if errors.Is(deliverErr, context.Canceled) && ctx.Err() != nil {
+			err = ctx.Err()
+			return 0, err
+		}
+
+		p.logger.Error("Error deliver outbox event", zap.String("eventId", event.EventId), zap.String("type", string(event.Type)), zap.Error(deliverErr))
+		_, err = tx.ExecContext(ctx, `
+            UPDATE outbox_events
+            SET attempts = attempts + 1,
+                last_error = $2,
+                next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => LEAST(power(2, attempts + 1), $3)),
+                failed_at = CASE WHEN attempts + 1 >= $4 THEN CURRENT_TIMESTAMP END
+            WHERE id = $1`, event.Seq, deliverErr.Error(), maxOutboxBackoff, maxAttempts)
+		if err != nil {
+			p.logger.Error("Error mark outbox event failed", zap.Error(err))
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return 0, err
+	}
+
+	return delivered, nil
+}
+
+func (p *Postgres) PurgeDispatchedEvents(ctx context.Context, olderThan time.Time) (int64, error) {
+	res, err := p.db.ExecContext(ctx, `
+        DELETE FROM outbox_events
+        WHERE dispatched_at IS NOT NULL AND dispatched_at < $1`, olderThan)
+	if err != nil {
+		p.logger.Error("Error purge outbox events", zap.Error(err))
+		return 0, err
+	}
+	return res.RowsAffected()
+}
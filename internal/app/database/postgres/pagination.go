@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+// Страницы списков сортируются по created_at DESC, id. Курсор указывает на последний объект
+// предыдущей страницы, следующая страница начинается строго после него.
+
+// keysetCondition условие "после курсора" для строк таблицы alias. Аргументы курсора дописываются в args
+func keysetCondition(alias string, page repos.PageRequest, args *[]any) string {
+	if page.After == nil {
+		return ""
+	}
+	*args = append(*args, page.After.CreatedAt, page.After.Id)
+	n := len(*args)
+	return fmt.Sprintf(`
+		AND (%[1]s.created_at < $%[2]d OR (%[1]s.created_at = $%[2]d AND %[1]s.id > $%[3]d))`, alias, n-1, n)
+}
+
+// pageOrder порядок строк списка
+func pageOrder(alias string) string {
+	return fmt.Sprintf(`%[1]s.created_at DESC, %[1]s.id`, alias)
+}
+
+// limitOffset ограничение страницы. С курсором offset всегда 0
+func limitOffset(page repos.PageRequest, args *[]any) string {
+	*args = append(*args, page.Limit, page.Offset)
+	n := len(*args)
+	return fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d`, n-1, n)
+}
+
+// countTotal считает все строки списка без учета страницы.
+// from - часть запроса начиная с FROM, args - только ее аргументы
+func (p *Postgres) countTotal(ctx context.Context, page repos.PageRequest, from string, args []any) (*int64, error) {
+	if !page.WithTotal {
+		return nil, nil
+	}
+	var total int64
+	err := p.db.QueryRowContext(ctx, `SELECT COUNT(*) `+from, args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
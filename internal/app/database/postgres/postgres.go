@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/0x0FACED/tender-service/config"
@@ -9,6 +10,14 @@ import (
 	"go.uber.org/zap"
 )
 
+// querier общий интерфейс для *sql.DB и *sql.Tx,
+// чтобы вспомогательные запросы можно было выполнять как в транзакции, так и без нее
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 type Postgres struct {
 	db *sql.DB
 
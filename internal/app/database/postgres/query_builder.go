@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// queryBuilder собирает параметризованные условия WHERE.
+// Аргументы нумеруются в порядке добавления, значения в текст запроса не попадают
+type queryBuilder struct {
+	conditions []string
+	args       []any
+}
+
+// arg добавляет аргумент и возвращает его плейсхолдер
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// argIndex добавляет аргумент и возвращает его номер
+func (b *queryBuilder) argIndex(v any) int {
+	b.args = append(b.args, v)
+	return len(b.args)
+}
+
+// where добавляет условие. Каждый ? в cond заменяется плейсхолдером очередного аргумента из args
+func (b *queryBuilder) where(cond string, args ...any) {
+	parts := strings.Split(cond, "?")
+	if len(parts)-1 != len(args) {
+		panic(fmt.Sprintf("queryBuilder: %d placeholders, %d args in %q", len(parts)-1, len(args), cond))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(parts[0])
+	for i, a := range args {
+		sb.WriteString(b.arg(a))
+		sb.WriteString(parts[i+1])
+	}
+	b.conditions = append(b.conditions, sb.String())
+}
+
+// whereRange добавляет условие на полуинтервал [from, to) по колонке column. Незаданная граница не ограничивает
+func (b *queryBuilder) whereRange(column string, from, to *time.Time) {
+	if from != nil {
+		b.where(column+" >= ?", *from)
+	}
+	if to != nil {
+		b.where(column+" < ?", *to)
+	}
+}
+
+// whereClause условия через AND. Без условий возвращает пустую строку
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return "\n\t\tWHERE " + strings.Join(b.conditions, "\n\t\tAND ")
+}
@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrQuestionNotFound = errors.New("question not found in tender")
+	ErrNotQuestionAsker = errors.New("not author of the question")
+	ErrQuestionAnswered = errors.New("question already answered")
+)
+
+const questionColumns = `q.id, q.tender_id, q.question, a.username, q.asker_id, q.answer, q.answer_visibility, r.username, q.answered_at, q.version, q.created_at`
+
+const questionFrom = `
+        FROM tender_questions q
+        JOIN employee a ON a.id = q.asker_id
+        LEFT JOIN employee r ON r.id = q.answered_by`
+
+func questionDest(q *models.TenderQuestion) []any {
+	return []any{&q.Id, &q.TenderId, &q.Question, &q.AskerUsername, &q.AskerId, &q.Answer, &q.AnswerVisibility, &q.AnsweredBy, &q.AnsweredAt, &q.Version, &q.CreatedAt}
+}
+
+func (p *Postgres) CreateTenderQuestion(ctx context.Context, tenderId repos.TenderId, username repos.Username, question string) (*models.TenderQuestion, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var askerId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&askerId)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error get asker", zap.Error(err))
+		return nil, err
+	}
+
+	var questionId repos.QuestionId
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO tender_questions (tender_id, asker_id, question)
+        VALUES ($1, $2, $3)
+        RETURNING id`, tenderId, askerId, question).Scan(&questionId)
+	if err != nil {
+		p.logger.Error("Error insert tender question", zap.Error(err))
+		return nil, err
+	}
+
+	result, err := p.commitQuestion(ctx, tx, tenderId, questionId, askerId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *Postgres) EditTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, username repos.Username, question string) (*models.TenderQuestion, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var askerId int
+	var answered bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT q.asker_id, q.answer IS NOT NULL
+        FROM tender_questions q
+        WHERE q.id = $1 AND q.tender_id = $2
+        FOR UPDATE`, questionId, tenderId).Scan(&askerId, &answered)
+	if err == sql.ErrNoRows {
+		err = ErrQuestionNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error lock tender question", zap.Error(err))
+		return nil, err
+	}
+
+	var userId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userId)
+	if err == sql.ErrNoRows {
+		err = ErrUserNotFound
+		return nil, err
+	} else if err != nil {
+		p.logger.Error("Error get user", zap.Error(err))
+		return nil, err
+	}
+	if userId != askerId {
+		err = ErrNotQuestionAsker
+		return nil, err
+	}
+	// Ответ дан на прежний текст, менять вопрос под ним нельзя
+	if answered {
+		err = ErrQuestionAnswered
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE tender_questions
+        SET question = $1, version = version + 1
+        WHERE id = $2`, question, questionId)
+	if err != nil {
+		p.logger.Error("Error update tender question", zap.Error(err))
+		return nil, err
+	}
+
+	result, err := p.commitQuestion(ctx, tx, tenderId, questionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AnswerTenderQuestion отвечает на вопрос или заменяет прежний ответ. Отвечают только ответственные за тендер
+func (p *Postgres) AnswerTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, username repos.Username, answer string, visibility repos.AnswerVisibility) (*models.TenderQuestion, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	userId, err := p.checkTenderResponsible(ctx, tx, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        UPDATE tender_questions
+        SET answer = $1, answer_visibility = $2, answered_by = $3, answered_at = CURRENT_TIMESTAMP, version = version + 1
+        WHERE id = $4 AND tender_id = $5`, answer, visibility, userId, questionId, tenderId)
+	if err != nil {
+		p.logger.Error("Error answer tender question", zap.Error(err))
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		err = ErrQuestionNotFound
+		return nil, err
+	}
+
+	result, err := p.commitQuestion(ctx, tx, tenderId, questionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// commitQuestion сохраняет текущее состояние вопроса новой версией, читает его и завершает транзакцию
+func (p *Postgres) commitQuestion(ctx context.Context, tx *sql.Tx, tenderId repos.TenderId, questionId repos.QuestionId, editorId int) (*models.TenderQuestion, error) {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO tender_question_versions (question_id, version, question, answer, answer_visibility, editor_id)
+        SELECT id, version, question, answer, answer_visibility, $2
+        FROM tender_questions
+        WHERE id = $1`, questionId, editorId)
+	if err != nil {
+		p.logger.Error("Error snapshot tender question", zap.Error(err))
+		return nil, err
+	}
+
+	question, err := p.getTenderQuestion(ctx, tx, tenderId, questionId)
+	if err != nil {
+		p.logger.Error("Error get tender question", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return question, nil
+}
+
+func (p *Postgres) GetTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId) (*models.TenderQuestion, error) {
+	question, err := p.getTenderQuestion(ctx, p.db, tenderId, questionId)
+	if err != nil {
+		p.logger.Error("Error get tender question", zap.Error(err))
+		return nil, err
+	}
+	return question, nil
+}
+
+func (p *Postgres) getTenderQuestion(ctx context.Context, q querier, tenderId repos.TenderId, questionId repos.QuestionId) (*models.TenderQuestion, error) {
+	var question models.TenderQuestion
+	err := q.QueryRowContext(ctx, `
+        SELECT `+questionColumns+questionFrom+`
+        WHERE q.id = $1 AND q.tender_id = $2`, questionId, tenderId).Scan(questionDest(&question)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrQuestionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// GetTenderQuestions вопросы тендера. Без params.All пользователь видит только свои вопросы и вопросы с публичным ответом
+func (p *Postgres) GetTenderQuestions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderQuestionsParams) (*models.Page[*models.TenderQuestion], error) {
+	from := questionFrom + `
+        WHERE q.tender_id = $1
+        AND ($2 OR q.asker_id = $3 OR q.answer_visibility = 'Public')`
+	args := []any{tenderId, params.All, params.ViewerId}
+
+	total, err := p.countTotal(ctx, params.Page, from, args)
+	if err != nil {
+		p.logger.Error("Error count tender questions", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+questionColumns+from+keysetCondition("q", params.Page, &args)+`
+        ORDER BY `+pageOrder("q")+limitOffset(params.Page, &args), args...)
+	if err != nil {
+		p.logger.Error("Error get list of tender questions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &models.Page[*models.TenderQuestion]{Items: []*models.TenderQuestion{}, TotalCount: total}
+	for rows.Next() {
+		var question models.TenderQuestion
+		if err := rows.Scan(questionDest(&question)...); err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		page.Items = append(page.Items, &question)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (p *Postgres) GetTenderQuestionVersions(ctx context.Context, questionId repos.QuestionId, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderQuestionVersion, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT v.version, v.question, v.answer, v.answer_visibility, e.username, v.created_at
+        FROM tender_question_versions v
+        LEFT JOIN employee e ON e.id = v.editor_id
+        WHERE v.question_id = $1
+        ORDER BY v.version DESC
+        LIMIT $2 OFFSET $3`, questionId, limit, offset)
+	if err != nil {
+		p.logger.Error("Error get list of question versions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []*models.TenderQuestionVersion{}
+	for rows.Next() {
+		var v models.TenderQuestionVersion
+		err := rows.Scan(&v.Version, &v.Question, &v.Answer, &v.AnswerVisibility, &v.EditorUsername, &v.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		versions = append(versions, &v)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return versions, nil
+}
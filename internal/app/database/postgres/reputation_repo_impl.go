@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+// bidSubjectColumns автор предложения, чья репутация от него зависит:
+// организация для предложений от организации, иначе сотрудник
+const bidSubjectColumns = `CASE WHEN b.organization_id IS NOT NULL THEN 'Organization' ELSE 'User' END::bid_author_type,
+        CASE WHEN b.organization_id IS NOT NULL THEN b.organization_id::text ELSE b.author_id::text END`
+
+// refreshReputationQuery пересчитывает строку репутации одного автора по всем его предложениям.
+// Проигрыш - отказ или предложение, оставшееся опубликованным в тендере с другим победителем.
+// Время до решения считается от подачи предложения до первой версии с решением
+const refreshReputationQuery = `
+        INSERT INTO bidder_reputation (subject_type, subject_id, wins, losses, canceled, rating_count, rating_sum, decision_count, decision_seconds, updated_at)
+        SELECT $1::bid_author_type, $2::text,
+            COUNT(b.id) FILTER (WHERE b.status = 'Approved'),
+            COUNT(b.id) FILTER (WHERE b.status = 'Rejected'
+                OR (b.status = 'Published' AND EXISTS(SELECT 1 FROM bids w WHERE w.tender_id = b.tender_id AND w.status = 'Approved'))),
+            COUNT(b.id) FILTER (WHERE b.status = 'Canceled'),
+            COALESCE(SUM(r.rating_count), 0),
+            COALESCE(SUM(r.rating_sum), 0),
+            COUNT(d.decided_at),
+            COALESCE(SUM(EXTRACT(EPOCH FROM d.decided_at - b.created_at)), 0)::BIGINT,
+            CURRENT_TIMESTAMP
+        FROM bids b
+        LEFT JOIN LATERAL (
+            SELECT COUNT(f.rating) AS rating_count, SUM(f.rating) AS rating_sum
+            FROM bid_feedbacks f
+            WHERE f.bid_id = b.id AND f.parent_id IS NULL AND f.deleted_at IS NULL
+        ) r ON TRUE
+        LEFT JOIN LATERAL (
+            SELECT MIN(v.created_at) AS decided_at
+            FROM bid_versions v
+            WHERE v.bid_id = b.id AND v.decision IS NOT NULL
+        ) d ON TRUE
+        WHERE ($1::bid_author_type = 'Organization' AND b.organization_id::text = $2::text)
+            OR ($1::bid_author_type = 'User' AND b.organization_id IS NULL AND b.author_id::text = $2::text)
+        ON CONFLICT (subject_type, subject_id) DO UPDATE
+        SET wins = EXCLUDED.wins, losses = EXCLUDED.losses, canceled = EXCLUDED.canceled,
+            rating_count = EXCLUDED.rating_count, rating_sum = EXCLUDED.rating_sum,
+            decision_count = EXCLUDED.decision_count, decision_seconds = EXCLUDED.decision_seconds,
+            updated_at = EXCLUDED.updated_at`
+
+// lockReputationQuery сериализует пересчет репутации одного автора до конца транзакции
+const lockReputationQuery = `SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || $2::text))`
+
+// refreshReputation пересчитывает репутацию автора. Должна вызываться в той же транзакции, что и событие.
+// Без блокировки две параллельные транзакции пересчитывают строку каждая по своему снимку,
+// и последняя записавшая затирает изменения другой. Следующий после блокировки запрос
+// получает новый снимок и видит все, что закоммитила предыдущая транзакция
+func (p *Postgres) refreshReputation(ctx context.Context, q querier, subjectType repos.BidAuthorType, subjectId string) error {
+	_, err := q.ExecContext(ctx, lockReputationQuery, subjectType, subjectId)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx, refreshReputationQuery, subjectType, subjectId)
+	return err
+}
+
+// refreshBidReputation пересчитывает репутацию автора предложения
+func (p *Postgres) refreshBidReputation(ctx context.Context, q querier, bidId repos.BidId) error {
+	var subjectType repos.BidAuthorType
+	var subjectId string
+	err := q.QueryRowContext(ctx, `
+        SELECT `+bidSubjectColumns+`
+        FROM bids b
+        WHERE b.id = $1`, bidId).Scan(&subjectType, &subjectId)
+	if err != nil {
+		return err
+	}
+	return p.refreshReputation(ctx, q, subjectType, subjectId)
+}
+
+// refreshTenderReputation пересчитывает репутацию всех участников тендера:
+// одобрение одного предложения - проигрыш для остальных
+func (p *Postgres) refreshTenderReputation(ctx context.Context, q querier, tenderId repos.TenderId) error {
+	rows, err := q.QueryContext(ctx, `
+        SELECT DISTINCT `+bidSubjectColumns+`
+        FROM bids b
+        WHERE b.tender_id = $1
+        ORDER BY 1, 2`, tenderId)
+	if err != nil {
+		return err
+	}
+
+	type subject struct {
+		subjectType repos.BidAuthorType
+		subjectId   string
+	}
+	var subjects []subject
+	for rows.Next() {
+		var s subject
+		if err := rows.Scan(&s.subjectType, &s.subjectId); err != nil {
+			rows.Close()
+			return err
+		}
+		subjects = append(subjects, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Строки читаются целиком до пересчета: в транзакции нельзя выполнять запрос, пока открыт курсор.
+	// Авторы упорядочены, чтобы параллельные пересчеты брали блокировки в одном порядке
+	for _, s := range subjects {
+		if err := p.refreshReputation(ctx, q, s.subjectType, s.subjectId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBidderReputation репутация автора. Без предложений - нулевая репутация
+func (p *Postgres) GetBidderReputation(ctx context.Context, subjectType repos.BidAuthorType, subjectId string) (*models.BidderReputation, error) {
+	reputation := models.BidderReputation{SubjectType: models.BidAuthorType(subjectType), SubjectId: subjectId}
+	err := p.db.QueryRowContext(ctx, `
+        SELECT wins, losses, canceled, rating_count, rating_sum, decision_count, decision_seconds, updated_at
+        FROM bidder_reputation
+        WHERE subject_type = $1 AND subject_id = $2`, subjectType, subjectId).Scan(
+		&reputation.Wins, &reputation.Losses, &reputation.Canceled, &reputation.RatingCount, &reputation.RatingSum,
+		&reputation.DecisionCount, &reputation.DecisionSeconds, &reputation.UpdatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		p.logger.Error("Error get bidder reputation", zap.Error(err))
+		return nil, err
+	}
+
+	return &reputation, nil
+}
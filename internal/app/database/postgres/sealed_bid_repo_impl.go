@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
+)
+
+// tenderAuditBidsRevealed действие раскрытия запечатанных предложений в журнале тендера
+const tenderAuditBidsRevealed = "BidsRevealed"
+
+// tenderSealColumns признак запечатанного тендера и момент раскрытия его предложений
+func tenderSealColumns(alias string) string {
+	return alias + ".sealed, " + alias + ".revealed_at"
+}
+
+// tenderSeal приемник колонок tenderSealColumns
+type tenderSeal struct {
+	sealed     bool
+	revealedAt sql.NullTime
+}
+
+func (s *tenderSeal) dest() []any {
+	return []any{&s.sealed, &s.revealedAt}
+}
+
+func (s tenderSeal) apply(tender *models.Tender) {
+	tender.Sealed = s.sealed
+	tender.RevealedAt = nil
+	if s.revealedAt.Valid {
+		tender.RevealedAt = &s.revealedAt.Time
+	}
+}
+
+// sealedRow строка с зашифрованным содержимым предложения: само предложение или его версия
+type sealedRow struct {
+	id      any
+	payload []byte
+}
+
+// RevealTenderBids расшифровывает запечатанные предложения закрытого тендера и их версии через open,
+// сохраняет открытое содержимое и записывает раскрытие в журнал тендера.
+// Возвращает false, если раскрывать нечего: тендер не запечатан, не закрыт или уже раскрыт
+func (p *Postgres) RevealTenderBids(ctx context.Context, tenderId repos.TenderId, trigger string, actor *repos.Username, open func(payload []byte) (*models.SealedBidContent, error)) (bool, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return false, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Блокировка тендера не дает раскрыть предложения дважды при одновременном закрытии вручную и планировщиком
+	var status repos.TenderStatus
+	var seal tenderSeal
+	err = tx.QueryRowContext(ctx, `
+        SELECT t.status, `+tenderSealColumns("t")+`
+        FROM tenders t
+        WHERE t.id = $1
+        FOR UPDATE OF t`, tenderId).Scan(append([]any{&status}, seal.dest()...)...)
+	if err == sql.ErrNoRows {
+		err = ErrTenderNotFound
+		return false, err
+	} else if err != nil {
+		p.logger.Error("Error lock tender", zap.Error(err))
+		return false, err
+	}
+	if !seal.sealed || seal.revealedAt.Valid || status != repos.TenderStatusClosed {
+		tx.Rollback()
+		return false, nil
+	}
+
+	var actorId *int
+	if actor != nil {
+		var id int
+		err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, *actor).Scan(&id)
+		if err == sql.ErrNoRows {
+			err = ErrUserNotFound
+			return false, err
+		} else if err != nil {
+			p.logger.Error("Error get actor", zap.Error(err))
+			return false, err
+		}
+		actorId = &id
+	}
+
+	bids, err := p.revealSealedRows(ctx, tx, "bids", "tender_id", tenderId, open)
+	if err != nil {
+		p.logger.Error("Error reveal bids", zap.Error(err))
+		return false, err
+	}
+	_, err = p.revealSealedRows(ctx, tx, "bid_versions", "tender_id", tenderId, open)
+	if err != nil {
+		p.logger.Error("Error reveal bid versions", zap.Error(err))
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE tenders SET revealed_at = CURRENT_TIMESTAMP WHERE id = $1`, tenderId)
+	if err != nil {
+		p.logger.Error("Error mark tender revealed", zap.Error(err))
+		return false, err
+	}
+
+	details, err := json.Marshal(map[string]any{"bids": bids, "trigger": trigger})
+	if err != nil {
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO tender_audit_log (tender_id, action, actor_id, details)
+        VALUES ($1, $2, $3, $4)`, tenderId, tenderAuditBidsRevealed, actorId, details)
+	if err != nil {
+		p.logger.Error("Error insert tender audit", zap.Error(err))
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+// revealSealedRows заменяет зашифрованное содержимое строк таблицы открытым и возвращает их количество
+func (p *Postgres) revealSealedRows(ctx context.Context, q querier, table, tenderColumn string, tenderId repos.TenderId, open func(payload []byte) (*models.SealedBidContent, error)) (int, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT id, sealed_payload
+        FROM `+table+`
+        WHERE `+tenderColumn+` = $1 AND sealed_payload IS NOT NULL`, tenderId)
+	if err != nil {
+		return 0, err
+	}
+
+	var sealed []sealedRow
+	for rows.Next() {
+		var row sealedRow
+		if err := rows.Scan(&row.id, &row.payload); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		sealed = append(sealed, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, row := range sealed {
+		content, err := open(row.payload)
+		if err != nil {
+			return 0, err
+		}
+		priceAmount, priceCurrency := moneyArgs(content.Price)
+		_, err = q.ExecContext(ctx, `
+            UPDATE `+table+`
+            SET name = $1, description = $2, price_amount = $3, price_currency = $4, delivery_days = $5, sealed_payload = NULL
+            WHERE id = $6`, content.Name, content.Description, priceAmount, priceCurrency, content.DeliveryDays, row.id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(sealed), nil
+}
+
+// GetTenderAudit журнал действий с тендером. Доступен только ответственным за тендер
+func (p *Postgres) GetTenderAudit(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.TenderAuditEntry, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT a.action, e.username, a.details, a.created_at
+        FROM tender_audit_log a
+        LEFT JOIN employee e ON e.id = a.actor_id
+        WHERE a.tender_id = $1
+        ORDER BY a.created_at, a.id`, tenderId)
+	if err != nil {
+		p.logger.Error("Error get tender audit", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.TenderAuditEntry{}
+	for rows.Next() {
+		var entry models.TenderAuditEntry
+		var actor sql.NullString
+		var details []byte
+		err := rows.Scan(&entry.Action, &actor, &details, &entry.CreatedAt)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		if actor.Valid {
+			entry.Actor = &actor.String
+		}
+		if err := json.Unmarshal(details, &entry.Details); err != nil {
+			p.logger.Error("Error unmarshal audit details", zap.Error(err))
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
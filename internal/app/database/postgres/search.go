@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// Параметры выделения совпадений: название выделяется целиком, из описания берутся фрагменты.
+// ts_headline не экранирует текст, поэтому совпадения отмечаются управляющими символами \x01 и \x02
+// (строки SQL вида E'...'), а в HTML с тегами <b> результат превращает highlightMatch после экранирования
+const (
+	searchNameHeadlineOptions        = `StartSel=\x01, StopSel=\x02, HighlightAll=true`
+	searchDescriptionHeadlineOptions = `StartSel=\x01, StopSel=\x02, MaxFragments=2, MaxWords=30, MinWords=10`
+
+	// searchStripMarkers убирает маркеры из исходного текста, чтобы пользователь не мог подделать выделение
+	searchStripMarkers = `E'\x01\x02'`
+)
+
+// searchHighlighter заменяет маркеры ts_headline на теги выделения
+var searchHighlighter = strings.NewReplacer("\x01", "<b>", "\x02", "</b>")
+
+// highlightMatch экранирует текст совпадения и выделяет найденные слова тегами <b>
+func highlightMatch(s string) string {
+	return searchHighlighter.Replace(html.EscapeString(s))
+}
+
+// searchTsQuery запрос по строке пользователя в русской и английской конфигурациях,
+// param - номер параметра запроса со строкой поиска
+func searchTsQuery(param int) string {
+	return fmt.Sprintf(`(websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('english', $%[1]d))`, param)
+}
+
+// searchMatchColumns релевантность и выделенные совпадения для строки таблицы alias.
+// Без строки поиска колонки пустые. Запрос должен содержать поисковый запрос в s.query.
+// Конфигурация russian разбирает и латинские слова через english_stem, поэтому выделение делается в ней
+func searchMatchColumns(alias string, param int) string {
+	return fmt.Sprintf(`
+			CASE WHEN $%[2]d = '' THEN NULL ELSE ts_rank(%[1]s.search_vector, s.query) END,
+			CASE WHEN $%[2]d = '' THEN NULL ELSE ts_headline('russian', translate(%[1]s.name, %[5]s, ''), s.query, E'%[3]s') END,
+			CASE WHEN $%[2]d = '' THEN NULL ELSE ts_headline('russian', translate(COALESCE(%[1]s.description, ''), %[5]s, ''), s.query, E'%[4]s') END`,
+		alias, param, searchNameHeadlineOptions, searchDescriptionHeadlineOptions, searchStripMarkers)
+}
+
+// searchOrder сначала самые релевантные, без строки поиска - обычный порядок страниц
+func searchOrder(alias string, param int) string {
+	return fmt.Sprintf(`CASE WHEN $%[2]d = '' THEN 0 ELSE ts_rank(%[1]s.search_vector, s.query) END DESC, `, alias, param) + pageOrder(alias)
+}
+
+// searchMatch сканируемые колонки searchMatchColumns
+type searchMatch struct {
+	rank        sql.NullFloat64
+	name        sql.NullString
+	description sql.NullString
+}
+
+func (m *searchMatch) dest() []any {
+	return []any{&m.rank, &m.name, &m.description}
+}
+
+func (m *searchMatch) model() *models.SearchMatch {
+	if !m.rank.Valid {
+		return nil
+	}
+	return &models.SearchMatch{
+		Rank:        float32(m.rank.Float64),
+		Name:        highlightMatch(m.name.String),
+		Description: highlightMatch(m.description.String),
+	}
+}
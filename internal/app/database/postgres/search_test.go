@@ -0,0 +1,20 @@
+package postgres
+
+import "testing"
+
+func TestHighlightMatch(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"Поставка \x01бумаги\x02", "Поставка <b>бумаги</b>"},
+		{"<script>alert(1)</script> \x01test\x02", "&lt;script&gt;alert(1)&lt;/script&gt; <b>test</b>"},
+		{`"quoted" & 'single'`, "&#34;quoted&#34; &amp; &#39;single&#39;"},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := highlightMatch(tc.in); got != tc.want {
+			t.Errorf("highlightMatch(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
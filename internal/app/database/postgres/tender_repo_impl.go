@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"slices"
 
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
@@ -9,106 +11,176 @@ import (
 	"go.uber.org/zap"
 )
 
-func (p *Postgres) GetTenders(ctx context.Context, params repos.GetTendersParams) ([]*models.Tender, error) {
-	var tenders []*models.Tender
+// tenderSortColumns колонки сортировки списка тендеров. Только эти значения попадают в ORDER BY
+var tenderSortColumns = map[repos.TenderSort]string{
+	repos.TenderSortName:      "t.name",
+	repos.TenderSortCreatedAt: "t.created_at",
+	repos.TenderSortUpdatedAt: "t.updated_at",
+}
+
+// tenderOrder порядок списка тендеров. По умолчанию - порядок страниц,
+// при поиске без явной сортировки - сначала самые релевантные
+func tenderOrder(params repos.GetTendersParams, searching bool, qParam int) string {
+	if params.Sort == nil && searching {
+		return searchOrder("t", qParam)
+	}
+
+	sort := repos.TenderSortCreatedAt
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+	direction := "DESC"
+	if params.Order != nil && *params.Order == repos.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	column, ok := tenderSortColumns[sort]
+	if !ok || (sort == repos.TenderSortCreatedAt && direction == "DESC") {
+		return pageOrder("t")
+	}
+	return column + " " + direction + ", t.id"
+}
+
+func (p *Postgres) GetTenders(ctx context.Context, params repos.GetTendersParams) (*models.Page[*models.Tender], error) {
+	var q repos.SearchQuery
+	if params.Q != nil {
+		q = *params.Q
+	}
+
+	b := &queryBuilder{}
+	qParam := b.argIndex(q)
 
-	serviceTypes := *params.ServiceType
+	// Тендер попадает в выдачу, если его статус публичный и он открыт или пользователь приглашен в него,
+	// а также если пользователь отвечает за его организацию
+	b.where("((t.status = ANY(?) AND (t.access = 'Open' OR t.id::text = ANY(?))) OR t.organization_id::text = ANY(?))",
+		pq.Array(params.VisibleStatuses), pq.Array(params.ViewerInvitedTenders), pq.Array(params.ViewerOrganizations))
+	if q != "" {
+		b.where("t.search_vector @@ s.query")
+	}
+	if params.ServiceType != nil && len(*params.ServiceType) > 0 {
+		b.where("t.service_type = ANY(?)", pq.Array(*params.ServiceType))
+	}
+	if params.Status != nil && len(*params.Status) > 0 {
+		b.where("t.status = ANY(?)", pq.Array(*params.Status))
+	}
+	if params.OrganizationId != nil {
+		b.where("t.organization_id::text = ?", *params.OrganizationId)
+	}
+	b.whereRange("t.created_at", params.CreatedFrom, params.CreatedTo)
+	b.whereRange("t.updated_at", params.UpdatedFrom, params.UpdatedTo)
+	b.whereRange("t.bids_close_at", params.DeadlineFrom, params.DeadlineTo)
+
+	from := `
+		FROM tenders t
+		CROSS JOIN LATERAL (SELECT ` + searchTsQuery(qParam) + ` AS query) s` + b.whereClause()
+	args := b.args
+
+	total, err := p.countTotal(ctx, params.Page, from, args)
+	if err != nil {
+		p.logger.Error("Error count tenders", zap.Error(err))
+		return nil, err
+	}
 
-	// Преобразуем в строку и используем pq.Array дальше
 	query := `
-		SELECT id, name, description, service_type, status, organization_id, created_at
-		FROM tenders
-		WHERE service_type = ANY($1)
-		LIMIT $2 OFFSET $3
-	`
+		SELECT t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at,
+			(SELECT COALESCE(MAX(version_number), 0) FROM tender_versions WHERE tender_id = t.id), ` +
+		tenderTermsColumns("t") + `,` + searchMatchColumns("t", qParam) + from + keysetCondition("t", params.Page, &args) + `
+		ORDER BY ` + tenderOrder(params, q != "", qParam) + limitOffset(params.Page, &args)
 
-	rows, err := p.db.QueryContext(ctx, query, pq.Array(serviceTypes), params.Limit, params.Offset)
+	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		p.logger.Error("Error in get tenders by service type", zap.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
+
+	page := &models.Page[*models.Tender]{Items: []*models.Tender{}, TotalCount: total}
 	for rows.Next() {
 		var tender models.Tender
-		err := rows.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
+		var terms tenderTerms
+		var match searchMatch
+		dest := append([]any{&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt, &tender.Version}, terms.dest()...)
+		err := rows.Scan(append(dest, match.dest()...)...)
 		if err != nil {
 			p.logger.Error("Error rows.Scan()", zap.Error(err))
 			return nil, err
 		}
-		var currentVersion int32
-		err = p.db.QueryRowContext(ctx, `
-			SELECT COALESCE(MAX(version_number), 0)
-			FROM tender_versions
-			WHERE tender_id = $1`, tender.Id).Scan(&currentVersion)
-		if err != nil {
-			p.logger.Error("Error get current version number", zap.Error(err))
-			return nil, err
-		}
-		tender.Version = currentVersion
-		tenders = append(tenders, &tender)
+		terms.apply(&tender)
+		tender.Match = match.model()
+		page.Items = append(page.Items, &tender)
 	}
 
-	return tenders, nil
-}
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
 
-func (p *Postgres) GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) ([]*models.Tender, error) {
-	var tenders []*models.Tender
-	var organizationId string
+	return page, nil
+}
 
-	id, err := p.GetUserIDByUsername(ctx, *params.Username)
-	if id == -1 {
-		p.logger.Error("User not found", zap.Error(err))
-		return nil, ErrUserNotFound
-	}
+func (p *Postgres) GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) (*models.Page[*models.Tender], error) {
+	organizations, err := p.GetUserOrganizations(ctx, *params.Username)
 	if err != nil {
-		p.logger.Error("Error in get user id by username", zap.Error(err))
+		p.logger.Error("Error in get user organizations", zap.Error(err))
 		return nil, err
 	}
+	if len(organizations) == 0 {
+		p.logger.Error("User is not responsible for any organization")
+		return nil, ErrUserNotAllowed
+	}
+
+	// Фильтр по организации допустим только среди организаций пользователя
+	if params.OrganizationId != nil {
+		if !slices.Contains(organizations, *params.OrganizationId) {
+			p.logger.Error("User is not responsible for organization", zap.String("organization_id", *params.OrganizationId))
+			return nil, ErrUserNotAllowed
+		}
+		organizations = []repos.OrganizationId{*params.OrganizationId}
+	}
+
+	from := `
+        FROM tenders t
+        WHERE t.organization_id = ANY($1::uuid[])`
+	args := []any{pq.Array(organizations)}
 
-	err = p.db.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, params.Username).Scan(&organizationId)
+	total, err := p.countTotal(ctx, params.Page, from, args)
 	if err != nil {
-		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return nil, ErrUserNotAllowed
+		p.logger.Error("Error count org tenders", zap.Error(err))
+		return nil, err
 	}
 
 	query := `
-        SELECT id, name, description, service_type, status, organization_id, created_at
-        FROM tenders
-        WHERE organization_id = $1
-        LIMIT $2 OFFSET $3`
+        SELECT t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at,
+            (SELECT COALESCE(MAX(version_number), 0) FROM tender_versions WHERE tender_id = t.id), ` +
+		tenderTermsColumns("t") + from + keysetCondition("t", params.Page, &args) + `
+        ORDER BY ` + pageOrder("t") + limitOffset(params.Page, &args)
 
-	rows, err := p.db.QueryContext(ctx, query, organizationId, params.Limit, params.Offset)
+	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		p.logger.Error("Error get iorg tenders", zap.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
 
+	page := &models.Page[*models.Tender]{Items: []*models.Tender{}, TotalCount: total}
 	for rows.Next() {
 		var tender models.Tender
-		err := rows.Scan(&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
+		var terms tenderTerms
+		err := rows.Scan(append([]any{&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt, &tender.Version}, terms.dest()...)...)
 		if err != nil {
 			p.logger.Error("Error rows.Scan()", zap.Error(err))
 			return nil, err
 		}
-		var currentVersion int32
-		err = p.db.QueryRowContext(ctx, `
-			SELECT COALESCE(MAX(version_number), 0)
-			FROM tender_versions
-			WHERE tender_id = $1`, tender.Id).Scan(&currentVersion)
-		if err != nil {
-			p.logger.Error("Error get current version number", zap.Error(err))
-			return nil, err
-		}
-		tender.Version = currentVersion
-		tenders = append(tenders, &tender)
+		terms.apply(&tender)
+		page.Items = append(page.Items, &tender)
 	}
 
-	return tenders, nil
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return page, nil
 }
 
 func (p *Postgres) CreateTender(ctx context.Context, params repos.CreateTenderParams) (*models.Tender, error) {
@@ -124,42 +196,45 @@ func (p *Postgres) CreateTender(ctx context.Context, params repos.CreateTenderPa
 		}
 	}()
 
-	var organizationId string
 	var tender models.Tender
+	organizationId := *params.OrganizationID
 
-	// Проверяем, что creator username является ответственным за организацию
-	err = tx.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, params.CreatorUsername).Scan(&organizationId)
+	// Проверяем, что creator username является ответственным именно за эту организацию
+	creatorId, err := p.checkOrganizationMember(ctx, tx, organizationId, *params.CreatorUsername)
 	if err != nil {
 		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return nil, ErrUserNotAllowed
+		return nil, err
 	}
 
-	if *params.OrganizationID != organizationId {
-		p.logger.Error("Not allowed")
-		return nil, ErrUserNotAllowed
+	budgetAmount, budgetCurrency := moneyArgs(params.Budget)
+	auctionStartsAt, auctionStepAmount, auctionStepCurrency, auctionExtension := auctionArgs(params.Auction)
+	access := models.TenderAccessOpen
+	if params.Access != nil {
+		access = *params.Access
 	}
-
+	var terms tenderTerms
 	err = tx.QueryRowContext(ctx, `
-    	INSERT INTO tenders (name, description, service_type, status, organization_id, created_at)
-    	VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
-    	RETURNING id, name, description, service_type, status, organization_id, created_at`,
-		params.Name, params.Description, params.ServiceType, params.Status, organizationId).Scan(
-		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
+    	INSERT INTO tenders AS t (name, description, service_type, status, organization_id, bids_open_at, bids_close_at, budget_amount, budget_currency, sealed,
+    		auction_starts_at, auction_step_amount, auction_step_currency, auction_extension_seconds, access, created_at)
+    	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, CURRENT_TIMESTAMP)
+    	RETURNING t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at, `+tenderTermsColumns("t"),
+		params.Name, params.Description, params.ServiceType, params.Status, organizationId, params.BidsOpenAt, params.BidsCloseAt, budgetAmount, budgetCurrency,
+		params.Sealed != nil && *params.Sealed, auctionStartsAt, auctionStepAmount, auctionStepCurrency, auctionExtension, access).Scan(append([]any{
+		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt},
+		terms.dest()...)...)
 	if err != nil {
 		p.logger.Error("Error create tender", zap.Error(err))
 		return nil, err
 	}
+	terms.apply(&tender)
 
-	var version repos.TenderVersion
-	err = tx.QueryRowContext(ctx, `
-    	INSERT INTO tender_versions (tender_id, version_number, name, description, service_type, status, organization_id, created_at, is_current)
-    	VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, TRUE)
-    	RETURNING version_number`,
-		tender.Id, 1, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId).Scan(&version)
+	err = p.recordTenderTransition(ctx, tx, tender.Id, nil, repos.TenderStatus(tender.Status), &creatorId)
+	if err != nil {
+		p.logger.Error("Error record tender status transition", zap.Error(err))
+		return nil, err
+	}
+
+	tender.Version, err = p.snapshotTender(ctx, tx, tender.Id, &creatorId, nil)
 	if err != nil {
 		p.logger.Error("Error create new version of tender", zap.Error(err))
 		return nil, err
@@ -171,12 +246,10 @@ func (p *Postgres) CreateTender(ctx context.Context, params repos.CreateTenderPa
 		return nil, err
 	}
 
-	tender.Version = version
 	return &tender, nil
 }
 
 func (p *Postgres) EditTender(ctx context.Context, tenderId repos.TenderId, username repos.Username, params repos.EditTenderParams) (*models.Tender, error) {
-	var organizationId string
 	var tender models.Tender
 
 	tx, err := p.db.BeginTx(ctx, nil)
@@ -191,50 +264,50 @@ func (p *Postgres) EditTender(ctx context.Context, tenderId repos.TenderId, user
 		}
 	}()
 
-	// Валидируем пользователя: существует ли и является ли ответственным за организацию
-	err = tx.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, username).Scan(&organizationId)
+	// Валидируем пользователя: является ли ответственным за организацию этого тендера
+	editorId, err := p.checkTenderResponsible(ctx, tx, tenderId, username)
 	if err != nil {
 		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return nil, ErrUserNotAllowed
+		return nil, err
 	}
 
-	err = tx.QueryRowContext(ctx, `
-        UPDATE tenders
-        SET name = $1, description = $2, service_type = $3, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $4 AND organization_id = $5
-        RETURNING id, name, description, service_type, status, organization_id, created_at`,
-		params.Name, params.Description, params.ServiceType, tenderId, organizationId).Scan(
-		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
+	err = p.checkTenderVersion(ctx, tx, tenderId, params.ExpectedVersion)
 	if err != nil {
-		p.logger.Error("Error update tender", zap.Error(err))
+		p.logger.Error("Error check tender version", zap.Error(err))
 		return nil, err
 	}
 
-	var currentVersion int32
+	budgetAmount, budgetCurrency := moneyArgs(params.Budget)
+	var terms tenderTerms
 	err = tx.QueryRowContext(ctx, `
-        SELECT COALESCE(MAX(version_number), 0)
-        FROM tender_versions
-        WHERE tender_id = $1`, tenderId).Scan(&currentVersion)
+        UPDATE tenders t
+        SET name = $1, description = $2, service_type = $3,
+            bids_open_at = COALESCE($5, bids_open_at), bids_close_at = COALESCE($6, bids_close_at),
+            budget_amount = COALESCE($7, budget_amount), budget_currency = COALESCE($8, budget_currency),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE t.id = $4
+        RETURNING t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at, `+tenderTermsColumns("t"),
+		params.Name, params.Description, params.ServiceType, tenderId, params.BidsOpenAt, params.BidsCloseAt, budgetAmount, budgetCurrency).Scan(append([]any{
+		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt},
+		terms.dest()...)...)
 	if err != nil {
-		p.logger.Error("Error get current version number", zap.Error(err))
+		p.logger.Error("Error update tender", zap.Error(err))
 		return nil, err
 	}
+	terms.apply(&tender)
 
-	tender.Version = currentVersion
-
-	_, err = tx.ExecContext(ctx, `
-        INSERT INTO tender_versions (tender_id, version_number, name, description, service_type, status, organization_id, created_at, updated_at, is_current)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, TRUE)`,
-		tender.Id, tender.Version+1, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId, tender.CreatedAt)
+	tender.Version, err = p.snapshotTender(ctx, tx, tenderId, &editorId, nil)
 	if err != nil {
 		p.logger.Error("Error create new version of tender", zap.Error(err))
 		return nil, err
 	}
 
+	err = p.emitTenderEvent(ctx, tx, models.EventTenderUpdated, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error emit tender event", zap.Error(err))
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		p.logger.Error("Error commit tx", zap.Error(err))
@@ -244,7 +317,9 @@ func (p *Postgres) EditTender(ctx context.Context, tenderId repos.TenderId, user
 	return &tender, nil
 }
 
-func (p *Postgres) RollbackTender(ctx context.Context, tenderId repos.TenderId, version int32, params repos.RollbackTenderParams) (*models.Tender, error) {
+func (p *Postgres) UpdateTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.UpdateTenderStatusParams) (*models.Tender, error) {
+	var tender models.Tender
+
 	tx, err := p.db.BeginTx(ctx, nil)
 	if err != nil {
 		p.logger.Error("Error begin tx", zap.Error(err))
@@ -257,43 +332,43 @@ func (p *Postgres) RollbackTender(ctx context.Context, tenderId repos.TenderId,
 		}
 	}()
 
-	var tender models.Tender
-	var organizationId string
-
+	// Менять статус может только ответственный за организацию, которой принадлежит тендер
+	var actorId int
 	err = tx.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, params.Username).Scan(&organizationId)
+        SELECT e.id
+        FROM employee e
+        JOIN organization_responsible r ON r.user_id = e.id
+        JOIN tenders t ON t.organization_id = r.organization_id
+        WHERE e.username = $1 AND t.id = $2`, params.Username, tenderId).Scan(&actorId)
 	if err != nil {
 		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return nil, ErrUserNotAllowed
-	}
-
-	var exists bool
-	err = tx.QueryRowContext(ctx, `
-        SELECT EXISTS(SELECT 1 FROM tenders WHERE id = $1 AND organization_id = $2)`, tenderId, organizationId).Scan(&exists)
-	if err != nil || !exists {
-		p.logger.Error("Tender not found")
-		return nil, ErrTenderNotFound
+		err = ErrUserNotAllowed
+		return nil, err
 	}
 
+	// Обновляем только если статус не успели поменять с момента проверки перехода
+	var terms tenderTerms
 	err = tx.QueryRowContext(ctx, `
-        SELECT tender_id, name, description, service_type, status, organization_id, created_at
-        FROM tender_versions
-        WHERE tender_id = $1 AND version_number = $2`, tenderId, version).Scan(
-		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
+        UPDATE tenders t
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE t.id = $2 AND t.status = $3
+        RETURNING t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at, `+tenderTermsColumns("t"),
+		params.Status, tenderId, params.CurrentStatus).Scan(append([]any{
+		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt},
+		terms.dest()...)...)
 	if err != nil {
-		p.logger.Error("Version not found")
-		return nil, ErrVersionNotFound
+		if err == sql.ErrNoRows {
+			p.logger.Error("Tender status changed concurrently")
+			err = ErrTenderStatusChanged
+			return nil, err
+		}
+		p.logger.Error("Error update tender status", zap.Error(err))
+		return nil, err
 	}
 
-	_, err = tx.ExecContext(ctx, `
-        UPDATE tender_versions
-        SET is_current = FALSE
-        WHERE tender_id = $1 AND is_current = TRUE`, tenderId)
+	err = p.recordTenderTransition(ctx, tx, tenderId, &params.CurrentStatus, params.Status, &actorId)
 	if err != nil {
-		p.logger.Error("Error update is_current of version", zap.Error(err))
+		p.logger.Error("Error record tender status transition", zap.Error(err))
 		return nil, err
 	}
 
@@ -307,120 +382,158 @@ func (p *Postgres) RollbackTender(ctx context.Context, tenderId repos.TenderId,
 		return nil, err
 	}
 
-	newVersion := currentVersion + 1
-	_, err = tx.ExecContext(ctx, `
-        INSERT INTO tender_versions (tender_id, version_number, name, description, service_type, status, organization_id, created_at, updated_at, is_current)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, TRUE)`,
-		tender.Id, newVersion, tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationId)
-	if err != nil {
-		p.logger.Error("Error insert new version in tender_versions", zap.Error(err))
-		return nil, err
-	}
-
 	err = tx.Commit()
 	if err != nil {
 		p.logger.Error("Error commit tx", zap.Error(err))
 		return nil, err
 	}
 
-	tender.Version = newVersion
+	tender.Version = currentVersion
+	terms.apply(&tender)
 	return &tender, nil
 }
 
-func (p *Postgres) GetTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderStatusParams) (repos.TenderStatus, error) {
-	var status repos.TenderStatus
+func (p *Postgres) GetTenderByID(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
+	var tender models.Tender
+	var terms tenderTerms
 
-	var organizationId string
 	err := p.db.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, params.Username).Scan(&organizationId)
+        SELECT t.id, t.name, t.description, t.service_type, t.status, t.organization_id, t.created_at, t.bids_open_at, t.bids_close_at,
+            (SELECT COALESCE(MAX(version_number), 0) FROM tender_versions WHERE tender_id = t.id), `+tenderTermsColumns("t")+`
+        FROM tenders t
+        WHERE t.id = $1`, tenderId).Scan(append([]any{
+		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.BidsOpenAt, &tender.BidsCloseAt, &tender.Version},
+		terms.dest()...)...)
 	if err != nil {
-		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return "", ErrUserNotAllowed
+		p.logger.Error("Tender not found")
+		return nil, ErrTenderNotFound
 	}
+	terms.apply(&tender)
+
+	return &tender, nil
+}
 
-	err = p.db.QueryRowContext(ctx, `
-        SELECT status
-        FROM tenders
-        WHERE id = $1 AND organization_id = $2`, tenderId, organizationId).Scan(&status)
+func (p *Postgres) IsTenderExists(ctx context.Context, tenderId repos.TenderId) (bool, error) {
+	var tenderExists bool
+	tenderQuery := `SELECT EXISTS (SELECT 1 FROM tenders WHERE id = $1)`
+	err := p.db.QueryRowContext(ctx, tenderQuery, tenderId).Scan(&tenderExists)
 	if err != nil {
+		return false, err
+	}
+
+	if !tenderExists {
 		p.logger.Error("Tender not found")
-		return "", ErrTenderNotFound
+		return false, ErrTenderNotFound
 	}
 
-	return status, nil
+	return true, nil
 }
 
-func (p *Postgres) UpdateTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.UpdateTenderStatusParams) (*models.Tender, error) {
-	var tender models.Tender
-
-	var organizationId string
-	err := p.db.QueryRowContext(ctx, `
-        SELECT r.organization_id
-        FROM organization_responsible r
-        JOIN employee e ON e.id = r.user_id
-        WHERE e.username = $1`, params.Username).Scan(&organizationId)
+// recordTenderTransition пишет переход статуса тендера в журнал.
+// from == nil для начального статуса, actorId == nil для системных переходов.
+// Вместе с записью в журнал в outbox попадает событие перехода
+func (p *Postgres) recordTenderTransition(ctx context.Context, q querier, tenderId repos.TenderId, from *repos.TenderStatus, to repos.TenderStatus, actorId *int) error {
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO tender_status_transitions (tender_id, from_status, to_status, actor_id, created_at)
+        VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)`, tenderId, from, to, actorId)
 	if err != nil {
-		p.logger.Error("Error in check org responsible", zap.Error(err))
-		return nil, ErrUserNotAllowed
+		return err
 	}
 
-	err = p.db.QueryRowContext(ctx, `
-        UPDATE tenders
-        SET status = $1, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $2 AND organization_id = $3
-        RETURNING id, name, description, service_type, status, organization_id, created_at`,
-		params.Status, tenderId, organizationId).Scan(
-		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt)
-	if err != nil {
-		p.logger.Error("Error update tender status", zap.Error(err))
-		return nil, err
+	if from == nil {
+		if err := p.emitTenderEvent(ctx, q, models.EventTenderCreated, tenderId, nil); err != nil {
+			return err
+		}
+		if to != repos.TenderStatusPublished {
+			return nil
+		}
 	}
+	return p.emitTenderEvent(ctx, q, tenderTransitionEvent(to), tenderId, from)
+}
 
-	var currentVersion int32
-	err = p.db.QueryRowContext(ctx, `
-        SELECT COALESCE(MAX(version_number), 0)
-        FROM tender_versions
-        WHERE tender_id = $1`, tenderId).Scan(&currentVersion)
+func (p *Postgres) GetTender(ctx context.Context, tenderId repos.TenderId, username repos.Username) (*models.Tender, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
 	if err != nil {
-		p.logger.Error("Error get current version number", zap.Error(err))
+		p.logger.Error("Error in check org responsible", zap.Error(err))
 		return nil, err
 	}
 
-	tender.Version = currentVersion
-	return &tender, nil
+	return p.GetTenderByID(ctx, tenderId)
 }
 
-func (p *Postgres) GetTenderByID(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
-	var tender models.Tender
+// closeExpiredTendersLockKey ключ advisory-блокировки, под которой закрываются просроченные тендеры.
+// Блокировка транзакционная, поэтому при нескольких инстансах сервиса за один тик тендеры закрывает только один из них
+const closeExpiredTendersLockKey int64 = 0x74656e646572
 
-	err := p.db.QueryRowContext(ctx, `
-        SELECT id, name, description, service_type, status, organization_id, created_at, version
-        FROM tenders
-        WHERE id = $1`, tenderId).Scan(
-		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.Status, &tender.OrganizationId, &tender.CreatedAt, &tender.Version)
+// CloseExpiredTenders переводит в Closed опубликованные тендеры, у которых истек срок приема предложений.
+// Если блокировку держит другой инстанс, ничего не делает и возвращает пустой список
+func (p *Postgres) CloseExpiredTenders(ctx context.Context) ([]*models.TenderClosedEvent, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
 	if err != nil {
-		p.logger.Error("Tender not found")
-		return nil, ErrTenderNotFound
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
 	}
 
-	return &tender, nil
-}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
-func (p *Postgres) IsTenderExists(ctx context.Context, tenderId repos.TenderId) (bool, error) {
-	var tenderExists bool
-	tenderQuery := `SELECT EXISTS (SELECT 1 FROM tenders WHERE id = $1)`
-	err := p.db.QueryRowContext(ctx, tenderQuery, tenderId).Scan(&tenderExists)
+	var locked bool
+	err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, closeExpiredTendersLockKey).Scan(&locked)
 	if err != nil {
-		return false, err
+		p.logger.Error("Error acquire advisory lock", zap.Error(err))
+		return nil, err
+	}
+	if !locked {
+		tx.Rollback()
+		return nil, nil
 	}
 
-	if !tenderExists {
-		p.logger.Error("Tender not found")
-		return false, ErrTenderNotFound
+	rows, err := tx.QueryContext(ctx, `
+        UPDATE tenders
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE status = $2 AND bids_close_at <= CURRENT_TIMESTAMP
+        RETURNING id, organization_id, bids_close_at, updated_at`,
+		repos.TenderStatusClosed, repos.TenderStatusPublished)
+	if err != nil {
+		p.logger.Error("Error close expired tenders", zap.Error(err))
+		return nil, err
 	}
 
-	return true, nil
+	var events []*models.TenderClosedEvent
+	for rows.Next() {
+		var event models.TenderClosedEvent
+		err = rows.Scan(&event.TenderId, &event.OrganizationId, &event.BidsCloseAt, &event.ClosedAt)
+		if err != nil {
+			rows.Close()
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	from := repos.TenderStatusPublished
+	for _, event := range events {
+		// Системный переход: actor_id не заполняется
+		err = p.recordTenderTransition(ctx, tx, event.TenderId, &from, repos.TenderStatusClosed, nil)
+		if err != nil {
+			p.logger.Error("Error record tender status transition", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return events, nil
 }
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// tenderSnapshotColumns содержимое тендера, которое попадает в каждую версию.
+// Новое поле тендера достаточно добавить в tender_versions и в этот список.
+var tenderSnapshotColumns = []string{"name", "description", "service_type", "status", "organization_id", "bids_open_at", "bids_close_at", "budget_amount", "budget_currency"}
+
+// snapshotTender делает текущую версию неактуальной и сохраняет новую версию
+// с полным текущим содержимым тендера и набором его вложений. Должна вызываться в той же транзакции, что и изменение.
+func (p *Postgres) snapshotTender(ctx context.Context, q querier, tenderId repos.TenderId, editorId *int, rolledBackFrom *int32) (int32, error) {
+	_, err := q.ExecContext(ctx, `
+        UPDATE tender_versions
+        SET is_current = FALSE
+        WHERE tender_id = $1 AND is_current = TRUE`, tenderId)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := strings.Join(tenderSnapshotColumns, ", ")
+	values := "t." + strings.Join(tenderSnapshotColumns, ", t.")
+
+	var version int32
+	err = q.QueryRowContext(ctx, `
+        INSERT INTO tender_versions (tender_id, version_number, edited_by, rolled_back_from, created_at, updated_at, is_current, attachment_ids, `+columns+`)
+        SELECT t.id,
+            (SELECT COALESCE(MAX(version_number), 0) + 1 FROM tender_versions WHERE tender_id = t.id),
+            $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, TRUE, `+tenderAttachments.snapshotIds("t")+`, `+values+`
+        FROM tenders t
+        WHERE t.id = $1
+        RETURNING version_number`, tenderId, editorId, rolledBackFrom).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// checkTenderResponsible проверяет, что пользователь является ответственным
+// за организацию, которой принадлежит тендер, и возвращает его id
+func (p *Postgres) checkTenderResponsible(ctx context.Context, q querier, tenderId repos.TenderId, username repos.Username) (int, error) {
+	var tenderExists bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM tenders WHERE id = $1)`, tenderId).Scan(&tenderExists)
+	if err != nil {
+		return 0, err
+	}
+	if !tenderExists {
+		return 0, ErrTenderNotFound
+	}
+
+	var userId int
+	err = q.QueryRowContext(ctx, `
+        SELECT e.id
+        FROM employee e
+        JOIN organization_responsible r ON r.user_id = e.id
+        JOIN tenders t ON t.organization_id = r.organization_id
+        WHERE e.username = $1 AND t.id = $2`, username, tenderId).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotAllowed
+	} else if err != nil {
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+// checkTenderVersion блокирует строку тендера до конца транзакции и,
+// если клиент передал ожидаемую версию, сверяет ее с текущей
+func (p *Postgres) checkTenderVersion(ctx context.Context, q querier, tenderId repos.TenderId, expected *int32) error {
+	var current int32
+	err := q.QueryRowContext(ctx, `
+        SELECT (SELECT COALESCE(MAX(version_number), 0) FROM tender_versions WHERE tender_id = t.id)
+        FROM tenders t
+        WHERE t.id = $1
+        FOR UPDATE OF t`, tenderId).Scan(&current)
+	if err == sql.ErrNoRows {
+		return ErrTenderNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if expected != nil && *expected != current {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (p *Postgres) RollbackTender(ctx context.Context, tenderId repos.TenderId, version int32, params repos.RollbackTenderParams) (*models.Tender, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var tender models.Tender
+
+	editorId, err := p.checkTenderResponsible(ctx, tx, tenderId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.checkTenderVersion(ctx, tx, tenderId, params.ExpectedVersion)
+	if err != nil {
+		p.logger.Error("Error check tender version", zap.Error(err))
+		return nil, err
+	}
+
+	var budget moneyValue
+	err = tx.QueryRowContext(ctx, `
+        SELECT v.tender_id, v.name, v.description, v.service_type, v.organization_id, v.bids_open_at, v.bids_close_at, `+moneyColumns("v", "budget")+`
+        FROM tender_versions v
+        WHERE v.tender_id = $1 AND v.version_number = $2`, tenderId, version).Scan(append([]any{
+		&tender.Id, &tender.Name, &tender.Description, &tender.ServiceType, &tender.OrganizationId, &tender.BidsOpenAt, &tender.BidsCloseAt},
+		budget.dest()...)...)
+	if err != nil {
+		p.logger.Error("Version not found")
+		err = ErrVersionNotFound
+		return nil, err
+	}
+
+	// Откат восстанавливает содержимое и вложения тендера, статус меняется только через переходы жизненного цикла
+	tender.Budget = budget.model()
+	budgetAmount, budgetCurrency := moneyArgs(tender.Budget)
+	var terms tenderTerms
+	err = tx.QueryRowContext(ctx, `
+        UPDATE tenders
+        SET name = $1, description = $2, service_type = $3, bids_open_at = $4, bids_close_at = $5,
+            budget_amount = $6, budget_currency = $7, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $8
+        RETURNING status, created_at, `+tenderTermsColumns("tenders"), tender.Name, tender.Description, tender.ServiceType, tender.BidsOpenAt, tender.BidsCloseAt,
+		budgetAmount, budgetCurrency, tenderId).Scan(append([]any{&tender.Status, &tender.CreatedAt}, terms.dest()...)...)
+	if err != nil {
+		p.logger.Error("Error rollback tender to version", zap.Error(err))
+		return nil, err
+	}
+	terms.apply(&tender)
+
+	err = p.restoreAttachments(ctx, tx, tenderAttachments, tenderId, version)
+	if err != nil {
+		p.logger.Error("Error restore tender attachments", zap.Error(err))
+		return nil, err
+	}
+
+	tender.Version, err = p.snapshotTender(ctx, tx, tenderId, &editorId, &version)
+	if err != nil {
+		p.logger.Error("Error insert new version in tender_versions", zap.Error(err))
+		return nil, err
+	}
+
+	err = p.emitTenderEvent(ctx, tx, models.EventTenderUpdated, tenderId, nil)
+	if err != nil {
+		p.logger.Error("Error emit tender event", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+func (p *Postgres) GetTenderVersions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderVersionsParams) ([]*models.TenderVersionInfo, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT v.version_number, v.name, v.description, v.service_type, v.status, v.bids_open_at, v.bids_close_at,
+            e.username, v.updated_at, v.is_current, v.rolled_back_from, v.attachment_ids, `+moneyColumns("v", "budget")+`
+        FROM tender_versions v
+        LEFT JOIN employee e ON e.id = v.edited_by
+        WHERE v.tender_id = $1
+        ORDER BY v.version_number DESC
+        LIMIT $2 OFFSET $3`, tenderId, params.Limit, params.Offset)
+	if err != nil {
+		p.logger.Error("Error get list of tender versions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*models.TenderVersionInfo
+	for rows.Next() {
+		var v models.TenderVersionInfo
+		var budget moneyValue
+		err := rows.Scan(append([]any{&v.Version, &v.Name, &v.Description, &v.ServiceType, &v.Status, &v.BidsOpenAt, &v.BidsCloseAt,
+			&v.AuthorUsername, &v.CreatedAt, &v.IsCurrent, &v.RolledBackFrom, pq.Array(&v.Attachments)}, budget.dest()...)...)
+		if err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		v.Budget = budget.model()
+		versions = append(versions, &v)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (p *Postgres) GetTenderVersion(ctx context.Context, tenderId repos.TenderId, version int32, username repos.Username) (*models.TenderVersionInfo, error) {
+	_, err := p.checkTenderResponsible(ctx, p.db, tenderId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	var v models.TenderVersionInfo
+	var budget moneyValue
+	err = p.db.QueryRowContext(ctx, `
+        SELECT v.version_number, v.name, v.description, v.service_type, v.status, v.bids_open_at, v.bids_close_at,
+            e.username, v.updated_at, v.is_current, v.rolled_back_from, v.attachment_ids, `+moneyColumns("v", "budget")+`
+        FROM tender_versions v
+        LEFT JOIN employee e ON e.id = v.edited_by
+        WHERE v.tender_id = $1 AND v.version_number = $2`, tenderId, version).Scan(append([]any{
+		&v.Version, &v.Name, &v.Description, &v.ServiceType, &v.Status, &v.BidsOpenAt, &v.BidsCloseAt,
+		&v.AuthorUsername, &v.CreatedAt, &v.IsCurrent, &v.RolledBackFrom, pq.Array(&v.Attachments)}, budget.dest()...)...)
+	if err == sql.ErrNoRows {
+		p.logger.Error("Version not found")
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		p.logger.Error("Error get tender version", zap.Error(err))
+		return nil, err
+	}
+	v.Budget = budget.model()
+
+	return &v, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"go.uber.org/zap"
 )
 
 func (p *Postgres) GetUserIDByUsername(ctx context.Context, username repos.Username) (int, error) {
@@ -19,3 +20,106 @@ func (p *Postgres) GetUserIDByUsername(ctx context.Context, username repos.Usern
 
 	return userID, nil
 }
+
+// GetUserOrganizations возвращает все организации, за которые отвечает пользователь.
+// Пустой список означает, что пользователь существует, но не является ответственным
+func (p *Postgres) GetUserOrganizations(ctx context.Context, username repos.Username) ([]repos.OrganizationId, error) {
+	return p.getUserOrganizations(ctx, p.db, username)
+}
+
+func (p *Postgres) getUserOrganizations(ctx context.Context, q querier, username repos.Username) ([]repos.OrganizationId, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT e.id, r.organization_id
+        FROM employee e
+        LEFT JOIN organization_responsible r ON r.user_id = e.id
+        WHERE e.username = $1
+        ORDER BY r.organization_id`, username)
+	if err != nil {
+		p.logger.Error("Error get user organizations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := false
+	organizations := []repos.OrganizationId{}
+	for rows.Next() {
+		var userId int
+		var organizationId sql.NullString
+		if err := rows.Scan(&userId, &organizationId); err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		found = true
+		if organizationId.Valid {
+			organizations = append(organizations, organizationId.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrUserNotFound
+	}
+
+	return organizations, nil
+}
+
+// checkOrganizationMember проверяет, что пользователь отвечает именно за указанную организацию, и возвращает его id
+func (p *Postgres) checkOrganizationMember(ctx context.Context, q querier, organizationId repos.OrganizationId, username repos.Username) (int, error) {
+	var userId int
+	var member bool
+	err := q.QueryRowContext(ctx, `
+        SELECT e.id, EXISTS(
+            SELECT 1 FROM organization_responsible r
+            WHERE r.user_id = e.id AND r.organization_id::text = $2)
+        FROM employee e
+        WHERE e.username = $1`, username, organizationId).Scan(&userId, &member)
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	if !member {
+		return 0, ErrUserNotAllowed
+	}
+
+	return userId, nil
+}
+
+// GetUserInvitedTenders возвращает тендеры, в которые пользователь приглашен лично или через свою организацию
+// и приглашение не отклонено
+func (p *Postgres) GetUserInvitedTenders(ctx context.Context, userId int) ([]repos.TenderId, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT DISTINCT i.tender_id
+        FROM tender_invitations i
+        WHERE i.status <> 'Declined'
+        AND (
+            i.employee_id = $1
+            OR i.organization_id IN (SELECT r.organization_id FROM organization_responsible r WHERE r.user_id = $1)
+        )`, userId)
+	if err != nil {
+		p.logger.Error("Error get user invited tenders", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenders := []repos.TenderId{}
+	for rows.Next() {
+		var tenderId repos.TenderId
+		if err := rows.Scan(&tenderId); err != nil {
+			p.logger.Error("Error rows.Scan()", zap.Error(err))
+			return nil, err
+		}
+		tenders = append(tenders, tenderId)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error rows.Err()", zap.Error(err))
+		return nil, err
+	}
+
+	return tenders, nil
+}
@@ -0,0 +1,470 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found in organization")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryPending  = errors.New("webhook delivery is still pending")
+)
+
+const webhookColumns = `w.id, w.organization_id, w.url, w.event_types, w.active, e.username, w.created_at, w.updated_at`
+
+const webhookFrom = `
+        FROM webhook_subscriptions w
+        LEFT JOIN employee e ON e.id = w.created_by`
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, d.event_type, d.status, d.attempts,
+        CASE WHEN d.status = 'Pending' THEN d.next_attempt_at END, d.last_status_code, d.last_error, d.delivered_at, d.created_at`
+
+func webhookDeliveryDest(d *models.WebhookDelivery) []any {
+	return []any{&d.Id, &d.WebhookId, &d.EventId, &d.EventType, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt, &d.CreatedAt}
+}
+
+func (p *Postgres) CreateWebhook(ctx context.Context, organizationId repos.OrganizationId, username repos.Username, url string, eventTypes []models.DomainEventType, secret string) (*models.Webhook, error) {
+	userId, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	var webhookId repos.WebhookId
+	err = p.db.QueryRowContext(ctx, `
+        INSERT INTO webhook_subscriptions (organization_id, url, event_types, secret, created_by)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id`, organizationId, url, pq.Array(eventTypeStrings(eventTypes)), secret, userId).Scan(&webhookId)
+	if err != nil {
+		p.logger.Error("Error insert webhook", zap.Error(err))
+		return nil, err
+	}
+
+	webhook, err := p.getWebhook(ctx, p.db, organizationId, webhookId)
+	if err != nil {
+		p.logger.Error("Error get webhook", zap.Error(err))
+		return nil, err
+	}
+	webhook.Secret = &secret
+
+	return webhook, nil
+}
+
+func (p *Postgres) GetWebhooks(ctx context.Context, organizationId repos.OrganizationId, username repos.Username, page repos.PageRequest) (*models.Page[*models.Webhook], error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	from := webhookFrom + `
+        WHERE w.organization_id = $1`
+	args := []any{organizationId}
+
+	total, err := p.countTotal(ctx, page, from, args)
+	if err != nil {
+		p.logger.Error("Error count webhooks", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+webhookColumns+from+keysetCondition("w", page, &args)+`
+        ORDER BY `+pageOrder("w")+limitOffset(page, &args), args...)
+	if err != nil {
+		p.logger.Error("Error get list of webhooks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := &models.Page[*models.Webhook]{Items: []*models.Webhook{}, TotalCount: total}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows.Scan)
+		if err != nil {
+			p.logger.Error("Error scan webhook", zap.Error(err))
+			return nil, err
+		}
+		webhooks.Items = append(webhooks.Items, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error iterate webhooks", zap.Error(err))
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (p *Postgres) GetWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username) (*models.Webhook, error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	return p.getWebhook(ctx, p.db, organizationId, webhookId)
+}
+
+func (p *Postgres) EditWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.EditWebhookParams) (*models.Webhook, error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, params.Username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+
+	var eventTypes any
+	if params.EventTypes != nil {
+		eventTypes = pq.Array(eventTypeStrings(*params.EventTypes))
+	}
+
+	res, err := p.db.ExecContext(ctx, `
+        UPDATE webhook_subscriptions
+        SET url = COALESCE($3, url), event_types = COALESCE($4, event_types),
+            secret = COALESCE($5, secret), active = COALESCE($6, active),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND organization_id = $2`, webhookId, organizationId, params.Url, eventTypes, params.Secret, params.Active)
+	if err != nil {
+		p.logger.Error("Error update webhook", zap.Error(err))
+		return nil, err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return nil, ErrWebhookNotFound
+	}
+
+	webhook, err := p.getWebhook(ctx, p.db, organizationId, webhookId)
+	if err != nil {
+		p.logger.Error("Error get webhook", zap.Error(err))
+		return nil, err
+	}
+	// Новый ключ возвращается один раз, чтобы его можно было сверить с настройками получателя
+	webhook.Secret = params.Secret
+
+	return webhook, nil
+}
+
+func (p *Postgres) DeleteWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username) error {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return err
+	}
+
+	res, err := p.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1 AND organization_id = $2`, webhookId, organizationId)
+	if err != nil {
+		p.logger.Error("Error delete webhook", zap.Error(err))
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (p *Postgres) GetWebhookDeliveries(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username, status *repos.WebhookDeliveryStatus, page repos.PageRequest) (*models.Page[*models.WebhookDelivery], error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+	if _, err := p.getWebhook(ctx, p.db, organizationId, webhookId); err != nil {
+		return nil, err
+	}
+
+	from := `
+        FROM webhook_deliveries d
+        WHERE d.subscription_id = $1
+        AND ($2::webhook_delivery_status IS NULL OR d.status = $2)`
+	args := []any{webhookId, status}
+
+	total, err := p.countTotal(ctx, page, from, args)
+	if err != nil {
+		p.logger.Error("Error count webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT `+webhookDeliveryColumns+from+keysetCondition("d", page, &args)+`
+        ORDER BY `+pageOrder("d")+limitOffset(page, &args), args...)
+	if err != nil {
+		p.logger.Error("Error get list of webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := &models.Page[*models.WebhookDelivery]{Items: []*models.WebhookDelivery{}, TotalCount: total}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(webhookDeliveryDest(&delivery)...); err != nil {
+			p.logger.Error("Error scan webhook delivery", zap.Error(err))
+			return nil, err
+		}
+		deliveries.Items = append(deliveries.Items, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error iterate webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (p *Postgres) GetWebhookDelivery(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, username repos.Username) (*models.WebhookDelivery, error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+	if _, err := p.getWebhook(ctx, p.db, organizationId, webhookId); err != nil {
+		return nil, err
+	}
+
+	return p.getWebhookDelivery(ctx, webhookId, deliveryId)
+}
+
+// RedeliverWebhook возвращает завершенную доставку в очередь с новым счетчиком попыток.
+// Журнал прошлых попыток сохраняется
+func (p *Postgres) RedeliverWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, username repos.Username) (*models.WebhookDelivery, error) {
+	_, err := p.checkOrganizationResponsible(ctx, p.db, organizationId, username)
+	if err != nil {
+		p.logger.Error("Error in check org responsible", zap.Error(err))
+		return nil, err
+	}
+	if _, err := p.getWebhook(ctx, p.db, organizationId, webhookId); err != nil {
+		return nil, err
+	}
+
+	var status repos.WebhookDeliveryStatus
+	err = p.db.QueryRowContext(ctx, `
+        WITH current AS (
+            SELECT id, status FROM webhook_deliveries WHERE id = $1 AND subscription_id = $2
+        ), requeued AS (
+            UPDATE webhook_deliveries d
+            SET status = 'Pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, delivered_at = NULL
+            FROM current c
+            WHERE d.id = c.id AND c.status <> 'Pending'
+        )
+        SELECT status FROM current`, deliveryId, webhookId).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookDeliveryNotFound
+	} else if err != nil {
+		p.logger.Error("Error requeue webhook delivery", zap.Error(err))
+		return nil, err
+	}
+	if status == models.WebhookDeliveryPending {
+		return nil, ErrWebhookDeliveryPending
+	}
+
+	return p.getWebhookDelivery(ctx, webhookId, deliveryId)
+}
+
+// EnqueueWebhookDeliveries событие получают подписки организации-владельца тендера и организации-автора предложения.
+// Черновик предложения владельцу тендера не виден, поэтому его события уходят только автору.
+// Видимость определяется по статусу до события: отмена черновика тоже остается у автора
+func (p *Postgres) EnqueueWebhookDeliveries(ctx context.Context, event models.DomainEvent, body []byte) (int64, error) {
+	var ref struct {
+		TenderId       string            `json:"tenderId"`
+		BidId          string            `json:"bidId"`
+		Status         models.BidStatus  `json:"status"`
+		PreviousStatus *models.BidStatus `json:"previousStatus"`
+	}
+	if err := json.Unmarshal(event.Payload, &ref); err != nil {
+		p.logger.Error("Error decode event payload", zap.String("eventId", event.EventId), zap.Error(err))
+		return 0, err
+	}
+	before := ref.Status
+	if ref.PreviousStatus != nil {
+		before = *ref.PreviousStatus
+	}
+	ownerVisible := event.AggregateType != models.EventAggregateBid || before != models.BidStatus(repos.BidStatusCreated)
+
+	res, err := p.db.ExecContext(ctx, `
+        INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+        SELECT s.id, $1, $2, $3
+        FROM webhook_subscriptions s
+        WHERE s.active
+            AND (cardinality(s.event_types) = 0 OR $2::text = ANY(s.event_types))
+            AND s.organization_id IN (
+                SELECT t.organization_id FROM tenders t WHERE t.id::text = $4 AND $6::boolean
+                UNION
+                SELECT b.organization_id FROM bids b WHERE b.id::text = $5 AND b.organization_id IS NOT NULL)
+        ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+		event.EventId, event.Type, body, ref.TenderId, ref.BidId, ownerVisible)
+	if err != nil {
+		p.logger.Error("Error enqueue webhook deliveries", zap.Error(err))
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ClaimWebhookDeliveries доставки отключенных подписок ждут, пока подписку не включат снова
+func (p *Postgres) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookRequest, error) {
+	rows, err := p.db.QueryContext(ctx, `
+        UPDATE webhook_deliveries d
+        SET attempts = d.attempts + 1, next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+        FROM webhook_subscriptions s
+        WHERE s.id = d.subscription_id AND d.id IN (
+            SELECT dd.id
+            FROM webhook_deliveries dd
+            JOIN webhook_subscriptions ss ON ss.id = dd.subscription_id
+            WHERE dd.status = 'Pending' AND dd.next_attempt_at <= CURRENT_TIMESTAMP AND ss.active
+            ORDER BY dd.next_attempt_at
+            LIMIT $1
+            FOR UPDATE OF dd SKIP LOCKED)
+        RETURNING d.id, d.event_id, d.event_type, s.url, s.secret, d.payload, d.attempts`, limit, lease.Seconds())
+	if err != nil {
+		p.logger.Error("Error claim webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*models.WebhookRequest
+	for rows.Next() {
+		var request models.WebhookRequest
+		if err := rows.Scan(&request.DeliveryId, &request.EventId, &request.EventType, &request.Url, &request.Secret, &request.Payload, &request.Attempt); err != nil {
+			p.logger.Error("Error scan webhook delivery", zap.Error(err))
+			return nil, err
+		}
+		requests = append(requests, &request)
+	}
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error iterate webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// CompleteWebhookDelivery результат применяется, только если доставку не успели повторить вручную во время запроса
+func (p *Postgres) CompleteWebhookDelivery(ctx context.Context, request models.WebhookRequest, result models.WebhookAttemptResult, status models.WebhookDeliveryStatus, retryIn time.Duration) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		p.logger.Error("Error begin tx", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+        SELECT $1, COUNT(*) + 1, $2, $3, $4
+        FROM webhook_delivery_attempts
+        WHERE delivery_id = $1`, request.DeliveryId, result.StatusCode, result.Error, result.DurationMs)
+	if err != nil {
+		p.logger.Error("Error insert webhook delivery attempt", zap.Error(err))
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = $3::webhook_delivery_status,
+            delivered_at = CASE WHEN $3::webhook_delivery_status = 'Delivered' THEN CURRENT_TIMESTAMP END,
+            next_attempt_at = CASE WHEN $3::webhook_delivery_status = 'Pending'
+                THEN CURRENT_TIMESTAMP + make_interval(secs => $4::float8) ELSE next_attempt_at END,
+            last_status_code = $5, last_error = $6
+        WHERE id = $1 AND attempts = $2 AND status = 'Pending'`,
+		request.DeliveryId, request.Attempt, status, retryIn.Seconds(), result.StatusCode, result.Error)
+	if err != nil {
+		p.logger.Error("Error update webhook delivery", zap.Error(err))
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		p.logger.Error("Error commit tx", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (p *Postgres) getWebhook(ctx context.Context, q querier, organizationId repos.OrganizationId, webhookId repos.WebhookId) (*models.Webhook, error) {
+	webhook, err := scanWebhook(q.QueryRowContext(ctx, `
+        SELECT `+webhookColumns+webhookFrom+`
+        WHERE w.id = $1 AND w.organization_id = $2`, webhookId, organizationId).Scan)
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookNotFound
+	} else if err != nil {
+		p.logger.Error("Error get webhook", zap.Error(err))
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (p *Postgres) getWebhookDelivery(ctx context.Context, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var payload []byte
+	err := p.db.QueryRowContext(ctx, `
+        SELECT `+webhookDeliveryColumns+`, d.payload
+        FROM webhook_deliveries d
+        WHERE d.id = $1 AND d.subscription_id = $2`, deliveryId, webhookId).Scan(append(webhookDeliveryDest(&delivery), &payload)...)
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookDeliveryNotFound
+	} else if err != nil {
+		p.logger.Error("Error get webhook delivery", zap.Error(err))
+		return nil, err
+	}
+	delivery.Payload = payload
+
+	rows, err := p.db.QueryContext(ctx, `
+        SELECT attempt, status_code, error, duration_ms, created_at
+        FROM webhook_delivery_attempts
+        WHERE delivery_id = $1
+        ORDER BY attempt`, deliveryId)
+	if err != nil {
+		p.logger.Error("Error get webhook delivery attempts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt models.WebhookDeliveryAttempt
+		if err := rows.Scan(&attempt.Attempt, &attempt.StatusCode, &attempt.Error, &attempt.DurationMs, &attempt.CreatedAt); err != nil {
+			p.logger.Error("Error scan webhook delivery attempt", zap.Error(err))
+			return nil, err
+		}
+		delivery.AttemptLog = append(delivery.AttemptLog, &attempt)
+	}
+	if err := rows.Err(); err != nil {
+		p.logger.Error("Error iterate webhook delivery attempts", zap.Error(err))
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// scanWebhook читает подписку без ключа подписи: он возвращается только при создании и смене
+func scanWebhook(scan func(dest ...any) error) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var eventTypes []string
+	err := scan(&webhook.Id, &webhook.OrganizationId, &webhook.Url, pq.Array(&eventTypes), &webhook.Active,
+		&webhook.CreatedBy, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.EventTypes = make([]models.DomainEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, models.DomainEventType(t))
+	}
+	return &webhook, nil
+}
+
+func eventTypeStrings(eventTypes []models.DomainEventType) []string {
+	types := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		types = append(types, string(t))
+	}
+	return types
+}
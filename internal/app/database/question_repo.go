@@ -0,0 +1,17 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type QuestionRepository interface {
+	CreateTenderQuestion(ctx context.Context, tenderId repos.TenderId, username repos.Username, question string) (*models.TenderQuestion, error)
+	EditTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, username repos.Username, question string) (*models.TenderQuestion, error)
+	AnswerTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, username repos.Username, answer string, visibility repos.AnswerVisibility) (*models.TenderQuestion, error)
+	GetTenderQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId) (*models.TenderQuestion, error)
+	GetTenderQuestions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderQuestionsParams) (*models.Page[*models.TenderQuestion], error)
+	GetTenderQuestionVersions(ctx context.Context, questionId repos.QuestionId, limit repos.PaginationLimit, offset repos.PaginationOffset) ([]*models.TenderQuestionVersion, error)
+}
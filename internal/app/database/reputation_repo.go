@@ -0,0 +1,12 @@
+package database
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type ReputationRepository interface {
+	GetBidderReputation(ctx context.Context, subjectType repos.BidAuthorType, subjectId string) (*models.BidderReputation, error)
+}
@@ -8,15 +8,22 @@ import (
 )
 
 type TenderRepository interface {
-	GetTenders(ctx context.Context, params repos.GetTendersParams) ([]*models.Tender, error)
-	GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) ([]*models.Tender, error)
+	GetTenders(ctx context.Context, params repos.GetTendersParams) (*models.Page[*models.Tender], error)
+	GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) (*models.Page[*models.Tender], error)
 	CreateTender(ctx context.Context, params repos.CreateTenderParams) (*models.Tender, error)
 	EditTender(ctx context.Context, tenderId repos.TenderId, username repos.Username, params repos.EditTenderParams) (*models.Tender, error)
 
 	RollbackTender(ctx context.Context, tenderId repos.TenderId, version int32, params repos.RollbackTenderParams) (*models.Tender, error)
-	GetTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderStatusParams) (repos.TenderStatus, error)
+	GetTenderVersions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderVersionsParams) ([]*models.TenderVersionInfo, error)
+	GetTenderVersion(ctx context.Context, tenderId repos.TenderId, version int32, username repos.Username) (*models.TenderVersionInfo, error)
 	UpdateTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.UpdateTenderStatusParams) (*models.Tender, error)
 	GetTenderByID(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error)
+	GetTender(ctx context.Context, tenderId repos.TenderId, username repos.Username) (*models.Tender, error)
 
 	IsTenderExists(ctx context.Context, tenderId repos.TenderId) (bool, error)
+
+	CloseExpiredTenders(ctx context.Context) ([]*models.TenderClosedEvent, error)
+
+	RevealTenderBids(ctx context.Context, tenderId repos.TenderId, trigger string, actor *repos.Username, open func(payload []byte) (*models.SealedBidContent, error)) (bool, error)
+	GetTenderAudit(ctx context.Context, tenderId repos.TenderId, username repos.Username) ([]*models.TenderAuditEntry, error)
 }
@@ -8,4 +8,6 @@ import (
 
 type UserRepository interface {
 	GetUserIDByUsername(ctx context.Context, username repos.Username) (int, error)
+	GetUserOrganizations(ctx context.Context, username repos.Username) ([]repos.OrganizationId, error)
+	GetUserInvitedTenders(ctx context.Context, userId int) ([]repos.TenderId, error)
 }
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, organizationId repos.OrganizationId, username repos.Username, url string, eventTypes []models.DomainEventType, secret string) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, organizationId repos.OrganizationId, username repos.Username, page repos.PageRequest) (*models.Page[*models.Webhook], error)
+	GetWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username) (*models.Webhook, error)
+	EditWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.EditWebhookParams) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username) error
+	GetWebhookDeliveries(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, username repos.Username, status *repos.WebhookDeliveryStatus, page repos.PageRequest) (*models.Page[*models.WebhookDelivery], error)
+	GetWebhookDelivery(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, username repos.Username) (*models.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, username repos.Username) (*models.WebhookDelivery, error)
+
+	// EnqueueWebhookDeliveries создает доставки события подпискам организаций, которых оно касается.
+	// Повторный вызов для того же события новых доставок не создает
+	EnqueueWebhookDeliveries(ctx context.Context, event models.DomainEvent, body []byte) (int64, error)
+	// ClaimWebhookDeliveries забирает до limit готовых доставок и откладывает их на lease,
+	// чтобы другой инстанс не отправил их, пока идет запрос
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookRequest, error)
+	// CompleteWebhookDelivery записывает результат попытки и переводит доставку в status.
+	// Для Pending следующая попытка планируется через retryIn
+	CompleteWebhookDelivery(ctx context.Context, request models.WebhookRequest, result models.WebhookAttemptResult, status models.WebhookDeliveryStatus, retryIn time.Duration) error
+}
@@ -0,0 +1,38 @@
+package models
+
+// Attachment Файл, приложенный к тендеру или предложению
+type Attachment struct {
+	// Id Уникальный идентификатор вложения, присвоенный сервером.
+	Id AttachmentId `json:"id"`
+
+	// TenderId Тендер, к которому приложен файл. Пусто для вложений предложения.
+	TenderId *TenderId `json:"tenderId,omitempty"`
+
+	// BidId Предложение, к которому приложен файл. Пусто для вложений тендера.
+	BidId *BidId `json:"bidId,omitempty"`
+
+	// FileName Имя файла, под которым он был загружен
+	FileName string `json:"fileName"`
+
+	// ContentType MIME-тип файла
+	ContentType string `json:"contentType"`
+
+	// Size Размер файла в байтах
+	Size int64 `json:"size"`
+
+	// Sha256 Контрольная сумма содержимого в hex
+	Sha256 string `json:"sha256"`
+
+	// UploadedBy Пользователь, загрузивший файл
+	UploadedBy *Username `json:"uploadedBy,omitempty"`
+
+	// CreatedAt Серверная дата и время загрузки.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// StorageKey Ключ содержимого в хранилище файлов
+	StorageKey string `json:"-"`
+}
+
+// AttachmentId Уникальный идентификатор вложения, присвоенный сервером.
+type AttachmentId = string
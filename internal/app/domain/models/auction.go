@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// AuctionPhase Этап редукциона
+type AuctionPhase string
+
+const (
+	// AuctionPhaseScheduled Прием предложений для допуска к торгам, торги еще не начались
+	AuctionPhaseScheduled AuctionPhase = "Scheduled"
+	// AuctionPhaseRunning Идут торги: допущенные участники снижают цены
+	AuctionPhaseRunning AuctionPhase = "Running"
+	// AuctionPhaseFinished Торги завершены: тендер закрыт или отменен
+	AuctionPhaseFinished AuctionPhase = "Finished"
+)
+
+// AuctionSettings Параметры редукциона (аукциона на понижение цены).
+// Торги идут с StartsAt до окончания приема предложений тендера
+type AuctionSettings struct {
+	// StartsAt Начало торгов в формате RFC3339. К торгам допускаются предложения, опубликованные до этого момента.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Step Минимальный шаг снижения цены, в валюте бюджета тендера
+	Step Money `json:"step"`
+
+	// ExtensionSeconds Продление торгов: цена, поданная меньше чем за ExtensionSeconds до окончания,
+	// сдвигает окончание на ExtensionSeconds от момента подачи. 0 - без продления
+	ExtensionSeconds int32 `json:"extensionSeconds"`
+}
+
+// AuctionState Текущее состояние торгов глазами пользователя
+type AuctionState struct {
+	// TenderId Тендер, по которому идут торги
+	TenderId TenderId `json:"tenderId"`
+
+	// Phase Этап торгов
+	Phase AuctionPhase `json:"phase"`
+
+	// StartsAt Начало торгов
+	StartsAt time.Time `json:"startsAt"`
+
+	// EndsAt Окончание торгов с учетом продлений
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// Step Минимальный шаг снижения цены
+	Step Money `json:"step"`
+
+	// BestPrice Лучшая (минимальная) цена среди участников. Пусто, пока цен нет
+	BestPrice *Money `json:"bestPrice,omitempty"`
+
+	// Participants Число допущенных участников
+	Participants int `json:"participants"`
+
+	// BidId Предложение пользователя. Пусто для владельца тендера
+	BidId *BidId `json:"bidId,omitempty"`
+
+	// Price Текущая цена предложения пользователя
+	Price *Money `json:"price,omitempty"`
+
+	// Rank Место предложения пользователя по цене, начиная с 1
+	Rank *int `json:"rank,omitempty"`
+}
@@ -12,6 +12,10 @@ type Bid struct {
 	// Передается в формате RFC3339.
 	CreatedAt string `json:"createdAt"`
 
+	// Decisions Текущее состояние голосования ответственных по предложению.
+	// Заполняется только в ответе на решение, в остальное время голосование отдает GET /api/bids/{bidId}/decisions
+	Decisions *BidDecisionTally `json:"decisions,omitempty"`
+
 	// Description Описание предложения
 	Description BidDescription `json:"description"`
 
@@ -29,6 +33,22 @@ type Bid struct {
 
 	// Version Номер версии посел правок
 	Version BidVersion `json:"version"`
+
+	// Price Цена предложения. Пусто для предложений, созданных до появления цены.
+	Price *Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки или выполнения работ в календарных днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
+
+	// Sealed Содержимое предложения зашифровано до закрытия тендера.
+	// Владельцу тендера такое предложение отдается без названия, описания и цены.
+	Sealed bool `json:"sealed,omitempty"`
+
+	// SealedPayload Зашифрованные название, описание, цена и срок поставки
+	SealedPayload []byte `json:"-"`
+
+	// Match Совпадение с поисковым запросом
+	Match *SearchMatch `json:"match,omitempty"`
 }
 
 // BidAuthorId Уникальный идентификатор автора предложения, присвоенный сервером.
@@ -0,0 +1,64 @@
+package models
+
+// BidFeedbackEntry Отзыв владельца тендера на предложение или ответ в его ветке
+type BidFeedbackEntry struct {
+	// Id Уникальный идентификатор отзыва, присвоенный сервером.
+	Id BidReviewId `json:"id"`
+
+	// BidId Предложение, к которому относится отзыв
+	BidId BidId `json:"bidId"`
+
+	// ParentId Отзыв, в ветке которого оставлен ответ. Пусто у самого отзыва
+	ParentId *BidReviewId `json:"parentId,omitempty"`
+
+	// AuthorUsername Автор отзыва или ответа
+	AuthorUsername *Username `json:"authorUsername,omitempty"`
+
+	// Description Текст. Пусто у удаленных записей
+	Description BidReviewDescription `json:"description"`
+
+	// Rating Оценка предложения от 1 до 5. Только у отзыва
+	Rating *int32 `json:"rating,omitempty"`
+
+	// Version Номер версии. Увеличивается при каждой правке и удалении
+	Version int32 `json:"version"`
+
+	// Deleted Запись удалена автором. История правок сохраняется
+	Deleted bool `json:"deleted"`
+
+	// CreatedAt Серверная дата и время создания.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// UpdatedAt Серверная дата и время последней правки или удаления.
+	// Передается в формате RFC3339.
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+
+	// Replies Ответы в ветке отзыва в порядке их появления
+	Replies []*BidFeedbackEntry `json:"replies,omitempty"`
+
+	// AuthorId Id автора для проверки прав
+	AuthorId *int `json:"-"`
+}
+
+// BidFeedbackVersion Сохраненная версия отзыва или ответа
+type BidFeedbackVersion struct {
+	// Version Номер версии
+	Version int32 `json:"version"`
+
+	// Description Текст
+	Description BidReviewDescription `json:"description"`
+
+	// Rating Оценка
+	Rating *int32 `json:"rating,omitempty"`
+
+	// Deleted Версия фиксирует удаление
+	Deleted bool `json:"deleted"`
+
+	// EditorUsername Пользователь, создавший версию
+	EditorUsername *Username `json:"editorUsername,omitempty"`
+
+	// CreatedAt Серверная дата и время создания версии.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
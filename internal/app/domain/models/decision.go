@@ -0,0 +1,29 @@
+package models
+
+// BidDecisionTally Текущее состояние голосования ответственных организации по предложению
+type BidDecisionTally struct {
+	// Quorum Количество одобрений, необходимое для принятия предложения
+	Quorum int32 `json:"quorum"`
+
+	// Approved Количество решений "Approved"
+	Approved int32 `json:"approved"`
+
+	// Rejected Количество решений "Rejected"
+	Rejected int32 `json:"rejected"`
+
+	// Votes Решения каждого ответственного
+	Votes []BidMemberDecision `json:"votes"`
+}
+
+// BidMemberDecision Решение одного ответственного по предложению
+type BidMemberDecision struct {
+	// Username Уникальный slug ответственного
+	Username Username `json:"username"`
+
+	// Decision Решение по предложению
+	Decision BidDecision `json:"decision"`
+
+	// CreatedAt Серверная дата и время принятия решения.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
@@ -0,0 +1,111 @@
+package models
+
+// CriterionKind Способ оценки предложения по критерию
+type CriterionKind string
+
+const (
+	// CriterionKindManual Оценку ставят ответственные за тендер
+	CriterionKindManual CriterionKind = "Manual"
+	// CriterionKindPrice Оценка считается по цене: самое дешевое предложение получает максимум
+	CriterionKindPrice CriterionKind = "Price"
+	// CriterionKindDeliveryTime Оценка считается по сроку поставки: самый короткий срок получает максимум
+	CriterionKindDeliveryTime CriterionKind = "DeliveryTime"
+)
+
+// EvaluationCriterion Критерий оценки предложений тендера
+type EvaluationCriterion struct {
+	// Id Уникальный идентификатор критерия, присвоенный сервером.
+	Id CriterionId `json:"id"`
+
+	// TenderId Тендер, к которому относится критерий
+	TenderId TenderId `json:"tenderId"`
+
+	// Name Название критерия, уникальное в пределах тендера
+	Name string `json:"name"`
+
+	// Description Что и как оценивается
+	Description *string `json:"description,omitempty"`
+
+	// Kind Способ оценки
+	Kind CriterionKind `json:"kind"`
+
+	// Weight Вес критерия в итоговой оценке
+	Weight int32 `json:"weight"`
+
+	// MaxScore Максимальная оценка по критерию. Для расчетных критериев не используется
+	MaxScore int32 `json:"maxScore"`
+}
+
+// CriterionId Уникальный идентификатор критерия, присвоенный сервером.
+type CriterionId = string
+
+// BidScore Оценка предложения по одному критерию от одного ответственного
+type BidScore struct {
+	// BidId Оцененное предложение
+	BidId BidId `json:"bidId"`
+
+	// CriterionId Критерий оценки
+	CriterionId CriterionId `json:"criterionId"`
+
+	// Evaluator Ответственный, поставивший оценку
+	Evaluator Username `json:"evaluator"`
+
+	// Score Оценка от 0 до MaxScore критерия
+	Score int32 `json:"score"`
+
+	// Comment Обоснование оценки
+	Comment *string `json:"comment,omitempty"`
+
+	// UpdatedAt Серверная дата и время последнего изменения оценки.
+	// Передается в формате RFC3339.
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// TenderEvaluation Итоги оценки опубликованных предложений тендера по критериям
+type TenderEvaluation struct {
+	// TenderId Оцениваемый тендер
+	TenderId TenderId `json:"tenderId"`
+
+	// Criteria Критерии, по которым считалась оценка
+	Criteria []*EvaluationCriterion `json:"criteria"`
+
+	// Currency Валюта, в которой сравниваются цены: валюта бюджета тендера,
+	// без бюджета - самая частая валюта предложений. Пусто, если цен нет
+	Currency string `json:"currency,omitempty"`
+
+	// Items Предложения по убыванию итоговой оценки
+	Items []BidEvaluation `json:"items"`
+}
+
+// BidEvaluation Итоговая оценка предложения
+type BidEvaluation struct {
+	// Rank Место по итоговой оценке, начиная с 1. Равные оценки делят место
+	Rank int `json:"rank"`
+
+	// Bid Предложение
+	Bid *Bid `json:"bid"`
+
+	// Total Взвешенная оценка от 0 до 100
+	Total float64 `json:"total"`
+
+	// Complete Оценка полная: по каждому критерию есть оценка или данные для расчета
+	Complete bool `json:"complete"`
+
+	// Criteria Оценки по отдельным критериям в порядке их объявления
+	Criteria []CriterionEvaluation `json:"criteria"`
+}
+
+// CriterionEvaluation Оценка предложения по одному критерию, приведенная к диапазону от 0 до 1
+type CriterionEvaluation struct {
+	// CriterionId Критерий оценки
+	CriterionId CriterionId `json:"criterionId"`
+
+	// Normalized Нормированная оценка от 0 до 1
+	Normalized float64 `json:"normalized"`
+
+	// AverageScore Средняя оценка ответственных. Только для критериев Manual
+	AverageScore *float64 `json:"averageScore,omitempty"`
+
+	// Evaluations Число ответственных, оценивших предложение. Только для критериев Manual
+	Evaluations int `json:"evaluations,omitempty"`
+}
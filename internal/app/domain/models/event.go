@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TenderClosedEvent Событие автоматического закрытия тендера по окончании приема предложений
+type TenderClosedEvent struct {
+	// TenderId Закрытый тендер
+	TenderId TenderId `json:"tenderId"`
+
+	// OrganizationId Организация, которой принадлежит тендер
+	OrganizationId OrganizationId `json:"organizationId"`
+
+	// BidsCloseAt Срок окончания приема предложений, по которому тендер был закрыт
+	BidsCloseAt time.Time `json:"bidsCloseAt"`
+
+	// ClosedAt Момент фактического закрытия
+	ClosedAt time.Time `json:"closedAt"`
+}
+
+// DomainEventType Тип доменного события
+type DomainEventType string
+
+const (
+	EventTenderCreated       DomainEventType = "TenderCreated"
+	EventTenderPublished     DomainEventType = "TenderPublished"
+	EventTenderClosed        DomainEventType = "TenderClosed"
+	EventTenderCanceled      DomainEventType = "TenderCanceled"
+	EventTenderStatusChanged DomainEventType = "TenderStatusChanged"
+	EventTenderUpdated       DomainEventType = "TenderUpdated"
+	EventBidCreated          DomainEventType = "BidCreated"
+	EventBidSubmitted        DomainEventType = "BidSubmitted"
+	EventBidCanceled         DomainEventType = "BidCanceled"
+	EventBidUpdated          DomainEventType = "BidUpdated"
+	EventBidDecided          DomainEventType = "BidDecided"
+	EventFeedbackAdded       DomainEventType = "FeedbackAdded"
+	EventFeedbackEdited      DomainEventType = "FeedbackEdited"
+	EventFeedbackDeleted     DomainEventType = "FeedbackDeleted"
+)
+
+// EventAggregate Тип объекта, к которому относится событие
+type EventAggregate string
+
+const (
+	EventAggregateTender   EventAggregate = "Tender"
+	EventAggregateBid      EventAggregate = "Bid"
+	EventAggregateFeedback EventAggregate = "Feedback"
+)
+
+// DomainEvent Событие из outbox. Доставляется как минимум один раз,
+// получатели отбрасывают повторы по EventId
+type DomainEvent struct {
+	// Seq Порядковый номер события. Внутри одного агрегата события доставляются по возрастанию
+	Seq int64 `json:"seq"`
+
+	// EventId Уникальный идентификатор события
+	EventId string `json:"eventId"`
+
+	// Type Тип события
+	Type DomainEventType `json:"type"`
+
+	// AggregateType, AggregateId Объект, с которым произошло событие
+	AggregateType EventAggregate `json:"aggregateType"`
+	AggregateId   string         `json:"aggregateId"`
+
+	// Payload Состояние объекта на момент события: TenderEventPayload, BidEventPayload или FeedbackEventPayload
+	Payload json.RawMessage `json:"payload"`
+
+	// OccurredAt Серверная дата и время события.
+	// Передается в формате RFC3339.
+	OccurredAt string `json:"occurredAt"`
+
+	// Attempts Число предыдущих неудачных попыток доставки
+	Attempts int32 `json:"-"`
+}
+
+// TenderEventPayload Содержимое событий тендера
+type TenderEventPayload struct {
+	TenderId       TenderId       `json:"tenderId"`
+	OrganizationId OrganizationId `json:"organizationId"`
+	Status         TenderStatus   `json:"status"`
+	Access         TenderAccess   `json:"access"`
+
+	// PreviousStatus Статус до перехода. Только у событий смены статуса
+	PreviousStatus *TenderStatus `json:"previousStatus,omitempty"`
+}
+
+// BidEventPayload Содержимое событий предложения. Содержимое запечатанных предложений в события не попадает
+type BidEventPayload struct {
+	BidId      BidId         `json:"bidId"`
+	TenderId   TenderId      `json:"tenderId"`
+	AuthorType BidAuthorType `json:"authorType"`
+	AuthorId   BidAuthorId   `json:"authorId"`
+	Status     BidStatus     `json:"status"`
+
+	// PreviousStatus Статус до перехода. Только у событий смены статуса
+	PreviousStatus *BidStatus `json:"previousStatus,omitempty"`
+}
+
+// FeedbackEventPayload Содержимое событий отзыва
+type FeedbackEventPayload struct {
+	FeedbackId BidReviewId  `json:"feedbackId"`
+	BidId      BidId        `json:"bidId"`
+	TenderId   TenderId     `json:"tenderId"`
+	ParentId   *BidReviewId `json:"parentId,omitempty"`
+	Rating     *int32       `json:"rating,omitempty"`
+	Deleted    bool         `json:"deleted"`
+}
@@ -0,0 +1,59 @@
+package models
+
+// TenderAccess Кто может видеть опубликованный тендер и подавать на него предложения
+type TenderAccess string
+
+const (
+	// TenderAccessOpen Любой сотрудник
+	TenderAccessOpen TenderAccess = "Open"
+	// TenderAccessInviteOnly Только приглашенные организации и сотрудники
+	TenderAccessInviteOnly TenderAccess = "InviteOnly"
+)
+
+// InvitationStatus Ответ приглашенного на приглашение в тендер
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "Pending"
+	InvitationStatusAccepted InvitationStatus = "Accepted"
+	// InvitationStatusDeclined Отклонивший приглашение теряет доступ к тендеру
+	InvitationStatusDeclined InvitationStatus = "Declined"
+)
+
+// TenderInvitation Приглашение организации или сотрудника в закрытый тендер
+type TenderInvitation struct {
+	// Id Уникальный идентификатор приглашения, присвоенный сервером.
+	Id InvitationId `json:"id"`
+
+	// TenderId Тендер, в который приглашают
+	TenderId TenderId `json:"tenderId"`
+
+	// TenderName Название тендера
+	TenderName TenderName `json:"tenderName"`
+
+	// OrganizationId Приглашенная организация. Ответить на приглашение может любой ее ответственный
+	OrganizationId *OrganizationId `json:"organizationId,omitempty"`
+
+	// EmployeeUsername Приглашенный сотрудник
+	EmployeeUsername *Username `json:"employeeUsername,omitempty"`
+
+	// Status Ответ на приглашение
+	Status InvitationStatus `json:"status"`
+
+	// InvitedBy Ответственный, отправивший приглашение
+	InvitedBy *Username `json:"invitedBy,omitempty"`
+
+	// RespondedBy Пользователь, ответивший на приглашение
+	RespondedBy *Username `json:"respondedBy,omitempty"`
+
+	// RespondedAt Серверная дата и время ответа на приглашение.
+	// Передается в формате RFC3339.
+	RespondedAt *string `json:"respondedAt,omitempty"`
+
+	// CreatedAt Серверная дата и время отправки приглашения.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// InvitationId Уникальный идентификатор приглашения, присвоенный сервером.
+type InvitationId = string
@@ -0,0 +1,10 @@
+package models
+
+// Money Денежная сумма в конкретной валюте
+type Money struct {
+	// Amount Сумма десятичной строкой с точностью до копеек, например "1500.00"
+	Amount string `json:"amount"`
+
+	// Currency Трехбуквенный код валюты ISO 4217, например "RUB"
+	Currency string `json:"currency"`
+}
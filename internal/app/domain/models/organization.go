@@ -0,0 +1,51 @@
+package models
+
+// Organization Информация об организации
+type Organization struct {
+	// Id Уникальный идентификатор организации, присвоенный сервером.
+	Id OrganizationId `json:"id"`
+
+	// Name Название организации
+	Name OrganizationName `json:"name"`
+
+	// Description Описание организации
+	Description OrganizationDescription `json:"description"`
+
+	// Type Организационно-правовая форма
+	Type OrganizationType `json:"type"`
+
+	// CreatedAt Серверная дата и время создания организации.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// OrganizationName Название организации
+type OrganizationName = string
+
+// OrganizationDescription Описание организации
+type OrganizationDescription = string
+
+// OrganizationType Организационно-правовая форма: IE, LLC или JSC
+type OrganizationType string
+
+// Employee Информация о сотруднике
+type Employee struct {
+	// Id Уникальный идентификатор сотрудника, присвоенный сервером.
+	Id EmployeeId `json:"id"`
+
+	// Username Уникальный slug пользователя.
+	Username Username `json:"username"`
+
+	// FirstName Имя
+	FirstName string `json:"firstName"`
+
+	// LastName Фамилия
+	LastName string `json:"lastName"`
+
+	// CreatedAt Серверная дата и время регистрации сотрудника.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// EmployeeId Уникальный идентификатор сотрудника, присвоенный сервером.
+type EmployeeId = string
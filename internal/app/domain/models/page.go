@@ -0,0 +1,13 @@
+package models
+
+// Page Страница списка
+type Page[T any] struct {
+	// Items Объекты страницы
+	Items []T `json:"items"`
+
+	// NextCursor Токен для запроса следующей страницы. Пустой, если страница последняя.
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// TotalCount Общее число объектов списка. Возвращается только по запросу withTotal=true.
+	TotalCount *int64 `json:"totalCount,omitempty"`
+}
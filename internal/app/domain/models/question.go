@@ -0,0 +1,74 @@
+package models
+
+// AnswerVisibility Кому виден ответ на вопрос по тендеру
+type AnswerVisibility string
+
+const (
+	// AnswerVisibilityPublic Вопрос и ответ видят все, кому виден тендер
+	AnswerVisibilityPublic AnswerVisibility = "Public"
+	// AnswerVisibilityPrivate Ответ видит только автор вопроса
+	AnswerVisibilityPrivate AnswerVisibility = "Private"
+)
+
+// TenderQuestion Вопрос по тендеру и ответ организации-владельца
+type TenderQuestion struct {
+	// Id Уникальный идентификатор вопроса, присвоенный сервером.
+	Id QuestionId `json:"id"`
+
+	// TenderId Тендер, к которому относится вопрос
+	TenderId TenderId `json:"tenderId"`
+
+	// Question Текст вопроса
+	Question string `json:"question"`
+
+	// AskerUsername Автор вопроса
+	AskerUsername Username `json:"askerUsername"`
+
+	// AskerId Id автора вопроса для проверки видимости
+	AskerId int `json:"-"`
+
+	// Answer Текст ответа. Пусто, пока на вопрос не ответили
+	Answer *string `json:"answer,omitempty"`
+
+	// AnswerVisibility Кому виден ответ
+	AnswerVisibility *AnswerVisibility `json:"answerVisibility,omitempty"`
+
+	// AnsweredBy Ответственный, давший или последним изменивший ответ
+	AnsweredBy *Username `json:"answeredBy,omitempty"`
+
+	// AnsweredAt Серверная дата и время последнего изменения ответа.
+	// Передается в формате RFC3339.
+	AnsweredAt *string `json:"answeredAt,omitempty"`
+
+	// Version Номер версии. Увеличивается при каждом изменении вопроса или ответа
+	Version int32 `json:"version"`
+
+	// CreatedAt Серверная дата и время в момент, когда был задан вопрос.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// QuestionId Уникальный идентификатор вопроса, присвоенный сервером.
+type QuestionId = string
+
+// TenderQuestionVersion Сохраненная версия вопроса и ответа
+type TenderQuestionVersion struct {
+	// Version Номер версии
+	Version int32 `json:"version"`
+
+	// Question Текст вопроса
+	Question string `json:"question"`
+
+	// Answer Текст ответа
+	Answer *string `json:"answer,omitempty"`
+
+	// AnswerVisibility Кому был виден ответ
+	AnswerVisibility *AnswerVisibility `json:"answerVisibility,omitempty"`
+
+	// EditorUsername Пользователь, создавший версию
+	EditorUsername *Username `json:"editorUsername,omitempty"`
+
+	// CreatedAt Серверная дата и время создания версии.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
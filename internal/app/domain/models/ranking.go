@@ -0,0 +1,42 @@
+package models
+
+// PriceOutlier Отклонение цены предложения от остальных предложений тендера
+type PriceOutlier string
+
+const (
+	// PriceOutlierLow Цена подозрительно низкая (ниже Q1 - 1.5*IQR)
+	PriceOutlierLow PriceOutlier = "low"
+	// PriceOutlierHigh Цена подозрительно высокая (выше Q3 + 1.5*IQR)
+	PriceOutlierHigh PriceOutlier = "high"
+)
+
+// BidRanking Опубликованные предложения тендера, упорядоченные по цене
+type BidRanking struct {
+	// TenderId Тендер, по которому построен рейтинг
+	TenderId TenderId `json:"tenderId"`
+
+	// Budget Бюджет тендера на момент построения рейтинга
+	Budget *Money `json:"budget,omitempty"`
+
+	// Items Предложения с ценой: по валюте, затем от дешевых к дорогим
+	Items []BidRankingItem `json:"items"`
+
+	// Unpriced Число опубликованных предложений без цены, не попавших в рейтинг
+	Unpriced int `json:"unpriced"`
+}
+
+// BidRankingItem Место предложения в рейтинге
+type BidRankingItem struct {
+	// Rank Место среди предложений в той же валюте, начиная с 1
+	Rank int `json:"rank"`
+
+	// Bid Предложение
+	Bid *Bid `json:"bid"`
+
+	// Outlier Выброс по цене среди предложений в той же валюте. Пусто, если цена обычная
+	// или предложений слишком мало для оценки.
+	Outlier *PriceOutlier `json:"outlier,omitempty"`
+
+	// ExceedsBudget Цена выше бюджета тендера (бюджет могли снизить после подачи предложения)
+	ExceedsBudget bool `json:"exceedsBudget,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// BidderReputation Репутация автора предложений по всем тендерам
+type BidderReputation struct {
+	// SubjectType User - сотрудник, подающий предложения от себя, Organization - организация
+	SubjectType BidAuthorType `json:"subjectType"`
+
+	// SubjectId Id сотрудника или организации
+	SubjectId string `json:"subjectId"`
+
+	// Wins Одобренные предложения
+	Wins int32 `json:"wins"`
+
+	// Losses Отклоненные предложения и предложения, проигравшие другому одобренному
+	Losses int32 `json:"losses"`
+
+	// Canceled Отозванные автором предложения
+	Canceled int32 `json:"canceled"`
+
+	// WinRate Доля побед среди решенных предложений. Пусто, пока решений нет
+	WinRate *float64 `json:"winRate,omitempty"`
+
+	// CancellationRate Доля отозванных среди предложений с итогом. Пусто, пока итогов нет
+	CancellationRate *float64 `json:"cancellationRate,omitempty"`
+
+	// RatingCount Число оценок в отзывах владельцев тендеров
+	RatingCount int32 `json:"ratingCount"`
+
+	// AverageRating Средняя оценка от 1 до 5. Пусто, пока оценок нет
+	AverageRating *float64 `json:"averageRating,omitempty"`
+
+	// AverageDecisionSeconds Среднее время от подачи предложения до решения по нему, в секундах
+	AverageDecisionSeconds *int64 `json:"averageDecisionSeconds,omitempty"`
+
+	// UpdatedAt Серверная дата и время последнего пересчета.
+	// Передается в формате RFC3339.
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+
+	// RatingSum, DecisionCount, DecisionSeconds Накопленные суммы, из которых считаются средние
+	RatingSum       int64 `json:"-"`
+	DecisionCount   int64 `json:"-"`
+	DecisionSeconds int64 `json:"-"`
+}
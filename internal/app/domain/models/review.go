@@ -11,6 +11,9 @@ type BidReview struct {
 
 	// Id Уникальный идентификатор отзыва, присвоенный сервером.
 	Id BidReviewId `json:"id"`
+
+	// Rating Оценка предложения от 1 до 5, если владелец тендера ее поставил
+	Rating *int32 `json:"rating,omitempty"`
 }
 
 // BidReviewDescription Описание предложения
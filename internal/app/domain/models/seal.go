@@ -0,0 +1,32 @@
+package models
+
+// SealedBidContent Содержимое запечатанного предложения, которое хранится зашифрованным до закрытия тендера
+type SealedBidContent struct {
+	// Name Полное название предложения
+	Name BidName `json:"name"`
+
+	// Description Описание предложения
+	Description BidDescription `json:"description"`
+
+	// Price Цена предложения
+	Price *Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки в днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
+}
+
+// TenderAuditEntry Запись журнала действий с тендером
+type TenderAuditEntry struct {
+	// Action Действие, например "BidsRevealed"
+	Action string `json:"action"`
+
+	// Actor Пользователь, выполнивший действие. Пусто, если действие выполнил сам сервис.
+	Actor *Username `json:"actor,omitempty"`
+
+	// Details Подробности действия
+	Details map[string]any `json:"details"`
+
+	// CreatedAt Серверная дата и время действия.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
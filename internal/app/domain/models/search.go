@@ -0,0 +1,13 @@
+package models
+
+// SearchMatch Совпадение полнотекстового поиска. Заполняется только для запросов с параметром q
+type SearchMatch struct {
+	// Rank Релевантность совпадения, результаты отсортированы по ней по убыванию
+	Rank float32 `json:"rank"`
+
+	// Name Название с совпадениями, выделенными тегами <b>. Текст экранирован для HTML
+	Name string `json:"name"`
+
+	// Description Фрагменты описания с совпадениями, выделенными тегами <b>. Текст экранирован для HTML
+	Description string `json:"description"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // OrganizationId Уникальный идентификатор организации, присвоенный сервером.
 type OrganizationId = string
 
@@ -29,6 +31,30 @@ type Tender struct {
 
 	// Version Номер версии посел правок
 	Version TenderVersion `json:"version"`
+
+	// BidsOpenAt Момент, с которого тендер принимает предложения. Пусто - без ограничения.
+	BidsOpenAt *time.Time `json:"bidsOpenAt,omitempty"`
+
+	// BidsCloseAt Момент окончания приема предложений. После него тендер закрывается автоматически.
+	BidsCloseAt *time.Time `json:"bidsCloseAt,omitempty"`
+
+	// Budget Максимальная цена предложения. Пусто - без ограничения.
+	Budget *Money `json:"budget,omitempty"`
+
+	// Sealed Предложения запечатаны до закрытия тендера
+	Sealed bool `json:"sealed"`
+
+	// RevealedAt Серверная дата и время раскрытия запечатанных предложений
+	RevealedAt *time.Time `json:"revealedAt,omitempty"`
+
+	// Access Доступ к тендеру: всем сотрудникам или только приглашенным
+	Access TenderAccess `json:"access"`
+
+	// Auction Параметры редукциона. Пусто для обычного тендера
+	Auction *AuctionSettings `json:"auction,omitempty"`
+
+	// Match Совпадение с поисковым запросом
+	Match *SearchMatch `json:"match,omitempty"`
 }
 
 // TenderDescription Описание тендера
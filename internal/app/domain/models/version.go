@@ -0,0 +1,116 @@
+package models
+
+import "time"
+
+// TenderVersionInfo Сохраненная версия тендера
+type TenderVersionInfo struct {
+	// Version Номер версии
+	Version TenderVersion `json:"version"`
+
+	// Name Полное название тендера
+	Name TenderName `json:"name"`
+
+	// Description Описание тендера
+	Description TenderDescription `json:"description"`
+
+	// ServiceType Вид услуги, к которой относиться тендер
+	ServiceType TenderServiceType `json:"serviceType"`
+
+	// Status Статус тендера на момент создания версии
+	Status TenderStatus `json:"status"`
+
+	// BidsOpenAt Начало приема предложений
+	BidsOpenAt *time.Time `json:"bidsOpenAt,omitempty"`
+
+	// BidsCloseAt Окончание приема предложений
+	BidsCloseAt *time.Time `json:"bidsCloseAt,omitempty"`
+
+	// Budget Максимальная цена предложения
+	Budget *Money `json:"budget,omitempty"`
+
+	// Attachments Вложения тендера на момент создания версии
+	Attachments []AttachmentId `json:"attachments"`
+
+	// AuthorUsername Пользователь, создавший версию. Пусто для версий, созданных до появления авторства.
+	AuthorUsername *Username `json:"authorUsername,omitempty"`
+
+	// CreatedAt Серверная дата и время создания версии.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// IsCurrent Является ли версия текущей
+	IsCurrent bool `json:"isCurrent"`
+
+	// RolledBackFrom Номер версии, из которой был сделан откат
+	RolledBackFrom *TenderVersion `json:"rolledBackFrom,omitempty"`
+}
+
+// BidVersionInfo Сохраненная версия предложения
+type BidVersionInfo struct {
+	// Version Номер версии
+	Version BidVersion `json:"version"`
+
+	// Name Полное название предложения
+	Name BidName `json:"name"`
+
+	// Description Описание предложения
+	Description BidDescription `json:"description"`
+
+	// Status Статус предложения на момент создания версии
+	Status BidStatus `json:"status"`
+
+	// Decision Решение, которое привело к созданию версии
+	Decision *BidDecision `json:"decision,omitempty"`
+
+	// Price Цена предложения
+	Price *Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки в календарных днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
+
+	// Sealed Содержимое версии зашифровано до закрытия тендера
+	Sealed bool `json:"sealed,omitempty"`
+
+	// SealedPayload Зашифрованное содержимое версии
+	SealedPayload []byte `json:"-"`
+
+	// Attachments Вложения предложения на момент создания версии
+	Attachments []AttachmentId `json:"attachments"`
+
+	// AuthorUsername Пользователь, создавший версию. Пусто для версий, созданных до появления авторства.
+	AuthorUsername *Username `json:"authorUsername,omitempty"`
+
+	// CreatedAt Серверная дата и время создания версии.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// IsCurrent Является ли версия текущей
+	IsCurrent bool `json:"isCurrent"`
+
+	// RolledBackFrom Номер версии, из которой был сделан откат
+	RolledBackFrom *BidVersion `json:"rolledBackFrom,omitempty"`
+}
+
+// VersionDiff Различия между двумя версиями
+type VersionDiff struct {
+	// From Номер исходной версии
+	From int32 `json:"from"`
+
+	// To Номер версии, с которой сравнивается исходная
+	To int32 `json:"to"`
+
+	// Changes Измененные поля
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange Изменение одного поля между версиями
+type FieldChange struct {
+	// Field Название поля в формате API
+	Field string `json:"field"`
+
+	// Old Значение в исходной версии
+	Old any `json:"old"`
+
+	// New Значение в сравниваемой версии
+	New any `json:"new"`
+}
@@ -0,0 +1,136 @@
+package models
+
+import "encoding/json"
+
+// WebhookId Уникальный идентификатор подписки, присвоенный сервером.
+type WebhookId = string
+
+// WebhookDeliveryId Уникальный идентификатор доставки, присвоенный сервером.
+type WebhookDeliveryId = string
+
+// WebhookDeliveryStatus Состояние доставки события подписке
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending Ожидает очередной попытки
+	WebhookDeliveryPending WebhookDeliveryStatus = "Pending"
+	// WebhookDeliveryDelivered Получатель ответил 2xx
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "Delivered"
+	// WebhookDeliveryDeadLetter Попытки исчерпаны, повторить можно только вручную
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "DeadLetter"
+)
+
+// Webhook Подписка организации на доменные события
+type Webhook struct {
+	// Id Уникальный идентификатор подписки, присвоенный сервером.
+	Id WebhookId `json:"id"`
+
+	// OrganizationId Организация, которой принадлежит подписка
+	OrganizationId OrganizationId `json:"organizationId"`
+
+	// Url Адрес, на который отправляются события
+	Url string `json:"url"`
+
+	// EventTypes Типы событий. Пустой список - все события
+	EventTypes []DomainEventType `json:"eventTypes"`
+
+	// Secret Ключ подписи HMAC-SHA256. Возвращается только при создании и смене ключа
+	Secret *string `json:"secret,omitempty"`
+
+	// Active Неактивной подписке события не доставляются
+	Active bool `json:"active"`
+
+	// CreatedBy Ответственный, создавший подписку
+	CreatedBy *Username `json:"createdBy,omitempty"`
+
+	// CreatedAt Серверная дата и время создания подписки.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// UpdatedAt Серверная дата и время последнего изменения.
+	// Передается в формате RFC3339.
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// WebhookDelivery Доставка одного события подписке
+type WebhookDelivery struct {
+	// Id Уникальный идентификатор доставки. Передается получателю в заголовке X-Webhook-Delivery
+	Id WebhookDeliveryId `json:"id"`
+
+	// WebhookId Подписка
+	WebhookId WebhookId `json:"webhookId"`
+
+	// EventId Доставляемое событие. Получатель отбрасывает повторы по нему
+	EventId string `json:"eventId"`
+
+	// EventType Тип события
+	EventType DomainEventType `json:"eventType"`
+
+	// Status Состояние доставки
+	Status WebhookDeliveryStatus `json:"status"`
+
+	// Attempts Число попыток с момента создания или ручного повтора
+	Attempts int32 `json:"attempts"`
+
+	// NextAttemptAt Когда будет следующая попытка. Только для Pending
+	// Передается в формате RFC3339.
+	NextAttemptAt *string `json:"nextAttemptAt,omitempty"`
+
+	// LastStatusCode HTTP-код последнего ответа получателя
+	LastStatusCode *int32 `json:"lastStatusCode,omitempty"`
+
+	// LastError Ошибка последней попытки
+	LastError *string `json:"lastError,omitempty"`
+
+	// DeliveredAt Серверная дата и время успешной доставки.
+	// Передается в формате RFC3339.
+	DeliveredAt *string `json:"deliveredAt,omitempty"`
+
+	// CreatedAt Серверная дата и время создания доставки.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+
+	// Payload Тело запроса. Только при получении одной доставки
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	// AttemptLog Журнал попыток. Только при получении одной доставки
+	AttemptLog []*WebhookDeliveryAttempt `json:"attemptLog,omitempty"`
+}
+
+// WebhookDeliveryAttempt Одна попытка доставки
+type WebhookDeliveryAttempt struct {
+	// Attempt Номер попытки
+	Attempt int32 `json:"attempt"`
+
+	// StatusCode HTTP-код ответа. Пустой, если ответа не было
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Error Ошибка попытки
+	Error *string `json:"error,omitempty"`
+
+	// DurationMs Длительность запроса в миллисекундах
+	DurationMs int32 `json:"durationMs"`
+
+	// CreatedAt Серверная дата и время попытки.
+	// Передается в формате RFC3339.
+	CreatedAt string `json:"createdAt"`
+}
+
+// WebhookRequest Запрос к получателю, готовый к отправке
+type WebhookRequest struct {
+	DeliveryId WebhookDeliveryId
+	EventId    string
+	EventType  DomainEventType
+	Url        string
+	Secret     string
+	Payload    []byte
+	// Attempt Номер текущей попытки
+	Attempt int32
+}
+
+// WebhookAttemptResult Результат попытки доставки
+type WebhookAttemptResult struct {
+	StatusCode *int32
+	Error      *string
+	DurationMs int32
+}
@@ -0,0 +1,71 @@
+package repos
+
+import (
+	"context"
+	"io"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// AttachmentId Уникальный идентификатор вложения, присвоенный сервером.
+type AttachmentId = string
+
+// AttachmentService предоставляет методы для работы с файлами тендеров и предложений.
+type AttachmentService interface {
+	// Загрузка файла к тендеру
+	UploadTenderAttachment(ctx context.Context, tenderId TenderId, params UploadAttachmentParams) (models.Attachment, error)
+	// Получение списка файлов тендера
+	GetTenderAttachments(ctx context.Context, tenderId TenderId, params GetAttachmentsParams) ([]*models.Attachment, error)
+	// Скачивание файла тендера. Вызывающий обязан закрыть содержимое
+	DownloadTenderAttachment(ctx context.Context, tenderId TenderId, attachmentId AttachmentId, params GetAttachmentsParams) (models.Attachment, io.ReadCloser, error)
+	// Удаление файла тендера
+	DeleteTenderAttachment(ctx context.Context, tenderId TenderId, attachmentId AttachmentId, params DeleteAttachmentParams) error
+
+	// Загрузка файла к предложению
+	UploadBidAttachment(ctx context.Context, bidId BidId, params UploadAttachmentParams) (models.Attachment, error)
+	// Получение списка файлов предложения
+	GetBidAttachments(ctx context.Context, bidId BidId, params GetAttachmentsParams) ([]*models.Attachment, error)
+	// Скачивание файла предложения. Вызывающий обязан закрыть содержимое
+	DownloadBidAttachment(ctx context.Context, bidId BidId, attachmentId AttachmentId, params GetAttachmentsParams) (models.Attachment, io.ReadCloser, error)
+	// Удаление файла предложения
+	DeleteBidAttachment(ctx context.Context, bidId BidId, attachmentId AttachmentId, params DeleteAttachmentParams) error
+}
+
+// UploadAttachmentParams defines parameters for UploadTenderAttachment and UploadBidAttachment.
+type UploadAttachmentParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// FileName Имя загружаемого файла
+	FileName string `json:"-"`
+
+	// ContentType MIME-тип, заявленный клиентом
+	ContentType string `json:"-"`
+
+	// Size Размер, заявленный клиентом
+	Size int64 `json:"-"`
+
+	// Content Содержимое файла
+	Content io.Reader `json:"-"`
+}
+
+// GetAttachmentsParams defines parameters for GetTenderAttachments, GetBidAttachments and downloads.
+type GetAttachmentsParams struct {
+	// Username Пользователь, от имени которого запрашиваются файлы.
+	// Без него доступны только файлы публичных тендеров.
+	Username *Username `form:"username,omitempty" json:"username,omitempty"`
+}
+
+// DeleteAttachmentParams defines parameters for DeleteTenderAttachment and DeleteBidAttachment.
+type DeleteAttachmentParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// CreateAttachmentParams метаданные уже сохраненного в хранилище файла. Заполняется сервисом.
+type CreateAttachmentParams struct {
+	Username    Username
+	FileName    string
+	ContentType string
+	Size        int64
+	Sha256      string
+	StorageKey  string
+}
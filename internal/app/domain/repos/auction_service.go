@@ -0,0 +1,30 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// AuctionService предоставляет методы для проведения редукциона по тендеру.
+type AuctionService interface {
+	// Подача новой цены предложения на торгах
+	PlaceAuctionPrice(ctx context.Context, bidId BidId, params PlaceAuctionPriceParams) (models.AuctionState, error)
+	// Текущее состояние торгов
+	GetAuctionState(ctx context.Context, tenderId TenderId, params GetAuctionStateParams) (models.AuctionState, error)
+	// Подписка на изменения состояния торгов. Канал закрывается по окончании торгов или отмене ctx
+	WatchAuction(ctx context.Context, tenderId TenderId, params GetAuctionStateParams) (<-chan models.AuctionState, error)
+}
+
+// PlaceAuctionPriceParams defines parameters for PlaceAuctionPrice.
+type PlaceAuctionPriceParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Price Новая цена. Должна быть ниже лучшей цены торгов как минимум на шаг редукциона
+	Price models.Money `json:"price"`
+}
+
+// GetAuctionStateParams defines parameters for GetAuctionState and WatchAuction.
+type GetAuctionStateParams struct {
+	Username Username `form:"username" json:"username"`
+}
@@ -21,6 +21,11 @@ type BidAuthorId = string
 // BidAuthorType Тип автора
 type BidAuthorType string
 
+const (
+	BidAuthorTypeUser         BidAuthorType = "User"
+	BidAuthorTypeOrganization BidAuthorType = "Organization"
+)
+
 // BidDecision Решение по предложению
 type BidDecision string
 
@@ -45,21 +50,39 @@ type BidReviewId = string
 // BidStatus Статус предложения
 type BidStatus string
 
+const (
+	BidStatusCreated   BidStatus = "Created"
+	BidStatusPublished BidStatus = "Published"
+	BidStatusCanceled  BidStatus = "Canceled"
+	BidStatusApproved  BidStatus = "Approved"
+	BidStatusRejected  BidStatus = "Rejected"
+)
+
 // BidVersion Номер версии посел правок
 type BidVersion = int32
 
 // BidService предоставляет методы для работы с предложениями.
 type BidService interface {
 	CreateBid(ctx context.Context, params CreateBidParams) (models.Bid, error)
-	GetUserBids(ctx context.Context, params GetUserBidsParams) ([]*models.Bid, error)
-	GetBidsForTender(ctx context.Context, tenderId TenderId, params GetBidsForTenderParams) ([]*models.Bid, error)
+	GetUserBids(ctx context.Context, params GetUserBidsParams) (models.Page[*models.Bid], error)
+	GetBidsForTender(ctx context.Context, tenderId TenderId, params GetBidsForTenderParams) (models.Page[*models.Bid], error)
+	GetBid(ctx context.Context, bidId BidId, params GetBidParams) (models.Bid, error)
 	GetBidStatus(ctx context.Context, bidId BidId, params GetBidStatusParams) (BidStatus, error)
 	UpdateBidStatus(ctx context.Context, bidId BidId, params UpdateBidStatusParams) (models.Bid, error)
 	EditBid(ctx context.Context, bidId BidId, username Username, params EditBidParams) (models.Bid, error)
 	SubmitBidDecision(ctx context.Context, bidId BidId, params SubmitBidDecisionParams) (models.Bid, error)
+	GetBidDecisions(ctx context.Context, bidId BidId, params GetBidDecisionsParams) (models.BidDecisionTally, error)
 	SubmitBidFeedback(ctx context.Context, bidId BidId, params SubmitBidFeedbackParams) (models.Bid, error)
+	ReplyBidFeedback(ctx context.Context, bidId BidId, feedbackId BidReviewId, params ReplyBidFeedbackParams) (models.BidFeedbackEntry, error)
+	EditBidFeedback(ctx context.Context, bidId BidId, feedbackId BidReviewId, params EditBidFeedbackParams) (models.BidFeedbackEntry, error)
+	DeleteBidFeedback(ctx context.Context, bidId BidId, feedbackId BidReviewId, params DeleteBidFeedbackParams) error
+	GetBidFeedback(ctx context.Context, bidId BidId, params GetBidFeedbackParams) (models.Page[*models.BidFeedbackEntry], error)
+	GetBidFeedbackVersions(ctx context.Context, bidId BidId, feedbackId BidReviewId, params GetBidFeedbackVersionsParams) ([]*models.BidFeedbackVersion, error)
 	RollbackBid(ctx context.Context, bidId BidId, version int32, params RollbackBidParams) (models.Bid, error)
 	GetBidReviews(ctx context.Context, tenderId TenderId, params GetBidReviewsParams) ([]*models.BidReview, error)
+	GetBidVersions(ctx context.Context, bidId BidId, params GetBidVersionsParams) ([]*models.BidVersionInfo, error)
+	DiffBidVersions(ctx context.Context, bidId BidId, from, to int32, params DiffBidVersionsParams) (models.VersionDiff, error)
+	GetBidRanking(ctx context.Context, tenderId TenderId, params GetBidRankingParams) (models.BidRanking, error)
 }
 
 // CreateBidParams определяет параметры для создания нового предложения.
@@ -70,6 +93,16 @@ type CreateBidParams struct {
 	TenderID        *TenderId       `json:"tenderId"`
 	OrganizationID  *OrganizationId `json:"organizationId"`
 	CreatorUsername *Username       `json:"creatorUsername"`
+
+	// Price Цена предложения. Если у тендера задан бюджет, цена не может его превышать.
+	Price *models.Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки в календарных днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
+
+	// SealedPayload Зашифрованное содержимое предложения к запечатанному тендеру. Заполняется сервисом,
+	// открытые название, описание и цена при этом не сохраняются.
+	SealedPayload []byte `json:"-"`
 }
 
 // BidReview Отзыв о предложении
@@ -95,23 +128,113 @@ type GetUserBidsParams struct {
 	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
 	Offset   *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
 	Username *Username         `form:"username,omitempty" json:"username,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
 }
 
 // EditBidParams defines parameters for EditBid.
 type EditBidParams struct {
 	Name        *BidName        `json:"name,omitempty"`
 	Description *BidDescription `json:"description,omitempty"`
+
+	// Price, DeliveryDays Новые цена и срок поставки. Незаданное значение остается прежним.
+	Price        *models.Money `json:"price,omitempty"`
+	DeliveryDays *int32        `json:"deliveryDays,omitempty"`
+
+	// SealedPayload Новое зашифрованное содержимое запечатанного предложения. Заполняется сервисом.
+	SealedPayload []byte `json:"-"`
+
+	// ExpectedVersion Версия, которую редактирует клиент (If-Match или поле expectedVersion).
+	// Если версия предложения успела измениться, правка отклоняется.
+	ExpectedVersion *BidVersion `json:"expectedVersion,omitempty"`
 }
 
 // SubmitBidFeedbackParams defines parameters for SubmitBidFeedback.
 type SubmitBidFeedbackParams struct {
 	BidFeedback BidFeedback `form:"bidFeedback" json:"bidFeedback"`
 	Username    Username    `form:"username" json:"username"`
+
+	// Rating Оценка предложения от 1 до 5
+	Rating *int32 `form:"rating,omitempty" json:"rating,omitempty"`
+}
+
+// ReplyBidFeedbackParams defines parameters for ReplyBidFeedback.
+type ReplyBidFeedbackParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Description Текст ответа
+	Description BidFeedback `json:"description"`
+}
+
+// EditBidFeedbackParams defines parameters for EditBidFeedback.
+type EditBidFeedbackParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Description Новый текст. Незаданное значение остается прежним.
+	Description *BidFeedback `json:"description,omitempty"`
+
+	// Rating Новая оценка. Незаданное значение остается прежним.
+	Rating *int32 `json:"rating,omitempty"`
+}
+
+// DeleteBidFeedbackParams defines parameters for DeleteBidFeedback.
+type DeleteBidFeedbackParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetBidFeedbackParams defines parameters for GetBidFeedback.
+type GetBidFeedbackParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+}
+
+// GetBidFeedbackVersionsParams defines parameters for GetBidFeedbackVersions.
+type GetBidFeedbackVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
 // RollbackBidParams defines parameters for RollbackBid.
 type RollbackBidParams struct {
 	Username Username `form:"username" json:"username"`
+
+	// ExpectedVersion Версия, от которой клиент делает откат (If-Match или поле expectedVersion).
+	ExpectedVersion *BidVersion `form:"expectedVersion,omitempty" json:"expectedVersion,omitempty"`
+}
+
+// GetBidParams defines parameters for GetBid.
+type GetBidParams struct {
+	Username Username `form:"username" json:"username"`
 }
 
 // GetBidStatusParams defines parameters for GetBidStatus.
@@ -123,6 +246,10 @@ type GetBidStatusParams struct {
 type UpdateBidStatusParams struct {
 	Status   BidStatus `form:"status" json:"status"`
 	Username Username  `form:"username" json:"username"`
+
+	// CurrentStatus Статус, из которого выполняется переход.
+	// Заполняется сервисом после проверки перехода, чтобы БД обновила предложение только если статус не изменился.
+	CurrentStatus BidStatus `json:"-"`
 }
 
 // SubmitBidDecisionParams defines parameters for SubmitBidDecision.
@@ -131,6 +258,11 @@ type SubmitBidDecisionParams struct {
 	Username Username    `form:"username" json:"username"`
 }
 
+// GetBidDecisionsParams defines parameters for GetBidDecisions.
+type GetBidDecisionsParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
 // GetBidsForTenderParams defines parameters for GetBidsForTender.
 type GetBidsForTenderParams struct {
 	Username Username `form:"username" json:"username"`
@@ -142,6 +274,22 @@ type GetBidsForTenderParams struct {
 
 	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
 	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+
+	// Q Строка полнотекстового поиска по названию и описанию предложений.
+	Q *SearchQuery `form:"q,omitempty" json:"q,omitempty"`
+
+	// TenderOwnerStatuses Статусы чужих предложений, которые видит владелец тендера.
+	// Заполняется сервисом по правилам видимости.
+	TenderOwnerStatuses []BidStatus `json:"-"`
 }
 
 // GetBidReviewsParams defines parameters for GetBidReviews.
@@ -160,3 +308,26 @@ type GetBidReviewsParams struct {
 	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
 	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
 }
+
+// GetBidVersionsParams defines parameters for GetBidVersions.
+type GetBidVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// DiffBidVersionsParams defines parameters for DiffBidVersions.
+type DiffBidVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetBidRankingParams defines parameters for GetBidRanking.
+type GetBidRankingParams struct {
+	Username Username `form:"username" json:"username"`
+}
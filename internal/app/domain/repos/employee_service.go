@@ -0,0 +1,52 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// EmployeeService предоставляет методы для работы с сотрудниками.
+type EmployeeService interface {
+	// Получение списка сотрудников
+	GetEmployees(ctx context.Context, params GetEmployeesParams) ([]*models.Employee, error)
+	// Получение сотрудника по username
+	GetEmployee(ctx context.Context, employee Username) (models.Employee, error)
+	// Регистрация сотрудника
+	CreateEmployee(ctx context.Context, params CreateEmployeeParams) (models.Employee, error)
+	// Редактирование своего профиля
+	EditEmployee(ctx context.Context, employee Username, params EditEmployeeParams) (models.Employee, error)
+	// Удаление своего профиля
+	DeleteEmployee(ctx context.Context, employee Username, params DeleteEmployeeParams) error
+}
+
+// GetEmployeesParams defines parameters for GetEmployees.
+type GetEmployeesParams struct {
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+type CreateEmployeeParams struct {
+	Username  *Username `json:"username"`
+	FirstName *string   `json:"firstName"`
+	LastName  *string   `json:"lastName"`
+}
+
+// EditEmployeeParams defines parameters for EditEmployee.
+type EditEmployeeParams struct {
+	// Username Пользователь, выполняющий запрос. Профиль может менять только его владелец
+	Username  Username `form:"username" json:"username"`
+	FirstName *string  `json:"firstName,omitempty"`
+	LastName  *string  `json:"lastName,omitempty"`
+}
+
+// DeleteEmployeeParams defines parameters for DeleteEmployee.
+type DeleteEmployeeParams struct {
+	// Username Пользователь, выполняющий запрос. Удалить профиль может только его владелец
+	Username Username `form:"username" json:"username"`
+}
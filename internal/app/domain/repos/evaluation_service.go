@@ -0,0 +1,78 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// CriterionId Уникальный идентификатор критерия, присвоенный сервером.
+type CriterionId = string
+
+// CriterionKind Способ оценки предложения по критерию
+type CriterionKind = models.CriterionKind
+
+// EvaluationService предоставляет методы для оценки предложений по взвешенным критериям.
+type EvaluationService interface {
+	// Замена набора критериев тендера
+	SetTenderCriteria(ctx context.Context, tenderId TenderId, params SetTenderCriteriaParams) ([]*models.EvaluationCriterion, error)
+	// Получение критериев тендера
+	GetTenderCriteria(ctx context.Context, tenderId TenderId, params GetTenderCriteriaParams) ([]*models.EvaluationCriterion, error)
+	// Выставление оценок предложению по критериям
+	SubmitBidScores(ctx context.Context, bidId BidId, params SubmitBidScoresParams) ([]*models.BidScore, error)
+	// Получение всех оценок предложения
+	GetBidScores(ctx context.Context, bidId BidId, params GetBidScoresParams) ([]*models.BidScore, error)
+	// Итоговые оценки и рейтинг опубликованных предложений тендера
+	GetTenderEvaluation(ctx context.Context, tenderId TenderId, params GetTenderEvaluationParams) (models.TenderEvaluation, error)
+}
+
+// CriterionParams Описание одного критерия в запросе на замену набора критериев.
+type CriterionParams struct {
+	Name        string         `json:"name"`
+	Description *string        `json:"description,omitempty"`
+	Kind        *CriterionKind `json:"kind,omitempty"`
+	Weight      int32          `json:"weight"`
+
+	// MaxScore Максимальная оценка для критериев Manual, по умолчанию 10.
+	MaxScore *int32 `json:"maxScore,omitempty"`
+}
+
+// SetTenderCriteriaParams defines parameters for SetTenderCriteria.
+type SetTenderCriteriaParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Criteria Новый набор критериев в порядке отображения. Полностью заменяет прежний.
+	Criteria []CriterionParams `json:"criteria"`
+}
+
+// GetTenderCriteriaParams defines parameters for GetTenderCriteria.
+type GetTenderCriteriaParams struct {
+	// Username Пользователь, от имени которого запрашиваются критерии.
+	// Без него доступны только критерии публичных тендеров.
+	Username *Username `form:"username,omitempty" json:"username,omitempty"`
+}
+
+// ScoreParams Оценка по одному критерию в запросе.
+type ScoreParams struct {
+	CriterionId CriterionId `json:"criterionId"`
+	Score       int32       `json:"score"`
+	Comment     *string     `json:"comment,omitempty"`
+}
+
+// SubmitBidScoresParams defines parameters for SubmitBidScores.
+type SubmitBidScoresParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Scores Оценки по критериям. Прежние оценки пользователя по этим критериям заменяются.
+	Scores []ScoreParams `json:"scores"`
+}
+
+// GetBidScoresParams defines parameters for GetBidScores.
+type GetBidScoresParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetTenderEvaluationParams defines parameters for GetTenderEvaluation.
+type GetTenderEvaluationParams struct {
+	Username Username `form:"username" json:"username"`
+}
@@ -0,0 +1,88 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// TenderAccess Кто может видеть опубликованный тендер и подавать на него предложения
+type TenderAccess = models.TenderAccess
+
+const (
+	TenderAccessOpen       = models.TenderAccessOpen
+	TenderAccessInviteOnly = models.TenderAccessInviteOnly
+)
+
+// InvitationId Уникальный идентификатор приглашения, присвоенный сервером.
+type InvitationId = models.InvitationId
+
+// InvitationStatus Ответ на приглашение в тендер
+type InvitationStatus = models.InvitationStatus
+
+// InvitationService предоставляет методы для приглашений в закрытые тендеры.
+type InvitationService interface {
+	// Приглашение организации или сотрудника ответственным за тендер
+	InviteToTender(ctx context.Context, tenderId TenderId, params InviteToTenderParams) (models.TenderInvitation, error)
+	// Получение всех приглашений тендера его ответственным
+	GetTenderInvitations(ctx context.Context, tenderId TenderId, params GetTenderInvitationsParams) ([]*models.TenderInvitation, error)
+	// Отзыв приглашения
+	RevokeTenderInvitation(ctx context.Context, tenderId TenderId, invitationId InvitationId, params RevokeTenderInvitationParams) error
+	// Получение приглашений пользователя и его организаций
+	GetUserInvitations(ctx context.Context, params GetUserInvitationsParams) ([]*models.TenderInvitation, error)
+	// Принятие или отклонение приглашения
+	RespondTenderInvitation(ctx context.Context, tenderId TenderId, invitationId InvitationId, params RespondTenderInvitationParams) (models.TenderInvitation, error)
+}
+
+// InviteToTenderParams defines parameters for InviteToTender.
+type InviteToTenderParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// OrganizationId Приглашаемая организация. Указывается либо она, либо сотрудник
+	OrganizationId *OrganizationId `json:"organizationId,omitempty"`
+
+	// EmployeeUsername Приглашаемый сотрудник
+	EmployeeUsername *Username `json:"employeeUsername,omitempty"`
+}
+
+// GetTenderInvitationsParams defines parameters for GetTenderInvitations.
+type GetTenderInvitationsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// RevokeTenderInvitationParams defines parameters for RevokeTenderInvitation.
+type RevokeTenderInvitationParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetUserInvitationsParams defines parameters for GetUserInvitations.
+type GetUserInvitationsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Status Только приглашения с этим ответом
+	Status *InvitationStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// RespondTenderInvitationParams defines parameters for RespondTenderInvitation.
+type RespondTenderInvitationParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Status Accepted или Declined
+	Status InvitationStatus `form:"status" json:"status"`
+}
@@ -0,0 +1,91 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// OrganizationName Название организации
+type OrganizationName = string
+
+// OrganizationDescription Описание организации
+type OrganizationDescription = string
+
+// OrganizationType Организационно-правовая форма
+type OrganizationType string
+
+const (
+	OrganizationTypeIE  OrganizationType = "IE"
+	OrganizationTypeLLC OrganizationType = "LLC"
+	OrganizationTypeJSC OrganizationType = "JSC"
+)
+
+// OrganizationService предоставляет методы для работы с организациями и их ответственными.
+type OrganizationService interface {
+	// Получение списка организаций
+	GetOrganizations(ctx context.Context, params GetOrganizationsParams) ([]*models.Organization, error)
+	// Получение организации по id
+	GetOrganization(ctx context.Context, organizationId OrganizationId) (models.Organization, error)
+	// Создание организации. Создатель становится ее первым ответственным
+	CreateOrganization(ctx context.Context, params CreateOrganizationParams) (models.Organization, error)
+	// Редактирование организации
+	EditOrganization(ctx context.Context, organizationId OrganizationId, params EditOrganizationParams) (models.Organization, error)
+	// Удаление организации
+	DeleteOrganization(ctx context.Context, organizationId OrganizationId, params DeleteOrganizationParams) error
+	// Получение списка ответственных за организацию
+	GetResponsibles(ctx context.Context, organizationId OrganizationId) ([]*models.Employee, error)
+	// Назначение сотрудника ответственным
+	AddResponsible(ctx context.Context, organizationId OrganizationId, params AddResponsibleParams) ([]*models.Employee, error)
+	// Снятие сотрудника с ответственных
+	RemoveResponsible(ctx context.Context, organizationId OrganizationId, params RemoveResponsibleParams) ([]*models.Employee, error)
+}
+
+// GetOrganizationsParams defines parameters for GetOrganizations.
+type GetOrganizationsParams struct {
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+type CreateOrganizationParams struct {
+	Name            *OrganizationName        `json:"name"`
+	Description     *OrganizationDescription `json:"description"`
+	Type            *OrganizationType        `json:"type"`
+	CreatorUsername *Username                `json:"creatorUsername"`
+}
+
+// EditOrganizationParams defines parameters for EditOrganization.
+type EditOrganizationParams struct {
+	Username    Username                 `form:"username" json:"username"`
+	Name        *OrganizationName        `json:"name,omitempty"`
+	Description *OrganizationDescription `json:"description,omitempty"`
+	Type        *OrganizationType        `json:"type,omitempty"`
+}
+
+// DeleteOrganizationParams defines parameters for DeleteOrganization.
+type DeleteOrganizationParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// AddResponsibleParams defines parameters for AddResponsible.
+type AddResponsibleParams struct {
+	// Username Ответственный, который выполняет назначение
+	Username Username `form:"username" json:"username"`
+
+	// Employee Сотрудник, которого назначают ответственным
+	Employee Username `json:"employee"`
+}
+
+// RemoveResponsibleParams defines parameters for RemoveResponsible.
+type RemoveResponsibleParams struct {
+	// Username Ответственный, который выполняет снятие
+	Username Username `form:"username" json:"username"`
+
+	// Employee Сотрудник, которого снимают с ответственных
+	Employee Username `json:"employee"`
+}
@@ -0,0 +1,30 @@
+package repos
+
+// SortOrder Направление сортировки
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// PageCursor Непрозрачный токен продолжения списка, полученный в nextCursor предыдущей страницы.
+type PageCursor = string
+
+// PageKey Позиция в списке, отсортированном по created_at DESC, id.
+type PageKey struct {
+	CreatedAt string `json:"c"`
+	Id        string `json:"i"`
+}
+
+// PageRequest Параметры страницы после проверки. Заполняется сервисом.
+type PageRequest struct {
+	Limit  PaginationLimit
+	Offset PaginationOffset
+
+	// After Позиция, после которой начинается страница. Используется вместо Offset.
+	After *PageKey
+
+	// WithTotal Нужно ли считать общее число объектов.
+	WithTotal bool
+}
@@ -0,0 +1,95 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// QuestionId Уникальный идентификатор вопроса, присвоенный сервером.
+type QuestionId = models.QuestionId
+
+// AnswerVisibility Кому виден ответ на вопрос по тендеру
+type AnswerVisibility = models.AnswerVisibility
+
+// QuestionService предоставляет методы для вопросов и ответов по тендерам.
+type QuestionService interface {
+	// Вопрос по тендеру от любого сотрудника
+	AskQuestion(ctx context.Context, tenderId TenderId, params AskQuestionParams) (models.TenderQuestion, error)
+	// Изменение вопроса его автором, пока на него не ответили
+	EditQuestion(ctx context.Context, tenderId TenderId, questionId QuestionId, params EditQuestionParams) (models.TenderQuestion, error)
+	// Ответ или изменение ответа ответственным организации-владельца тендера
+	AnswerQuestion(ctx context.Context, tenderId TenderId, questionId QuestionId, params AnswerQuestionParams) (models.TenderQuestion, error)
+	// Получение видимых пользователю вопросов по тендеру
+	GetTenderQuestions(ctx context.Context, tenderId TenderId, params GetTenderQuestionsParams) (models.Page[*models.TenderQuestion], error)
+	// Получение истории изменений вопроса и ответа
+	GetQuestionVersions(ctx context.Context, tenderId TenderId, questionId QuestionId, params GetQuestionVersionsParams) ([]*models.TenderQuestionVersion, error)
+}
+
+// AskQuestionParams defines parameters for AskQuestion.
+type AskQuestionParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Question Текст вопроса
+	Question string `json:"question"`
+}
+
+// EditQuestionParams defines parameters for EditQuestion.
+type EditQuestionParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Question Новый текст вопроса
+	Question string `json:"question"`
+}
+
+// AnswerQuestionParams defines parameters for AnswerQuestion.
+type AnswerQuestionParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Answer Текст ответа. Заменяет прежний ответ
+	Answer string `json:"answer"`
+
+	// Visibility Кому виден ответ, по умолчанию только автору вопроса
+	Visibility *AnswerVisibility `json:"visibility,omitempty"`
+}
+
+// GetTenderQuestionsParams defines parameters for GetTenderQuestions.
+type GetTenderQuestionsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+
+	// ViewerId Пользователь, для которого отбираются вопросы. Заполняется сервисом.
+	ViewerId int `json:"-"`
+
+	// All Пользователь видит все вопросы тендера, а не только свои и публичные. Заполняется сервисом.
+	All bool `json:"-"`
+}
+
+// GetQuestionVersionsParams defines parameters for GetQuestionVersions.
+type GetQuestionVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
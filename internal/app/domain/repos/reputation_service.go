@@ -0,0 +1,15 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// ReputationService предоставляет репутацию авторов предложений.
+type ReputationService interface {
+	// Репутация сотрудника по предложениям, поданным от его имени
+	GetEmployeeReputation(ctx context.Context, employee Username) (models.BidderReputation, error)
+	// Репутация организации по предложениям, поданным от ее имени
+	GetOrganizationReputation(ctx context.Context, organizationId OrganizationId) (models.BidderReputation, error)
+}
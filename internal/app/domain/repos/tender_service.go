@@ -2,6 +2,7 @@ package repos
 
 import (
 	"context"
+	"time"
 
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 )
@@ -21,28 +22,58 @@ type TenderName = string
 // TenderServiceType Вид услуги, к которой относиться тендер
 type TenderServiceType string
 
+const (
+	TenderServiceTypeConstruction TenderServiceType = "Construction"
+	TenderServiceTypeDelivery     TenderServiceType = "Delivery"
+	TenderServiceTypeManufacture  TenderServiceType = "Manufacture"
+)
+
+// TenderSort Поле сортировки списка тендеров
+type TenderSort string
+
+const (
+	TenderSortName      TenderSort = "name"
+	TenderSortCreatedAt TenderSort = "createdAt"
+	TenderSortUpdatedAt TenderSort = "updatedAt"
+)
+
 // TenderStatus Статус тендер
 type TenderStatus string
 
+const (
+	TenderStatusCreated   TenderStatus = "Created"
+	TenderStatusPublished TenderStatus = "Published"
+	TenderStatusClosed    TenderStatus = "Closed"
+	TenderStatusCanceled  TenderStatus = "Canceled"
+)
+
 // TenderVersion Номер версии посел правок
 type TenderVersion = int32
 
 // TenderService предоставляет методы для работы с тендерами.
 type TenderService interface {
 	// Получение списка тендеров
-	GetTenders(ctx context.Context, params GetTendersParams) ([]*models.Tender, error)
+	GetTenders(ctx context.Context, params GetTendersParams) (models.Page[*models.Tender], error)
 	// Получение тендеров пользователя
-	GetUserTenders(ctx context.Context, params GetUserTendersParams) ([]*models.Tender, error)
+	GetUserTenders(ctx context.Context, params GetUserTendersParams) (models.Page[*models.Tender], error)
 	// Создание нового тендера
 	CreateTender(ctx context.Context, params CreateTenderParams) (models.Tender, error)
 	// Редактирование тендера
 	EditTender(ctx context.Context, tenderId TenderId, username Username, params EditTenderParams) (models.Tender, error)
+	// Получение тендера по id
+	GetTender(ctx context.Context, tenderId TenderId, params GetTenderParams) (models.Tender, error)
 	// Откат версии тендера
 	RollbackTender(ctx context.Context, tenderId TenderId, version int32, params RollbackTenderParams) (models.Tender, error)
 	// Получение текущего статуса тендера
 	GetTenderStatus(ctx context.Context, tenderId TenderId, params GetTenderStatusParams) (TenderStatus, error)
 	// Изменение статуса тендера
 	UpdateTenderStatus(ctx context.Context, tenderId TenderId, params UpdateTenderStatusParams) (models.Tender, error)
+	// Получение истории версий тендера
+	GetTenderVersions(ctx context.Context, tenderId TenderId, params GetTenderVersionsParams) ([]*models.TenderVersionInfo, error)
+	// Сравнение двух версий тендера
+	DiffTenderVersions(ctx context.Context, tenderId TenderId, from, to int32, params DiffTenderVersionsParams) (models.VersionDiff, error)
+	// Получение журнала действий с тендером
+	GetTenderAudit(ctx context.Context, tenderId TenderId, params GetTenderAuditParams) ([]*models.TenderAuditEntry, error)
 }
 
 type CreateTenderParams struct {
@@ -52,8 +83,32 @@ type CreateTenderParams struct {
 	Status          *TenderStatus      `json:"status"`
 	OrganizationID  *OrganizationId    `json:"organizationId"`
 	CreatorUsername *Username          `json:"creatorUsername"`
+
+	// BidsOpenAt Начало приема предложений. Если не указано, предложения принимаются сразу после публикации.
+	BidsOpenAt *time.Time `json:"bidsOpenAt,omitempty"`
+
+	// BidsCloseAt Окончание приема предложений. Если не указано, тендер закрывается только вручную.
+	BidsCloseAt *time.Time `json:"bidsCloseAt,omitempty"`
+
+	// Budget Максимальная цена предложения. Если не указан, цена не ограничена.
+	Budget *models.Money `json:"budget,omitempty"`
+
+	// Sealed Запечатанный тендер: содержимое предложений шифруется и раскрывается владельцу
+	// только после закрытия тендера. Задается при создании и не меняется.
+	Sealed *bool `json:"sealed,omitempty"`
+
+	// Auction Провести тендер как редукцион. Только для видов услуг Delivery и Manufacture,
+	// требует бюджет и срок окончания приема предложений. Задается при создании и не меняется.
+	Auction *models.AuctionSettings `json:"auction,omitempty"`
+
+	// Access Доступ к тендеру, по умолчанию Open. Закрытый тендер видят и принимают от
+	// предложения только приглашенные. Задается при создании и не меняется.
+	Access *TenderAccess `json:"access,omitempty"`
 }
 
+// SearchQuery Строка полнотекстового поиска в синтаксисе websearch: слова, "фразы", or, -исключения
+type SearchQuery = string
+
 // GetTendersParams defines parameters for GetTenders.
 type GetTendersParams struct {
 	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
@@ -64,10 +119,62 @@ type GetTendersParams struct {
 	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
 	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
 
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+
 	// ServiceType Возвращенные тендеры должны соответствовать указанным видам услуг.
 	//
 	// Если список пустой, фильтры не применяются.
 	ServiceType *[]TenderServiceType `form:"service_type,omitempty" json:"service_type,omitempty"`
+
+	// Username Пользователь, от имени которого запрашивается список. Без него видны только публичные тендеры.
+	Username *Username `form:"username,omitempty" json:"username,omitempty"`
+
+	// Q Строка полнотекстового поиска по названию и описанию. Результаты сортируются по релевантности.
+	Q *SearchQuery `form:"q,omitempty" json:"q,omitempty"`
+
+	// Status Вернуть тендеры только в указанных статусах. Правила видимости при этом сохраняются.
+	Status *[]TenderStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// OrganizationId Вернуть тендеры только указанной организации.
+	OrganizationId *OrganizationId `form:"organizationId,omitempty" json:"organizationId,omitempty"`
+
+	// CreatedFrom, CreatedTo Полуинтервал [from, to) даты создания тендера.
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// UpdatedFrom, UpdatedTo Полуинтервал [from, to) даты последнего изменения тендера.
+	UpdatedFrom *time.Time `form:"updatedFrom,omitempty" json:"updatedFrom,omitempty"`
+	UpdatedTo   *time.Time `form:"updatedTo,omitempty" json:"updatedTo,omitempty"`
+
+	// DeadlineFrom, DeadlineTo Полуинтервал [from, to) окончания приема предложений.
+	// Тендеры без срока под такой фильтр не попадают.
+	DeadlineFrom *time.Time `form:"deadlineFrom,omitempty" json:"deadlineFrom,omitempty"`
+	DeadlineTo   *time.Time `form:"deadlineTo,omitempty" json:"deadlineTo,omitempty"`
+
+	// Sort Поле сортировки. По умолчанию createdAt, а при поиске - релевантность.
+	Sort *TenderSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки. По умолчанию desc.
+	Order *SortOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// VisibleStatuses Статусы тендеров, видимые всем пользователям.
+	// Заполняется сервисом по правилам видимости.
+	VisibleStatuses []TenderStatus `json:"-"`
+
+	// ViewerOrganizations Организации пользователя, тендеры которых видны ему в любом статусе.
+	// Заполняется сервисом по правилам видимости.
+	ViewerOrganizations []OrganizationId `json:"-"`
+
+	// ViewerInvitedTenders Закрытые тендеры, в которые приглашен пользователь.
+	// Заполняется сервисом.
+	ViewerInvitedTenders []TenderId `json:"-"`
 }
 
 // GetUserTendersParams defines parameters for GetUserTenders.
@@ -80,6 +187,18 @@ type GetUserTendersParams struct {
 	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
 	Offset   *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
 	Username *Username         `form:"username,omitempty" json:"username,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+
+	// OrganizationId Вернуть тендеры только этой организации. Пользователь должен быть за нее ответственным.
+	OrganizationId *OrganizationId `form:"organizationId,omitempty" json:"organizationId,omitempty"`
 }
 
 // EditTenderParams defines parameters for EditTender.
@@ -87,11 +206,30 @@ type EditTenderParams struct {
 	Name        *TenderName        `form:"name" json:"name"`
 	Description *TenderDescription `form:"description" json:"description"`
 	ServiceType *TenderServiceType `form:"serviceType" json:"serviceType"`
+
+	// BidsOpenAt, BidsCloseAt Новое окно приема предложений. Незаданная граница остается прежней.
+	BidsOpenAt  *time.Time `form:"bidsOpenAt,omitempty" json:"bidsOpenAt,omitempty"`
+	BidsCloseAt *time.Time `form:"bidsCloseAt,omitempty" json:"bidsCloseAt,omitempty"`
+
+	// Budget Новый бюджет. Незаданный бюджет остается прежним.
+	Budget *models.Money `form:"budget,omitempty" json:"budget,omitempty"`
+
+	// ExpectedVersion Версия, которую редактирует клиент (If-Match или поле expectedVersion).
+	// Если версия тендера успела измениться, правка отклоняется.
+	ExpectedVersion *TenderVersion `form:"expectedVersion,omitempty" json:"expectedVersion,omitempty"`
 }
 
 // RollbackTenderParams defines parameters for RollbackTender.
 type RollbackTenderParams struct {
 	Username Username `form:"username" json:"username"`
+
+	// ExpectedVersion Версия, от которой клиент делает откат (If-Match или поле expectedVersion).
+	ExpectedVersion *TenderVersion `form:"expectedVersion,omitempty" json:"expectedVersion,omitempty"`
+}
+
+// GetTenderParams defines parameters for GetTender.
+type GetTenderParams struct {
+	Username Username `form:"username" json:"username"`
 }
 
 // GetTenderStatusParams defines parameters for GetTenderStatus.
@@ -103,4 +241,31 @@ type GetTenderStatusParams struct {
 type UpdateTenderStatusParams struct {
 	Status   TenderStatus `form:"status" json:"status"`
 	Username Username     `form:"username" json:"username"`
+
+	// CurrentStatus Статус, из которого выполняется переход.
+	// Заполняется сервисом после проверки перехода, чтобы БД обновила тендер только если статус не изменился.
+	CurrentStatus TenderStatus `json:"-"`
+}
+
+// GetTenderVersionsParams defines parameters for GetTenderVersions.
+type GetTenderVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// DiffTenderVersionsParams defines parameters for DiffTenderVersions.
+type DiffTenderVersionsParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetTenderAuditParams defines parameters for GetTenderAudit.
+type GetTenderAuditParams struct {
+	Username Username `form:"username" json:"username"`
 }
@@ -0,0 +1,134 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// WebhookId Уникальный идентификатор подписки, присвоенный сервером.
+type WebhookId = models.WebhookId
+
+// WebhookDeliveryId Уникальный идентификатор доставки, присвоенный сервером.
+type WebhookDeliveryId = models.WebhookDeliveryId
+
+// WebhookDeliveryStatus Состояние доставки события подписке
+type WebhookDeliveryStatus = models.WebhookDeliveryStatus
+
+// WebhookService предоставляет методы для управления подписками организаций на события и их доставками.
+type WebhookService interface {
+	// Создание подписки ответственным за организацию
+	CreateWebhook(ctx context.Context, organizationId OrganizationId, params CreateWebhookParams) (models.Webhook, error)
+	// Получение подписок организации
+	GetWebhooks(ctx context.Context, organizationId OrganizationId, params GetWebhooksParams) (models.Page[*models.Webhook], error)
+	// Получение подписки
+	GetWebhook(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, params GetWebhookParams) (models.Webhook, error)
+	// Изменение адреса, типов событий, ключа подписи или активности подписки
+	EditWebhook(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, params EditWebhookParams) (models.Webhook, error)
+	// Удаление подписки вместе с журналом доставок
+	DeleteWebhook(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, params DeleteWebhookParams) error
+	// Журнал доставок подписки
+	GetWebhookDeliveries(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, params GetWebhookDeliveriesParams) (models.Page[*models.WebhookDelivery], error)
+	// Доставка с телом запроса и журналом попыток
+	GetWebhookDelivery(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, deliveryId WebhookDeliveryId, params GetWebhookDeliveryParams) (models.WebhookDelivery, error)
+	// Ручной повтор завершенной доставки
+	RedeliverWebhook(ctx context.Context, organizationId OrganizationId, webhookId WebhookId, deliveryId WebhookDeliveryId, params RedeliverWebhookParams) (models.WebhookDelivery, error)
+}
+
+// CreateWebhookParams defines parameters for CreateWebhook.
+type CreateWebhookParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Url Адрес получателя, http или https
+	Url string `json:"url"`
+
+	// EventTypes Типы событий. Пустой список - все события
+	EventTypes []models.DomainEventType `json:"eventTypes,omitempty"`
+
+	// Secret Ключ подписи. Если не указан, генерируется сервером
+	Secret *string `json:"secret,omitempty"`
+}
+
+// GetWebhooksParams defines parameters for GetWebhooks.
+type GetWebhooksParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+}
+
+// GetWebhookParams defines parameters for GetWebhook.
+type GetWebhookParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// EditWebhookParams defines parameters for EditWebhook.
+type EditWebhookParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Url Новый адрес получателя
+	Url *string `json:"url,omitempty"`
+
+	// EventTypes Новый список типов событий
+	EventTypes *[]models.DomainEventType `json:"eventTypes,omitempty"`
+
+	// Secret Новый ключ подписи
+	Secret *string `json:"secret,omitempty"`
+
+	// Active Включение и отключение подписки
+	Active *bool `json:"active,omitempty"`
+}
+
+// DeleteWebhookParams defines parameters for DeleteWebhook.
+type DeleteWebhookParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	Username Username `form:"username" json:"username"`
+
+	// Status Только доставки в этом состоянии
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Максимальное число возвращаемых объектов. Используется для запросов с пагинацией.
+	//
+	// Сервер должен возвращать максимальное допустимое число объектов.
+	Limit *PaginationLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Какое количество объектов должно быть пропущено с начала. Используется для запросов с пагинацией.
+	Offset *PaginationOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Токен продолжения из nextCursor предыдущей страницы. Используется вместо offset.
+	Cursor *PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число объектов в totalCount.
+	WithTotal *bool `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+
+	// Page Параметры страницы после проверки. Заполняется сервисом.
+	Page PageRequest `json:"-"`
+}
+
+// GetWebhookDeliveryParams defines parameters for GetWebhookDelivery.
+type GetWebhookDeliveryParams struct {
+	Username Username `form:"username" json:"username"`
+}
+
+// RedeliverWebhookParams defines parameters for RedeliverWebhook.
+type RedeliverWebhookParams struct {
+	Username Username `form:"username" json:"username"`
+}
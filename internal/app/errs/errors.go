@@ -31,4 +31,55 @@ var (
 	ErrUnknownStatus          = errors.New("unknown status")
 	ErrUnknownDecision        = errors.New("unknown decision")
 	ErrAlreadyExists          = errors.New("already exists")
+
+	ErrInvalidStatusCreateTender = errors.New("tender must be Created only")
+	ErrInvalidStatusTransition   = errors.New("invalid status transition")
+	ErrTenderNotEditable         = errors.New("tender is not editable in current status")
+	ErrTenderNotBiddable         = errors.New("tender does not accept bids in current status")
+	ErrTenderClosed              = errors.New("tender is closed or canceled")
+	ErrBidDecisionRequired       = errors.New("bid can be approved or rejected only by decision")
+	ErrBidNotPublished           = errors.New("bid is not published")
+	ErrBidNotEditable            = errors.New("bid is not editable in current status")
+	ErrInvalidBidWindow          = errors.New("invalid bids window")
+	ErrBidsNotOpen               = errors.New("tender does not accept bids yet")
+	ErrBidsClosed                = errors.New("tender bids deadline passed")
+	ErrTenderNotVisible          = errors.New("tender is not visible to user")
+	ErrBidNotVisible             = errors.New("bid is not visible to user")
+	ErrInvalidPagination         = errors.New("invalid pagination parameters")
+	ErrInvalidCursor             = errors.New("invalid page cursor")
+	ErrUnknownServiceType        = errors.New("unknown service type")
+	ErrUnknownSort               = errors.New("unknown sort field or order")
+	ErrInvalidDateRange          = errors.New("invalid date range")
+	ErrAttachmentTooLarge        = errors.New("attachment is too large")
+	ErrUnsupportedContentType    = errors.New("unsupported attachment content type")
+	ErrInvalidMoney              = errors.New("invalid money amount or currency")
+	ErrBudgetExceeded            = errors.New("bid price exceeds tender budget")
+	ErrCurrencyMismatch          = errors.New("bid currency does not match tender budget")
+	ErrInvalidCriteria           = errors.New("invalid evaluation criteria")
+	ErrInvalidScore              = errors.New("invalid bid score")
+	ErrCriterionNotScorable      = errors.New("criterion is calculated and cannot be scored manually")
+	ErrNoCriteria                = errors.New("tender has no evaluation criteria")
+	ErrTenderNotEvaluable        = errors.New("tender bids cannot be scored in current status")
+	ErrTenderSealed              = errors.New("tender bids are sealed until it closes")
+	ErrSealingUnavailable        = errors.New("sealed tenders are not configured")
+	ErrInvalidAuction            = errors.New("invalid auction settings")
+	ErrNotAuction                = errors.New("tender is not an auction")
+	ErrAuctionStarted            = errors.New("auction already started")
+	ErrAuctionNotRunning         = errors.New("auction is not running")
+	ErrNotAuctionParticipant     = errors.New("user is not an auction participant")
+	ErrQuestionsClosed           = errors.New("tender does not accept questions in current status")
+	ErrUnknownAnswerVisibility   = errors.New("unknown answer visibility")
+	ErrQuestionNotVisible        = errors.New("question is not visible to user")
+	ErrUnknownTenderAccess       = errors.New("unknown tender access")
+	ErrTenderNotInviteOnly       = errors.New("tender is open to everyone")
+	ErrInvalidInvitation         = errors.New("invitation must target organization or employee")
+	ErrFeedbackNotAllowed        = errors.New("user cannot leave feedback on bid")
+	ErrInvalidFeedbackRating     = errors.New("invalid feedback rating")
+	ErrInvalidWebhookUrl         = errors.New("invalid webhook url")
+	ErrWebhookUrlNotAllowed      = errors.New("webhook url is not allowed")
+	ErrUnknownEventType          = errors.New("unknown event type")
+	ErrInvalidWebhookSecret      = errors.New("invalid webhook secret")
+
+	ErrUnknownOrganizationType = errors.New("unknown organization type")
+	ErrNotProfileOwner         = errors.New("only owner can change employee profile")
 )
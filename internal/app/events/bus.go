@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// Handler обработчик события внутри процесса
+type Handler func(ctx context.Context, event models.DomainEvent) error
+
+// Bus получатель, раздающий события подписчикам внутри процесса.
+// Обработчики вызываются синхронно в порядке подписки, ошибка любого из них приводит к повторной доставке события
+type Bus struct {
+	mu       sync.RWMutex
+	nextId   int
+	handlers map[models.DomainEventType]map[int]Handler
+	all      map[int]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[models.DomainEventType]map[int]Handler),
+		all:      make(map[int]Handler),
+	}
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Subscribe подписывает handler на события типа eventType, пустой тип - на все события.
+// Возвращает функцию отписки
+func (b *Bus) Subscribe(eventType models.DomainEventType, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextId
+	b.nextId++
+	if eventType == "" {
+		b.all[id] = handler
+	} else {
+		if b.handlers[eventType] == nil {
+			b.handlers[eventType] = make(map[int]Handler)
+		}
+		b.handlers[eventType][id] = handler
+	}
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.all, id)
+		delete(b.handlers[eventType], id)
+	}
+}
+
+func (b *Bus) Deliver(ctx context.Context, event models.DomainEvent) error {
+	var errs []error
+	for _, handler := range b.subscribers(event.Type) {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// subscribers обработчики события в порядке подписки
+func (b *Bus) subscribers(eventType models.DomainEventType) []Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ids := make([]int, 0, len(b.all)+len(b.handlers[eventType]))
+	for id := range b.all {
+		ids = append(ids, id)
+	}
+	for id := range b.handlers[eventType] {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	handlers := make([]Handler, 0, len(ids))
+	for _, id := range ids {
+		if handler, ok := b.all[id]; ok {
+			handlers = append(handlers, handler)
+		} else {
+			handlers = append(handlers, b.handlers[eventType][id])
+		}
+	}
+	return handlers
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+	"go.uber.org/zap"
+)
+
+// Dispatcher периодически разбирает outbox и передает события получателям.
+// Несколько инстансов могут работать одновременно: события между ними делит блокировка строк в БД
+type Dispatcher struct {
+	outbox database.OutboxRepository
+	cfg    config.OutboxConfig
+
+	mu    sync.RWMutex
+	sinks []Sink
+
+	logger *zaplog.ZapLogger
+}
+
+func NewDispatcher(outbox database.OutboxRepository, cfg config.OutboxConfig, logger *zaplog.ZapLogger) *Dispatcher {
+	return &Dispatcher{
+		outbox: outbox,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// AddSink добавляет получателя событий
+func (d *Dispatcher) AddSink(sink Sink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sinks = append(d.sinks, sink)
+}
+
+// Run блокируется до отмены ctx, опрашивая outbox раз в interval.
+// Пока есть готовые события, пачки разбираются без паузы
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	// Доставленные события чистятся не чаще раза в час
+	purge := time.NewTicker(time.Hour)
+	defer purge.Stop()
+
+	d.logger.Info("Outbox dispatcher started", zap.Duration("interval", d.cfg.Interval))
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatch(ctx)
+		case <-purge.C:
+			d.purge(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivered, err := d.outbox.DispatchOutbox(ctx, d.cfg.BatchSize, d.cfg.MaxAttempts, d.deliver)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				d.logger.Error("Error dispatch outbox", zap.Error(err))
+			}
+			return
+		}
+		if delivered < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// deliver передает событие всем получателям. Событие считается доставленным, только если его приняли все
+func (d *Dispatcher) deliver(ctx context.Context, event models.DomainEvent) error {
+	d.mu.RLock()
+	sinks := d.sinks
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) purge(ctx context.Context) {
+	purged, err := d.outbox.PurgeDispatchedEvents(ctx, time.Now().Add(-d.cfg.Retention))
+	if err != nil {
+		d.logger.Error("Error purge outbox", zap.Error(err))
+		return
+	}
+	if purged > 0 {
+		d.logger.Info("Outbox purged", zap.Int64("events", purged))
+	}
+}
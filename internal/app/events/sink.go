@@ -0,0 +1,17 @@
+package events
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// Sink получатель доменных событий из outbox.
+// Доставка как минимум однократная: при ошибке любого получателя событие повторяется для всех,
+// поэтому получатели должны отбрасывать повторы по EventId
+type Sink interface {
+	// Name Имя получателя для логов
+	Name() string
+	// Deliver доставляет событие. Ошибка откладывает событие до следующей попытки
+	Deliver(ctx context.Context, event models.DomainEvent) error
+}
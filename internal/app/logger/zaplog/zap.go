@@ -61,6 +61,13 @@ func New() *ZapLogger {
 	}
 }
 
+// NewNop логгер, который ничего не пишет. Для тестов, где вывод и файл логов не нужны
+func NewNop() *ZapLogger {
+	return &ZapLogger{
+		log: zap.NewNop(),
+	}
+}
+
 func customTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString(t.Format("[2006-01-02 | 15:04:05]"))
 }
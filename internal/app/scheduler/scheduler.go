@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+	"go.uber.org/zap"
+)
+
+// TenderClosedHandler обработчик события автоматического закрытия тендера
+type TenderClosedHandler func(ctx context.Context, event models.TenderClosedEvent)
+
+// Scheduler периодически закрывает тендеры, у которых истек срок приема предложений.
+// Несколько инстансов могут работать одновременно: взаимоисключение обеспечивает БД
+type Scheduler struct {
+	tenders  database.TenderRepository
+	interval time.Duration
+
+	mu       sync.RWMutex
+	handlers []TenderClosedHandler
+
+	logger *zaplog.ZapLogger
+}
+
+func New(tenders database.TenderRepository, cfg config.SchedulerConfig, logger *zaplog.ZapLogger) *Scheduler {
+	return &Scheduler{
+		tenders:  tenders,
+		interval: cfg.Interval,
+		logger:   logger,
+	}
+}
+
+// OnTenderClosed подписывает обработчик на события автоматического закрытия тендеров
+func (s *Scheduler) OnTenderClosed(handler TenderClosedHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// Run блокируется до отмены ctx, проверяя тендеры раз в interval
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.logger.Info("Scheduler started", zap.Duration("interval", s.interval))
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopped")
+			return
+		case <-ticker.C:
+			s.closeExpiredTenders(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) closeExpiredTenders(ctx context.Context) {
+	events, err := s.tenders.CloseExpiredTenders(ctx)
+	if err != nil {
+		s.logger.Error("Error close expired tenders", zap.Error(err))
+		return
+	}
+
+	s.mu.RLock()
+	handlers := s.handlers
+	s.mu.RUnlock()
+
+	for _, event := range events {
+		s.logger.Info("Tender closed by deadline",
+			zap.String("tender_id", event.TenderId),
+			zap.Time("bids_close_at", event.BidsCloseAt))
+		for _, handler := range handlers {
+			handler(ctx, *event)
+		}
+	}
+}
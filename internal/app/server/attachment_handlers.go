@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+// attachmentFormField поле multipart/form-data с содержимым файла
+const attachmentFormField = "file"
+
+func (s *server) UploadTenderAttachment(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	params, closeFile, err := bindUploadAttachment(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+
+	attachment, err := s.attachmentHandler.UploadTenderAttachment(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusCreated, attachment)
+}
+
+func (s *server) GetTenderAttachments(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetAttachmentsParams
+
+	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	attachments, err := s.attachmentHandler.GetTenderAttachments(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, attachments)
+}
+
+func (s *server) DownloadTenderAttachment(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var attachmentId repos.AttachmentId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, ctx.Param("attachmentId"), &attachmentId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attachmentId: %s", err))
+	}
+
+	var params repos.GetAttachmentsParams
+
+	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	attachment, content, err := s.attachmentHandler.DownloadTenderAttachment(context.TODO(), tenderId, attachmentId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return streamAttachment(ctx, attachment, content)
+}
+
+func (s *server) DeleteTenderAttachment(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var attachmentId repos.AttachmentId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, ctx.Param("attachmentId"), &attachmentId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attachmentId: %s", err))
+	}
+
+	var params repos.DeleteAttachmentParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = s.attachmentHandler.DeleteTenderAttachment(context.TODO(), tenderId, attachmentId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *server) UploadBidAttachment(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	params, closeFile, err := bindUploadAttachment(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+
+	attachment, err := s.attachmentHandler.UploadBidAttachment(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusCreated, attachment)
+}
+
+func (s *server) GetBidAttachments(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.GetAttachmentsParams
+
+	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	attachments, err := s.attachmentHandler.GetBidAttachments(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, attachments)
+}
+
+func (s *server) DownloadBidAttachment(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var attachmentId repos.AttachmentId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, ctx.Param("attachmentId"), &attachmentId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attachmentId: %s", err))
+	}
+
+	var params repos.GetAttachmentsParams
+
+	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	attachment, content, err := s.attachmentHandler.DownloadBidAttachment(context.TODO(), bidId, attachmentId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return streamAttachment(ctx, attachment, content)
+}
+
+func (s *server) DeleteBidAttachment(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var attachmentId repos.AttachmentId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attachmentId", runtime.ParamLocationPath, ctx.Param("attachmentId"), &attachmentId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attachmentId: %s", err))
+	}
+
+	var params repos.DeleteAttachmentParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = s.attachmentHandler.DeleteBidAttachment(context.TODO(), bidId, attachmentId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// bindUploadAttachment достает username и файл из multipart/form-data запроса.
+// Возвращенную функцию нужно вызвать, когда содержимое файла больше не нужно
+func bindUploadAttachment(ctx echo.Context) (repos.UploadAttachmentParams, func(), error) {
+	var params repos.UploadAttachmentParams
+
+	err := runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return params, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	header, err := ctx.FormFile(attachmentFormField)
+	if err != nil {
+		return params, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for form field %s: %s", attachmentFormField, err))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return params, nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params.FileName = header.Filename
+	params.ContentType = header.Header.Get(echo.HeaderContentType)
+	params.Size = header.Size
+	params.Content = file
+
+	return params, func() { file.Close() }, nil
+}
+
+// streamAttachment отдает содержимое файла с его метаданными в заголовках и закрывает содержимое
+func streamAttachment(ctx echo.Context, attachment models.Attachment, content io.ReadCloser) error {
+	defer content.Close()
+
+	h := ctx.Response().Header()
+	h.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	h.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	h.Set("ETag", strconv.Quote(attachment.Sha256))
+	h.Set("X-Checksum-Sha256", attachment.Sha256)
+
+	return ctx.Stream(http.StatusOK, attachment.ContentType, content)
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+// AUCTION_STREAM_HEARTBEAT период комментариев-пингов в потоке торгов, чтобы прокси не рвали простаивающее соединение
+const AUCTION_STREAM_HEARTBEAT = 15 * time.Second
+
+func (s *server) PlaceAuctionPrice(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.PlaceAuctionPriceParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody PlaceAuctionPriceJSONRequestBody
+	if err := ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+	params.Price = requestBody.Price
+
+	state, err := s.auctionHandler.PlaceAuctionPrice(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, state)
+}
+
+func (s *server) GetAuctionState(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetAuctionStateParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	state, err := s.auctionHandler.GetAuctionState(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, state)
+}
+
+// StreamAuction отдает состояние торгов потоком Server-Sent Events: событие state при каждом изменении.
+// Поток завершается вместе с торгами или при отключении клиента
+func (s *server) StreamAuction(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetAuctionStateParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	// Контекст запроса, а не context.TODO: подписка должна закончиться, когда клиент отключится
+	reqCtx := ctx.Request().Context()
+	states, err := s.auctionHandler.WatchAuction(reqCtx, tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+
+	resp := ctx.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	heartbeat := time.NewTicker(AUCTION_STREAM_HEARTBEAT)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-reqCtx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(resp, ": ping\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+		case state, ok := <-states:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(state)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(resp, "event: state\ndata: %s\n\n", data); err != nil {
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+func (s *server) GetBidFeedback(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.GetBidFeedbackParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", ctx.QueryParams(), &params.WithTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withTotal: %s", err))
+	}
+
+	feedback, err := s.bidHandler.GetBidFeedback(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, feedback)
+}
+
+func (s *server) ReplyBidFeedback(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+	var feedbackId repos.BidReviewId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "feedbackId", runtime.ParamLocationPath, ctx.Param("feedbackId"), &feedbackId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feedbackId: %s", err))
+	}
+
+	var params repos.ReplyBidFeedbackParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody ReplyBidFeedbackJSONRequestBody
+	if err := ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+	params.Description = requestBody.Description
+
+	reply, err := s.bidHandler.ReplyBidFeedback(context.TODO(), bidId, feedbackId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, reply)
+}
+
+func (s *server) EditBidFeedback(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+	var feedbackId repos.BidReviewId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "feedbackId", runtime.ParamLocationPath, ctx.Param("feedbackId"), &feedbackId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feedbackId: %s", err))
+	}
+
+	var params repos.EditBidFeedbackParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody EditBidFeedbackJSONRequestBody
+	if err := ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+	params.Description = requestBody.Description
+	params.Rating = requestBody.Rating
+
+	feedback, err := s.bidHandler.EditBidFeedback(context.TODO(), bidId, feedbackId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, feedback)
+}
+
+func (s *server) DeleteBidFeedback(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+	var feedbackId repos.BidReviewId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "feedbackId", runtime.ParamLocationPath, ctx.Param("feedbackId"), &feedbackId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feedbackId: %s", err))
+	}
+
+	var params repos.DeleteBidFeedbackParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = s.bidHandler.DeleteBidFeedback(context.TODO(), bidId, feedbackId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *server) GetBidFeedbackVersions(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+	var feedbackId repos.BidReviewId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "feedbackId", runtime.ParamLocationPath, ctx.Param("feedbackId"), &feedbackId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter feedbackId: %s", err))
+	}
+
+	var params repos.GetBidFeedbackVersionsParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	versions, err := s.bidHandler.GetBidFeedbackVersions(context.TODO(), bidId, feedbackId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, versions)
+}
@@ -13,13 +13,9 @@ import (
 func (s *server) GetUserBids(ctx echo.Context) error {
 	var err error
 
-	defaultLimit := int32(10)
-	defaultOffset := int32(0)
 	defaultUsername := ""
 
 	params := repos.GetUserBidsParams{
-		Limit:    &defaultLimit,
-		Offset:   &defaultOffset,
 		Username: &defaultUsername,
 	}
 
@@ -33,6 +29,16 @@ func (s *server) GetUserBids(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", ctx.QueryParams(), &params.WithTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withTotal: %s", err))
+	}
+
 	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
@@ -59,8 +65,15 @@ func (s *server) CreateBid(ctx echo.Context) error {
 		Description:     &requestBody.Description,
 		Status:          &requestBody.Status,
 		TenderID:        &requestBody.TenderId,
-		OrganizationID:  &requestBody.OrganizationId,
+		OrganizationID:  requestBody.OrganizationId,
 		CreatorUsername: &requestBody.CreatorUsername,
+		Price:           requestBody.Price,
+		DeliveryDays:    requestBody.DeliveryDays,
+	}
+
+	// Пустой organizationId равносилен его отсутствию
+	if params.OrganizationID != nil && *params.OrganizationID == "" {
+		params.OrganizationID = nil
 	}
 
 	// здесь надо создавать еще контекст с таймаутом, например
@@ -73,8 +86,35 @@ func (s *server) CreateBid(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, bid.Version)
+	return ctx.JSON(http.StatusOK, bid)
+}
+
+func (s *server) GetBid(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.GetBidParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	bid, err := s.bidHandler.GetBid(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	setETag(ctx, bid.Version)
 	return ctx.JSON(http.StatusOK, bid)
 }
+
 func (s *server) EditBid(ctx echo.Context) error {
 	var err error
 
@@ -96,9 +136,17 @@ func (s *server) EditBid(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
 	}
 
+	expectedVersion, err := getExpectedVersion(ctx, requestBody.ExpectedVersion)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	params := repos.EditBidParams{
-		Name:        requestBody.Name,
-		Description: requestBody.Description,
+		Name:            requestBody.Name,
+		Description:     requestBody.Description,
+		Price:           requestBody.Price,
+		DeliveryDays:    requestBody.DeliveryDays,
+		ExpectedVersion: expectedVersion,
 	}
 
 	bid, err := s.bidHandler.EditBid(context.TODO(), bidId, username, params)
@@ -106,6 +154,7 @@ func (s *server) EditBid(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, bid.Version)
 	return ctx.JSON(http.StatusOK, bid)
 }
 
@@ -130,10 +179,11 @@ func (s *server) SubmitBidFeedback(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
 	}
 
-	// здесь булет идти валидация запроса.
-	// проверяем, является ли username автором бида с bidId,
-	// либо он состоит в орагнизации, которая является автором бида
-	// возвращаем бид (? зачем?)
+	err = runtime.BindQueryParameter("form", true, false, "rating", ctx.QueryParams(), &params.Rating)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rating: %s", err))
+	}
+
 	bid, err := s.bidHandler.SubmitBidFeedback(context.TODO(), bidId, params)
 	if err != nil {
 		httpStatus, errResp := getStatusByError(err)
@@ -163,11 +213,23 @@ func (s *server) RollbackBid(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
 	}
 
+	var requestBody RollbackJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+	}
+
+	params.ExpectedVersion, err = getExpectedVersion(ctx, requestBody.ExpectedVersion)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	bid, err := s.bidHandler.RollbackBid(context.TODO(), bidId, version, params)
 	if err != nil {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, bid.Version)
 	return ctx.JSON(http.StatusOK, bid)
 }
 
@@ -221,6 +283,7 @@ func (s *server) UpdateBidStatus(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, bid.Version)
 	return ctx.JSON(http.StatusOK, bid)
 }
 
@@ -249,9 +312,33 @@ func (s *server) SubmitBidDecision(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, bid.Version)
 	return ctx.JSON(http.StatusOK, bid)
 }
 
+func (s *server) GetBidDecisions(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.GetBidDecisionsParams
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	tally, err := s.bidHandler.GetBidDecisions(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, tally)
+}
+
 func (s *server) GetBidsForTender(ctx echo.Context) error {
 	var err error
 	var tenderId repos.TenderId
@@ -278,6 +365,21 @@ func (s *server) GetBidsForTender(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", ctx.QueryParams(), &params.WithTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withTotal: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
 	bids, err := s.bidHandler.GetBidsForTender(context.TODO(), tenderId, params)
 	if err != nil {
 		httpStatus, errResp := getStatusByError(err)
@@ -324,3 +426,97 @@ func (s *server) GetBidReviews(ctx echo.Context) error {
 	}
 	return ctx.JSON(http.StatusOK, revs)
 }
+
+func (s *server) GetBidRanking(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetBidRankingParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	ranking, err := s.bidHandler.GetBidRanking(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, ranking)
+}
+
+func (s *server) GetBidVersions(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var params repos.GetBidVersionsParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	versions, err := s.bidHandler.GetBidVersions(context.TODO(), bidId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, versions)
+}
+
+func (s *server) DiffBidVersions(ctx echo.Context) error {
+	var err error
+	var bidId repos.BidId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bidId", runtime.ParamLocationPath, ctx.Param("bidId"), &bidId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bidId: %s", err))
+	}
+
+	var from, to int32
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "a", runtime.ParamLocationPath, ctx.Param("a"), &from)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "b", runtime.ParamLocationPath, ctx.Param("b"), &to)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter b: %s", err))
+	}
+
+	var params repos.DiffBidVersionsParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	diff, err := s.bidHandler.DiffBidVersions(context.TODO(), bidId, from, to, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+func (s *server) GetEmployees(ctx echo.Context) error {
+	var err error
+
+	var params repos.GetEmployeesParams
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	employees, err := s.employeeHandler.GetEmployees(context.TODO(), params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, employees)
+}
+
+func (s *server) CreateEmployee(ctx echo.Context) error {
+	var err error
+	var requestBody CreateEmployeeJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params := repos.CreateEmployeeParams{
+		Username:  &requestBody.Username,
+		FirstName: requestBody.FirstName,
+		LastName:  requestBody.LastName,
+	}
+
+	employee, err := s.employeeHandler.CreateEmployee(context.TODO(), params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, employee)
+}
+
+func (s *server) GetEmployee(ctx echo.Context) error {
+	var err error
+	var employee repos.Username
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "employee", runtime.ParamLocationPath, ctx.Param("employee"), &employee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter employee: %s", err))
+	}
+
+	emp, err := s.employeeHandler.GetEmployee(context.TODO(), employee)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, emp)
+}
+
+func (s *server) EditEmployee(ctx echo.Context) error {
+	var err error
+	var employee repos.Username
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "employee", runtime.ParamLocationPath, ctx.Param("employee"), &employee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter employee: %s", err))
+	}
+
+	var params repos.EditEmployeeParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody EditEmployeeJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params.FirstName = requestBody.FirstName
+	params.LastName = requestBody.LastName
+
+	emp, err := s.employeeHandler.EditEmployee(context.TODO(), employee, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, emp)
+}
+
+func (s *server) DeleteEmployee(ctx echo.Context) error {
+	var err error
+	var employee repos.Username
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "employee", runtime.ParamLocationPath, ctx.Param("employee"), &employee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter employee: %s", err))
+	}
+
+	var params repos.DeleteEmployeeParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = s.employeeHandler.DeleteEmployee(context.TODO(), employee, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+func (s *server) GetOrganizations(ctx echo.Context) error {
+	var err error
+
+	var params repos.GetOrganizationsParams
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	organizations, err := s.organizationHandler.GetOrganizations(context.TODO(), params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, organizations)
+}
+
+func (s *server) CreateOrganization(ctx echo.Context) error {
+	var err error
+	var requestBody CreateOrganizationJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params := repos.CreateOrganizationParams{
+		Name:            &requestBody.Name,
+		Description:     &requestBody.Description,
+		Type:            &requestBody.Type,
+		CreatorUsername: &requestBody.CreatorUsername,
+	}
+
+	org, err := s.organizationHandler.CreateOrganization(context.TODO(), params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, org)
+}
+
+func (s *server) GetOrganization(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	org, err := s.organizationHandler.GetOrganization(context.TODO(), organizationId)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, org)
+}
+
+func (s *server) EditOrganization(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	var params repos.EditOrganizationParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody EditOrganizationJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params.Name = requestBody.Name
+	params.Description = requestBody.Description
+	params.Type = requestBody.Type
+
+	org, err := s.organizationHandler.EditOrganization(context.TODO(), organizationId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, org)
+}
+
+func (s *server) DeleteOrganization(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	var params repos.DeleteOrganizationParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = s.organizationHandler.DeleteOrganization(context.TODO(), organizationId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *server) GetResponsibles(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	responsibles, err := s.organizationHandler.GetResponsibles(context.TODO(), organizationId)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, responsibles)
+}
+
+func (s *server) AddResponsible(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	var params repos.AddResponsibleParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	var requestBody AddResponsibleJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params.Employee = requestBody.Employee
+
+	responsibles, err := s.organizationHandler.AddResponsible(context.TODO(), organizationId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, responsibles)
+}
+
+func (s *server) RemoveResponsible(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	var params repos.RemoveResponsibleParams
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "employee", runtime.ParamLocationPath, ctx.Param("employee"), &params.Employee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter employee: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	responsibles, err := s.organizationHandler.RemoveResponsible(context.TODO(), organizationId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, responsibles)
+}
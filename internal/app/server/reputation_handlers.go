@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+)
+
+func (s *server) GetEmployeeReputation(ctx echo.Context) error {
+	var err error
+	var employee repos.Username
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "employee", runtime.ParamLocationPath, ctx.Param("employee"), &employee)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter employee: %s", err))
+	}
+
+	reputation, err := s.reputationHandler.GetEmployeeReputation(context.TODO(), employee)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, reputation)
+}
+
+func (s *server) GetOrganizationReputation(ctx echo.Context) error {
+	var err error
+	var organizationId repos.OrganizationId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organizationId", runtime.ParamLocationPath, ctx.Param("organizationId"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	reputation, err := s.reputationHandler.GetOrganizationReputation(context.TODO(), organizationId)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, reputation)
+}
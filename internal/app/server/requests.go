@@ -1,6 +1,11 @@
 package server
 
-import "github.com/0x0FACED/tender-service/internal/app/domain/repos"
+import (
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
 
 // Псевдоним типа для CreateBid запроса
 type CreateBidJSONRequestBody CreateBidJSONBody
@@ -14,6 +19,9 @@ type CreateTenderJSONRequestBody CreateTenderJSONBody
 // Псевдоним типа для EditTender запроса
 type EditTenderJSONRequestBody EditTenderJSONBody
 
+// Псевдоним типа для RollbackTender и RollbackBid запросов
+type RollbackJSONRequestBody RollbackJSONBody
+
 // CreateBidJSONBody defines parameters for CreateBid.
 type CreateBidJSONBody struct {
 	// CreatorUsername Уникальный slug пользователя.
@@ -25,19 +33,35 @@ type CreateBidJSONBody struct {
 	// Name Полное название предложения
 	Name repos.BidName `json:"name"`
 
-	// OrganizationId Уникальный идентификатор организации, присвоенный сервером.
-	OrganizationId repos.OrganizationId `json:"organizationId"`
+	// OrganizationId Организация, от имени которой подается предложение.
+	// Если не указана, автором предложения считается пользователь.
+	OrganizationId *repos.OrganizationId `json:"organizationId,omitempty"`
 
 	// Status Статус предложения
 	Status repos.BidStatus `json:"status"`
 
 	// TenderId Уникальный идентификатор тендера, присвоенный сервером.
 	TenderId repos.TenderId `json:"tenderId"`
+
+	// Price Цена предложения. Не может превышать бюджет тендера
+	Price *models.Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки в днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
 }
 
 type EditBidJSONBody struct {
 	Name        *repos.BidName        `json:"name,omitempty"`
 	Description *repos.BidDescription `json:"description,omitempty"`
+
+	// Price Цена предложения. Не может превышать бюджет тендера
+	Price *models.Money `json:"price,omitempty"`
+
+	// DeliveryDays Срок поставки в днях
+	DeliveryDays *int32 `json:"deliveryDays,omitempty"`
+
+	// ExpectedVersion Версия, которую редактирует клиент. Альтернатива заголовку If-Match.
+	ExpectedVersion *repos.BidVersion `json:"expectedVersion,omitempty"`
 }
 
 // CreateTenderJSONBody defines parameters for CreateTender.
@@ -59,6 +83,24 @@ type CreateTenderJSONBody struct {
 
 	// Status Статус тендер
 	Status repos.TenderStatus `json:"status"`
+
+	// BidsOpenAt Начало приема предложений в формате RFC3339
+	BidsOpenAt *time.Time `json:"bidsOpenAt,omitempty"`
+
+	// BidsCloseAt Окончание приема предложений в формате RFC3339
+	BidsCloseAt *time.Time `json:"bidsCloseAt,omitempty"`
+
+	// Budget Бюджет тендера - максимальная цена предложений
+	Budget *models.Money `json:"budget,omitempty"`
+
+	// Sealed Запечатать предложения до закрытия тендера
+	Sealed *bool `json:"sealed,omitempty"`
+
+	// Auction Параметры редукциона. Без них тендер проводится обычным порядком
+	Auction *models.AuctionSettings `json:"auction,omitempty"`
+
+	// Access Доступ к тендеру: Open (по умолчанию) или InviteOnly
+	Access *repos.TenderAccess `json:"access,omitempty"`
 }
 
 // EditTenderJSONBody defines parameters for EditTender.
@@ -71,4 +113,203 @@ type EditTenderJSONBody struct {
 
 	// ServiceType Вид услуги, к которой относиться тендер
 	ServiceType *repos.TenderServiceType `json:"serviceType,omitempty"`
+
+	// BidsOpenAt Начало приема предложений в формате RFC3339
+	BidsOpenAt *time.Time `json:"bidsOpenAt,omitempty"`
+
+	// BidsCloseAt Окончание приема предложений в формате RFC3339
+	BidsCloseAt *time.Time `json:"bidsCloseAt,omitempty"`
+
+	// Budget Бюджет тендера - максимальная цена предложений
+	Budget *models.Money `json:"budget,omitempty"`
+
+	// ExpectedVersion Версия, которую редактирует клиент. Альтернатива заголовку If-Match.
+	ExpectedVersion *repos.TenderVersion `json:"expectedVersion,omitempty"`
+}
+
+// RollbackJSONBody defines parameters for RollbackTender and RollbackBid.
+type RollbackJSONBody struct {
+	// ExpectedVersion Версия, от которой клиент делает откат. Альтернатива заголовку If-Match.
+	ExpectedVersion *int32 `json:"expectedVersion,omitempty"`
+}
+
+// Псевдоним типа для CreateOrganization запроса
+type CreateOrganizationJSONRequestBody CreateOrganizationJSONBody
+
+// Псевдоним типа для EditOrganization запроса
+type EditOrganizationJSONRequestBody EditOrganizationJSONBody
+
+// Псевдоним типа для AddResponsible запроса
+type AddResponsibleJSONRequestBody AddResponsibleJSONBody
+
+// Псевдоним типа для CreateEmployee запроса
+type CreateEmployeeJSONRequestBody CreateEmployeeJSONBody
+
+// Псевдоним типа для EditEmployee запроса
+type EditEmployeeJSONRequestBody EditEmployeeJSONBody
+
+// CreateOrganizationJSONBody defines parameters for CreateOrganization.
+type CreateOrganizationJSONBody struct {
+	// CreatorUsername Уникальный slug пользователя. Становится первым ответственным за организацию.
+	CreatorUsername repos.Username `json:"creatorUsername"`
+
+	// Name Название организации
+	Name repos.OrganizationName `json:"name"`
+
+	// Description Описание организации
+	Description repos.OrganizationDescription `json:"description"`
+
+	// Type Организационно-правовая форма: IE, LLC или JSC
+	Type repos.OrganizationType `json:"type"`
+}
+
+// EditOrganizationJSONBody defines parameters for EditOrganization.
+type EditOrganizationJSONBody struct {
+	Name        *repos.OrganizationName        `json:"name,omitempty"`
+	Description *repos.OrganizationDescription `json:"description,omitempty"`
+	Type        *repos.OrganizationType        `json:"type,omitempty"`
+}
+
+// AddResponsibleJSONBody defines parameters for AddResponsible.
+type AddResponsibleJSONBody struct {
+	// Employee Сотрудник, которого назначают ответственным
+	Employee repos.Username `json:"employee"`
+}
+
+// CreateEmployeeJSONBody defines parameters for CreateEmployee.
+type CreateEmployeeJSONBody struct {
+	// Username Уникальный slug пользователя.
+	Username repos.Username `json:"username"`
+
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+}
+
+// EditEmployeeJSONBody defines parameters for EditEmployee.
+type EditEmployeeJSONBody struct {
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
 }
+
+// Псевдоним типа для SetTenderCriteria запроса
+type SetTenderCriteriaJSONRequestBody SetTenderCriteriaJSONBody
+
+// Псевдоним типа для SubmitBidScores запроса
+type SubmitBidScoresJSONRequestBody SubmitBidScoresJSONBody
+
+// SetTenderCriteriaJSONBody defines parameters for SetTenderCriteria.
+type SetTenderCriteriaJSONBody struct {
+	// Criteria Новый набор критериев оценки. Полностью заменяет прежний
+	Criteria []repos.CriterionParams `json:"criteria"`
+}
+
+// PlaceAuctionPriceJSONBody defines parameters for PlaceAuctionPrice.
+type PlaceAuctionPriceJSONBody struct {
+	// Price Новая цена предложения. Должна быть ниже лучшей цены торгов не меньше чем на шаг
+	Price models.Money `json:"price"`
+}
+
+// AskQuestionJSONBody defines parameters for AskQuestion.
+type AskQuestionJSONBody struct {
+	// Question Текст вопроса
+	Question string `json:"question"`
+}
+
+// EditQuestionJSONBody defines parameters for EditQuestion.
+type EditQuestionJSONBody struct {
+	// Question Новый текст вопроса
+	Question string `json:"question"`
+}
+
+// AnswerQuestionJSONBody defines parameters for AnswerQuestion.
+type AnswerQuestionJSONBody struct {
+	// Answer Текст ответа
+	Answer string `json:"answer"`
+
+	// Visibility Кому виден ответ: Public - всем, Private - только автору вопроса
+	Visibility *repos.AnswerVisibility `json:"visibility,omitempty"`
+}
+
+// InviteToTenderJSONBody defines parameters for InviteToTender.
+type InviteToTenderJSONBody struct {
+	// OrganizationId Приглашаемая организация
+	OrganizationId *repos.OrganizationId `json:"organizationId,omitempty"`
+
+	// EmployeeUsername Приглашаемый сотрудник
+	EmployeeUsername *repos.Username `json:"employeeUsername,omitempty"`
+}
+
+// CreateWebhookJSONBody defines parameters for CreateWebhook.
+type CreateWebhookJSONBody struct {
+	// Url Адрес получателя, http или https
+	Url string `json:"url"`
+
+	// EventTypes Типы событий. Пустой список - все события
+	EventTypes []models.DomainEventType `json:"eventTypes,omitempty"`
+
+	// Secret Ключ подписи. Если не указан, генерируется сервером
+	Secret *string `json:"secret,omitempty"`
+}
+
+// EditWebhookJSONBody defines parameters for EditWebhook.
+type EditWebhookJSONBody struct {
+	// Url Новый адрес получателя
+	Url *string `json:"url,omitempty"`
+
+	// EventTypes Новый список типов событий
+	EventTypes *[]models.DomainEventType `json:"eventTypes,omitempty"`
+
+	// Secret Новый ключ подписи
+	Secret *string `json:"secret,omitempty"`
+
+	// Active Включение и отключение подписки
+	Active *bool `json:"active,omitempty"`
+}
+
+// ReplyBidFeedbackJSONBody defines parameters for ReplyBidFeedback.
+type ReplyBidFeedbackJSONBody struct {
+	// Description Текст ответа
+	Description repos.BidFeedback `json:"description"`
+}
+
+// EditBidFeedbackJSONBody defines parameters for EditBidFeedback.
+type EditBidFeedbackJSONBody struct {
+	// Description Новый текст
+	Description *repos.BidFeedback `json:"description,omitempty"`
+
+	// Rating Новая оценка от 1 до 5. Только для отзыва
+	Rating *int32 `json:"rating,omitempty"`
+}
+
+// SubmitBidScoresJSONBody defines parameters for SubmitBidScores.
+type SubmitBidScoresJSONBody struct {
+	// Scores Оценки предложения по критериям тендера
+	Scores []repos.ScoreParams `json:"scores"`
+}
+
+// Псевдоним типа для PlaceAuctionPrice запроса
+type PlaceAuctionPriceJSONRequestBody PlaceAuctionPriceJSONBody
+
+// Псевдоним типа для AskQuestion запроса
+type AskQuestionJSONRequestBody AskQuestionJSONBody
+
+// Псевдоним типа для EditQuestion запроса
+type EditQuestionJSONRequestBody EditQuestionJSONBody
+
+// Псевдоним типа для AnswerQuestion запроса
+type AnswerQuestionJSONRequestBody AnswerQuestionJSONBody
+
+// Псевдоним типа для InviteToTender запроса
+type InviteToTenderJSONRequestBody InviteToTenderJSONBody
+
+// Псевдоним типа для ReplyBidFeedback запроса
+type ReplyBidFeedbackJSONRequestBody ReplyBidFeedbackJSONBody
+
+// Псевдоним типа для EditBidFeedback запроса
+type EditBidFeedbackJSONRequestBody EditBidFeedbackJSONBody
+
+// Псевдоним типа для CreateWebhook запроса
+type CreateWebhookJSONRequestBody CreateWebhookJSONBody
+
+// Псевдоним типа для EditWebhook запроса
+type EditWebhookJSONRequestBody EditWebhookJSONBody
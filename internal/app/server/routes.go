@@ -3,20 +3,83 @@ package server
 func (s *server) RegisterHandlers() {
 	s.r.GET("/api/bids/my", s.GetUserBids)
 	s.r.POST("/api/bids/new", s.CreateBid)
+	s.r.GET("/api/bids/:bidId", s.GetBid)
 	s.r.PATCH("/api/bids/:bidId/edit", s.EditBid)
 	s.r.PUT("/api/bids/:bidId/feedback", s.SubmitBidFeedback)
+	s.r.GET("/api/bids/:bidId/feedback", s.GetBidFeedback)
+	s.r.POST("/api/bids/:bidId/feedback/:feedbackId/replies", s.ReplyBidFeedback)
+	s.r.PATCH("/api/bids/:bidId/feedback/:feedbackId/edit", s.EditBidFeedback)
+	s.r.DELETE("/api/bids/:bidId/feedback/:feedbackId", s.DeleteBidFeedback)
+	s.r.GET("/api/bids/:bidId/feedback/:feedbackId/versions", s.GetBidFeedbackVersions)
 	s.r.PUT("/api/bids/:bidId/rollback/:version", s.RollbackBid)
+	s.r.GET("/api/bids/:bidId/versions", s.GetBidVersions)
+	s.r.GET("/api/bids/:bidId/versions/:a/diff/:b", s.DiffBidVersions)
 	s.r.GET("/api/bids/:bidId/status", s.GetBidStatus)
+	s.r.GET("/api/bids/:bidId/scores", s.GetBidScores)
+	s.r.PUT("/api/bids/:bidId/scores", s.SubmitBidScores)
+	s.r.GET("/api/bids/:bidId/attachments", s.GetBidAttachments)
+	s.r.POST("/api/bids/:bidId/attachments", s.UploadBidAttachment)
+	s.r.GET("/api/bids/:bidId/attachments/:attachmentId", s.DownloadBidAttachment)
+	s.r.DELETE("/api/bids/:bidId/attachments/:attachmentId", s.DeleteBidAttachment)
 	s.r.PUT("/api/bids/:bidId/status", s.UpdateBidStatus)
+	s.r.GET("/api/bids/:bidId/decisions", s.GetBidDecisions)
 	s.r.PUT("/api/bids/:bidId/submit_decision", s.SubmitBidDecision)
+	s.r.POST("/api/bids/:bidId/auction/price", s.PlaceAuctionPrice)
 	s.r.GET("/api/bids/:tenderId/list", s.GetBidsForTender)
 	s.r.GET("/api/bids/:tenderId/reviews", s.GetBidReviews)
+	s.r.GET("/api/bids/:tenderId/ranking", s.GetBidRanking)
+	s.r.GET("/api/bids/:tenderId/evaluation", s.GetTenderEvaluation)
+	s.r.GET("/api/bids/:tenderId/auction", s.GetAuctionState)
+	s.r.GET("/api/bids/:tenderId/auction/stream", s.StreamAuction)
+	s.r.GET("/api/employees", s.GetEmployees)
+	s.r.POST("/api/employees/new", s.CreateEmployee)
+	s.r.GET("/api/employees/:employee", s.GetEmployee)
+	s.r.PATCH("/api/employees/:employee/edit", s.EditEmployee)
+	s.r.DELETE("/api/employees/:employee", s.DeleteEmployee)
+	s.r.GET("/api/employees/:employee/reputation", s.GetEmployeeReputation)
+	s.r.GET("/api/organizations", s.GetOrganizations)
+	s.r.POST("/api/organizations/new", s.CreateOrganization)
+	s.r.GET("/api/organizations/:organizationId", s.GetOrganization)
+	s.r.PATCH("/api/organizations/:organizationId/edit", s.EditOrganization)
+	s.r.DELETE("/api/organizations/:organizationId", s.DeleteOrganization)
+	s.r.GET("/api/organizations/:organizationId/reputation", s.GetOrganizationReputation)
+	s.r.GET("/api/organizations/:organizationId/responsibles", s.GetResponsibles)
+	s.r.POST("/api/organizations/:organizationId/responsibles", s.AddResponsible)
+	s.r.DELETE("/api/organizations/:organizationId/responsibles/:employee", s.RemoveResponsible)
+	s.r.GET("/api/organizations/:organizationId/webhooks", s.GetWebhooks)
+	s.r.POST("/api/organizations/:organizationId/webhooks", s.CreateWebhook)
+	s.r.GET("/api/organizations/:organizationId/webhooks/:webhookId", s.GetWebhook)
+	s.r.PATCH("/api/organizations/:organizationId/webhooks/:webhookId/edit", s.EditWebhook)
+	s.r.DELETE("/api/organizations/:organizationId/webhooks/:webhookId", s.DeleteWebhook)
+	s.r.GET("/api/organizations/:organizationId/webhooks/:webhookId/deliveries", s.GetWebhookDeliveries)
+	s.r.GET("/api/organizations/:organizationId/webhooks/:webhookId/deliveries/:deliveryId", s.GetWebhookDelivery)
+	s.r.POST("/api/organizations/:organizationId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", s.RedeliverWebhook)
 	s.r.GET("/api/ping", s.CheckServer)
 	s.r.GET("/api/tenders", s.GetTenders)
 	s.r.GET("/api/tenders/my", s.GetUserTenders)
+	s.r.GET("/api/tenders/invitations/my", s.GetUserInvitations)
 	s.r.POST("/api/tenders/new", s.CreateTender)
+	s.r.GET("/api/tenders/:tenderId", s.GetTender)
 	s.r.PATCH("/api/tenders/:tenderId/edit", s.EditTender)
 	s.r.PUT("/api/tenders/:tenderId/rollback/:version", s.RollbackTender)
+	s.r.GET("/api/tenders/:tenderId/versions", s.GetTenderVersions)
+	s.r.GET("/api/tenders/:tenderId/versions/:a/diff/:b", s.DiffTenderVersions)
 	s.r.GET("/api/tenders/:tenderId/status", s.GetTenderStatus)
 	s.r.PUT("/api/tenders/:tenderId/status", s.UpdateTenderStatus)
+	s.r.GET("/api/tenders/:tenderId/audit", s.GetTenderAudit)
+	s.r.GET("/api/tenders/:tenderId/criteria", s.GetTenderCriteria)
+	s.r.PUT("/api/tenders/:tenderId/criteria", s.SetTenderCriteria)
+	s.r.GET("/api/tenders/:tenderId/invitations", s.GetTenderInvitations)
+	s.r.POST("/api/tenders/:tenderId/invitations", s.InviteToTender)
+	s.r.DELETE("/api/tenders/:tenderId/invitations/:invitationId", s.RevokeTenderInvitation)
+	s.r.PUT("/api/tenders/:tenderId/invitations/:invitationId/respond", s.RespondTenderInvitation)
+	s.r.GET("/api/tenders/:tenderId/questions", s.GetTenderQuestions)
+	s.r.POST("/api/tenders/:tenderId/questions", s.AskQuestion)
+	s.r.PATCH("/api/tenders/:tenderId/questions/:questionId/edit", s.EditQuestion)
+	s.r.PUT("/api/tenders/:tenderId/questions/:questionId/answer", s.AnswerQuestion)
+	s.r.GET("/api/tenders/:tenderId/questions/:questionId/versions", s.GetQuestionVersions)
+	s.r.GET("/api/tenders/:tenderId/attachments", s.GetTenderAttachments)
+	s.r.POST("/api/tenders/:tenderId/attachments", s.UploadTenderAttachment)
+	s.r.GET("/api/tenders/:tenderId/attachments/:attachmentId", s.DownloadTenderAttachment)
+	s.r.DELETE("/api/tenders/:tenderId/attachments/:attachmentId", s.DeleteTenderAttachment)
 }
@@ -1,11 +1,18 @@
 package server
 
 import (
+	"context"
+
 	"github.com/0x0FACED/tender-service/config"
 	"github.com/0x0FACED/tender-service/internal/app/database/postgres"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	"github.com/0x0FACED/tender-service/internal/app/events"
 	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+	"github.com/0x0FACED/tender-service/internal/app/scheduler"
 	servicesimpl "github.com/0x0FACED/tender-service/internal/app/services_impl"
+	"github.com/0x0FACED/tender-service/internal/app/storage"
+	"github.com/0x0FACED/tender-service/internal/app/webhooks"
 	"github.com/0x0FACED/tender-service/migrations"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -15,9 +22,18 @@ import (
 type server struct {
 	r *echo.Echo
 
-	bidHandler    repos.BidService
-	healthHandler repos.HealthService
-	tenderHandler repos.TenderService
+	bidHandler          repos.BidService
+	healthHandler       repos.HealthService
+	tenderHandler       repos.TenderService
+	organizationHandler repos.OrganizationService
+	employeeHandler     repos.EmployeeService
+	attachmentHandler   repos.AttachmentService
+	evaluationHandler   repos.EvaluationService
+	auctionHandler      repos.AuctionService
+	questionHandler     repos.QuestionService
+	invitationHandler   repos.InvitationService
+	reputationHandler   repos.ReputationService
+	webhookHandler      repos.WebhookService
 
 	logger *zaplog.ZapLogger
 	cfg    config.ServerConfig
@@ -27,17 +43,35 @@ func New(
 	bid repos.BidService,
 	health repos.HealthService,
 	tender repos.TenderService,
+	organization repos.OrganizationService,
+	employee repos.EmployeeService,
+	attachment repos.AttachmentService,
+	evaluation repos.EvaluationService,
+	auction repos.AuctionService,
+	question repos.QuestionService,
+	invitation repos.InvitationService,
+	reputation repos.ReputationService,
+	webhook repos.WebhookService,
 	logger *zaplog.ZapLogger,
 	cfg config.ServerConfig,
 
 ) *server {
 	return &server{
-		r:             echo.New(),
-		bidHandler:    bid,
-		healthHandler: health,
-		tenderHandler: tender,
-		logger:        logger,
-		cfg:           cfg,
+		r:                   echo.New(),
+		bidHandler:          bid,
+		healthHandler:       health,
+		tenderHandler:       tender,
+		organizationHandler: organization,
+		employeeHandler:     employee,
+		attachmentHandler:   attachment,
+		evaluationHandler:   evaluation,
+		auctionHandler:      auction,
+		questionHandler:     question,
+		invitationHandler:   invitation,
+		reputationHandler:   reputation,
+		webhookHandler:      webhook,
+		logger:              logger,
+		cfg:                 cfg,
 	}
 }
 
@@ -61,9 +95,27 @@ func Start() error {
 
 	l.Info("DB successfully connected")
 
-	bidService := servicesimpl.NewBidService(db)
-	tenderService := servicesimpl.NewTenderService(db)
+	blobs, err := storage.New(cfg.Storage)
+	if err != nil {
+		l.Fatal("cant init attachments storage", zap.Error(err))
+		return err
+	}
+
+	l.Info("Attachments storage initialized", zap.String("backend", cfg.Storage.Backend))
+
+	sealer := servicesimpl.NewBidSealer(cfg.Sealing.Key, db)
+	bidService := servicesimpl.NewBidService(db, db, db, sealer)
+	tenderService := servicesimpl.NewTenderService(db, db, sealer, l)
 	healthService := servicesimpl.NewHealthService(db)
+	organizationService := servicesimpl.NewOrganizationService(db)
+	employeeService := servicesimpl.NewEmployeeService(db)
+	attachmentService := servicesimpl.NewAttachmentService(db, db, db, db, blobs, cfg.Storage.MaxAttachmentSize)
+	evaluationService := servicesimpl.NewEvaluationService(db, db, db, db, sealer)
+	auctionService := servicesimpl.NewAuctionService(db, db, db)
+	questionService := servicesimpl.NewQuestionService(db, db, db)
+	invitationService := servicesimpl.NewInvitationService(db, db, db)
+	reputationService := servicesimpl.NewReputationService(db, db, db)
+	webhookService := servicesimpl.NewWebhookService(db, cfg.Webhook.AllowPrivateNetworks)
 
 	if err := migrations.Up(cfg.Database.ConnString); err != nil {
 		l.Fatal("cant migrate up", zap.Error(err))
@@ -72,7 +124,28 @@ func Start() error {
 
 	l.Info("Migrate Up successfully")
 
-	s := New(bidService, healthService, tenderService, l, cfg.Server)
+	// Планировщик работает, пока запущен сервер
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+
+	sched := scheduler.New(db, cfg.Scheduler, l)
+	// Запечатанные предложения раскрываются сразу после закрытия тендера по сроку
+	sched.OnTenderClosed(func(ctx context.Context, event models.TenderClosedEvent) {
+		if err := sealer.RevealTender(ctx, event.TenderId, servicesimpl.RevealTriggerScheduler, nil); err != nil {
+			l.Error("cant reveal sealed bids", zap.String("tenderId", event.TenderId), zap.Error(err))
+		}
+	})
+	go sched.Run(schedulerCtx)
+
+	// Доменные события из outbox получают подписчики внутри процесса и подписки организаций
+	bus := events.NewBus()
+	dispatcher := events.NewDispatcher(db, cfg.Outbox, l)
+	dispatcher.AddSink(bus)
+	dispatcher.AddSink(webhooks.NewSink(db))
+	go dispatcher.Run(schedulerCtx)
+	go webhooks.NewSender(db, cfg.Webhook, l).Run(schedulerCtx)
+
+	s := New(bidService, healthService, tenderService, organizationService, employeeService, attachmentService, evaluationService, auctionService, questionService, invitationService, reputationService, webhookService, l, cfg.Server)
 	s.RegisterHandlers()
 	s.r.Use(middleware.Logger())
 
@@ -25,12 +25,82 @@ func (s *server) GetTenders(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", ctx.QueryParams(), &params.WithTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withTotal: %s", err))
+	}
+
 	// Construction, Delivery, Manufacture
 	err = runtime.BindQueryParameter("form", true, false, "service_type", ctx.QueryParams(), &params.ServiceType)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_type: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "organizationId", ctx.QueryParams(), &params.OrganizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter createdFrom: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter createdTo: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "updatedFrom", ctx.QueryParams(), &params.UpdatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter updatedFrom: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "updatedTo", ctx.QueryParams(), &params.UpdatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter updatedTo: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "deadlineFrom", ctx.QueryParams(), &params.DeadlineFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deadlineFrom: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "deadlineTo", ctx.QueryParams(), &params.DeadlineTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deadlineTo: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
 	// валидируем запрос, делаем запросик в бд, получаем список
 	tenders, err := s.tenderHandler.GetTenders(context.TODO(), params)
 	if err != nil {
@@ -54,11 +124,26 @@ func (s *server) GetUserTenders(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", ctx.QueryParams(), &params.WithTotal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withTotal: %s", err))
+	}
+
 	err = runtime.BindQueryParameter("form", true, false, "username", ctx.QueryParams(), &params.Username)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
 	}
 
+	err = runtime.BindQueryParameter("form", true, false, "organizationId", ctx.QueryParams(), &params.OrganizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organizationId: %s", err))
+	}
+
 	// Получаем списко тендеров, но перед этим
 	// валидируем данные, проверяем доступ юзера к тендерам
 	tenders, err := s.tenderHandler.GetUserTenders(context.TODO(), params)
@@ -85,6 +170,12 @@ func (s *server) CreateTender(ctx echo.Context) error {
 		Status:          &requestBody.Status,
 		OrganizationID:  &requestBody.OrganizationId,
 		CreatorUsername: &requestBody.CreatorUsername,
+		BidsOpenAt:      requestBody.BidsOpenAt,
+		BidsCloseAt:     requestBody.BidsCloseAt,
+		Budget:          requestBody.Budget,
+		Sealed:          requestBody.Sealed,
+		Auction:         requestBody.Auction,
+		Access:          requestBody.Access,
 	}
 
 	// Валидация здесь + потом создание записи в бд, если все гуд
@@ -94,6 +185,32 @@ func (s *server) CreateTender(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, tender.Version)
+	return ctx.JSON(http.StatusOK, tender)
+}
+
+func (s *server) GetTender(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetTenderParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	tender, err := s.tenderHandler.GetTender(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	setETag(ctx, tender.Version)
 	return ctx.JSON(http.StatusOK, tender)
 }
 
@@ -118,10 +235,19 @@ func (s *server) EditTender(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
 	}
 
+	expectedVersion, err := getExpectedVersion(ctx, requestBody.ExpectedVersion)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	params := repos.EditTenderParams{
-		Name:        requestBody.Name,
-		Description: requestBody.Description,
-		ServiceType: requestBody.ServiceType,
+		Name:            requestBody.Name,
+		Description:     requestBody.Description,
+		ServiceType:     requestBody.ServiceType,
+		BidsOpenAt:      requestBody.BidsOpenAt,
+		BidsCloseAt:     requestBody.BidsCloseAt,
+		Budget:          requestBody.Budget,
+		ExpectedVersion: expectedVersion,
 	}
 
 	tender, err := s.tenderHandler.EditTender(context.TODO(), tenderId, username, params)
@@ -129,6 +255,7 @@ func (s *server) EditTender(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, tender.Version)
 	return ctx.JSON(http.StatusOK, tender)
 }
 
@@ -155,11 +282,23 @@ func (s *server) RollbackTender(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
 	}
 
+	var requestBody RollbackJSONRequestBody
+
+	if err = ctx.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid request format: %s", err))
+	}
+
+	params.ExpectedVersion, err = getExpectedVersion(ctx, requestBody.ExpectedVersion)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	tender, err := s.tenderHandler.RollbackTender(context.TODO(), tenderId, version, params)
 	if err != nil {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, tender.Version)
 	return ctx.JSON(http.StatusOK, tender)
 }
 
@@ -213,5 +352,100 @@ func (s *server) UpdateTenderStatus(ctx echo.Context) error {
 		httpStatus, errResp := getStatusByError(err)
 		return ctx.JSON(httpStatus, errResp)
 	}
+	setETag(ctx, tender.Version)
 	return ctx.JSON(http.StatusOK, tender)
 }
+
+func (s *server) GetTenderVersions(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetTenderVersionsParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	versions, err := s.tenderHandler.GetTenderVersions(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, versions)
+}
+
+func (s *server) DiffTenderVersions(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var from, to int32
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "a", runtime.ParamLocationPath, ctx.Param("a"), &from)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+	}
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "b", runtime.ParamLocationPath, ctx.Param("b"), &to)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter b: %s", err))
+	}
+
+	var params repos.DiffTenderVersionsParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	diff, err := s.tenderHandler.DiffTenderVersions(context.TODO(), tenderId, from, to, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, diff)
+}
+
+func (s *server) GetTenderAudit(ctx echo.Context) error {
+	var err error
+	var tenderId repos.TenderId
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenderId", runtime.ParamLocationPath, ctx.Param("tenderId"), &tenderId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenderId: %s", err))
+	}
+
+	var params repos.GetTenderAuditParams
+
+	err = runtime.BindQueryParameter("form", true, true, "username", ctx.QueryParams(), &params.Username)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter username: %s", err))
+	}
+
+	entries, err := s.tenderHandler.GetTenderAudit(context.TODO(), tenderId, params)
+	if err != nil {
+		httpStatus, errResp := getStatusByError(err)
+		return ctx.JSON(httpStatus, errResp)
+	}
+	return ctx.JSON(http.StatusOK, entries)
+}
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	p "github.com/0x0FACED/tender-service/internal/app/database/postgres"
 	e "github.com/0x0FACED/tender-service/internal/app/errs"
+	"github.com/labstack/echo/v4"
 )
 
 // ErrorResponse Используется для возвращения ошибки пользователю
@@ -33,6 +37,190 @@ func getStatusByError(err error) (int, ErrorResponse) {
 	case e.ErrAlreadyExists:
 		return http.StatusBadRequest, ErrorResponse{Reason: "Запись уже существует."}
 
+	case e.ErrInvalidStatusCreateTender:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректный статус. Статус должен быть 'Created'."}
+
+	case e.ErrInvalidStatusTransition:
+		return http.StatusConflict, ErrorResponse{Reason: "Недопустимый переход статуса."}
+
+	case e.ErrTenderNotEditable:
+		return http.StatusConflict, ErrorResponse{Reason: "Тендер нельзя изменить в текущем статусе."}
+
+	case e.ErrBidNotEditable:
+		return http.StatusConflict, ErrorResponse{Reason: "Заявку нельзя изменить в текущем статусе."}
+
+	case e.ErrTenderNotBiddable:
+		return http.StatusConflict, ErrorResponse{Reason: "Тендер не принимает заявки в текущем статусе."}
+
+	case e.ErrTenderClosed:
+		return http.StatusConflict, ErrorResponse{Reason: "Тендер закрыт или отменен, заявки по нему изменить нельзя."}
+
+	case e.ErrBidDecisionRequired:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Статусы 'Approved' и 'Rejected' выставляются только через принятие решения."}
+
+	case e.ErrBidNotPublished:
+		return http.StatusConflict, ErrorResponse{Reason: "Принять решение или выставить оценку можно только по опубликованной заявке."}
+
+	case e.ErrInvalidBidWindow:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректный срок приема заявок: начало должно быть раньше окончания, а окончание - в будущем."}
+
+	case e.ErrBidsNotOpen:
+		return http.StatusConflict, ErrorResponse{Reason: "Прием заявок по тендеру еще не начался."}
+
+	case e.ErrBidsClosed:
+		return http.StatusConflict, ErrorResponse{Reason: "Срок приема заявок по тендеру истек."}
+
+	// Скрытые тендеры и предложения для пользователя не существуют
+	case e.ErrTenderNotVisible:
+		return http.StatusNotFound, ErrorResponse{Reason: "Тендер не найден."}
+
+	case e.ErrBidNotVisible:
+		return http.StatusNotFound, ErrorResponse{Reason: "Заявка не найдена."}
+
+	case e.ErrInvalidPagination:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректные параметры пагинации."}
+
+	case e.ErrInvalidCursor:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректный курсор страницы."}
+
+	case e.ErrUnknownServiceType:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестный вид услуги."}
+
+	case e.ErrUnknownSort:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестное поле или направление сортировки."}
+
+	case e.ErrInvalidDateRange:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Начало диапазона дат должно быть раньше его конца."}
+
+	case e.ErrAttachmentTooLarge:
+		return http.StatusRequestEntityTooLarge, ErrorResponse{Reason: "Превышен допустимый размер файла."}
+
+	case e.ErrUnsupportedContentType:
+		return http.StatusUnsupportedMediaType, ErrorResponse{Reason: "Недопустимый тип файла."}
+
+	case e.ErrInvalidMoney:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректная сумма: ожидается положительное число с точностью до копеек и трехбуквенный код валюты, срок поставки - от 1 до 3650 дней."}
+
+	case e.ErrBudgetExceeded:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Цена предложения превышает бюджет тендера."}
+
+	case e.ErrCurrencyMismatch:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Валюта предложения не совпадает с валютой бюджета тендера."}
+
+	case e.ErrInvalidCriteria:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректные критерии оценки: названия должны быть уникальны, вес - от 1 до 100, максимальная оценка - от 1 до 100, критерии Price и DeliveryTime - не более одного."}
+
+	case e.ErrInvalidScore:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректная оценка: она должна быть в пределах шкалы критерия, по каждому критерию - не более одной."}
+
+	case e.ErrCriterionNotScorable:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Оценка по критерию рассчитывается автоматически и не выставляется вручную."}
+
+	case e.ErrNoCriteria:
+		return http.StatusConflict, ErrorResponse{Reason: "У тендера не заданы критерии оценки."}
+
+	case e.ErrTenderNotEvaluable:
+		return http.StatusConflict, ErrorResponse{Reason: "Заявки по тендеру нельзя оценивать в текущем статусе."}
+
+	case e.ErrTenderSealed:
+		return http.StatusConflict, ErrorResponse{Reason: "Заявки по запечатанному тендеру раскрываются только после его закрытия."}
+
+	case e.ErrSealingUnavailable:
+		return http.StatusServiceUnavailable, ErrorResponse{Reason: "Запечатанные тендеры недоступны: не настроен ключ шифрования."}
+
+	case e.ErrInvalidAuction:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Некорректные параметры редукциона: только для Delivery и Manufacture, нужны бюджет и срок окончания приема заявок, торги начинаются до него, шаг - в валюте бюджета и меньше бюджета, продление - от 0 до 3600 секунд, тендер не может быть запечатанным."}
+
+	case e.ErrNotAuction:
+		return http.StatusConflict, ErrorResponse{Reason: "Тендер не проводится как редукцион."}
+
+	case e.ErrAuctionStarted:
+		return http.StatusConflict, ErrorResponse{Reason: "Торги уже начались: новые заявки не принимаются, цена меняется только через торги."}
+
+	case e.ErrAuctionNotRunning, p.ErrAuctionClosed:
+		return http.StatusConflict, ErrorResponse{Reason: "Торги не идут."}
+
+	case e.ErrNotAuctionParticipant:
+		return http.StatusForbidden, ErrorResponse{Reason: "Пользователь не участвует в торгах."}
+
+	case p.ErrAuctionPriceTooHigh:
+		return http.StatusConflict, ErrorResponse{Reason: "Цена должна быть ниже текущей лучшей цены как минимум на шаг торгов, первая цена - не выше бюджета."}
+
+	case e.ErrQuestionsClosed:
+		return http.StatusConflict, ErrorResponse{Reason: "Вопросы по тендеру принимаются только пока он опубликован."}
+
+	case e.ErrUnknownAnswerVisibility:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестная видимость ответа. Допустимые значения: 'Public', 'Private'."}
+
+	case e.ErrQuestionNotVisible, p.ErrQuestionNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Вопрос не найден."}
+
+	case p.ErrNotQuestionAsker:
+		return http.StatusForbidden, ErrorResponse{Reason: "Пользователь не является автором вопроса."}
+
+	case p.ErrQuestionAnswered:
+		return http.StatusConflict, ErrorResponse{Reason: "На вопрос уже ответили, изменить его нельзя."}
+
+	case e.ErrUnknownTenderAccess:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестный режим доступа к тендеру. Допустимые значения: 'Open', 'InviteOnly'."}
+
+	case e.ErrTenderNotInviteOnly:
+		return http.StatusConflict, ErrorResponse{Reason: "Тендер открыт для всех, приглашения не нужны."}
+
+	case e.ErrInvalidInvitation:
+		return http.StatusBadRequest, ErrorResponse{Reason: "В приглашении указывается либо организация, либо сотрудник."}
+
+	case p.ErrInvitationNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Приглашение не найдено."}
+
+	case p.ErrAlreadyInvited:
+		return http.StatusConflict, ErrorResponse{Reason: "Организация или сотрудник уже приглашены в тендер."}
+
+	case p.ErrNotInvitee:
+		return http.StatusForbidden, ErrorResponse{Reason: "Ответить на приглашение может только приглашенный."}
+
+	case e.ErrFeedbackNotAllowed:
+		return http.StatusForbidden, ErrorResponse{Reason: "Отзыв оставляют только ответственные за тендер, отвечать в ветке отзыва может еще и автор предложения."}
+
+	case e.ErrInvalidFeedbackRating:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Оценка - целое число от 1 до 5 и ставится только в самом отзыве, не в ответе."}
+
+	case p.ErrFeedbackNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Отзыв не найден."}
+
+	case p.ErrNotFeedbackAuthor:
+		return http.StatusForbidden, ErrorResponse{Reason: "Изменить или удалить отзыв может только его автор."}
+
+	case p.ErrFeedbackEditExpired:
+		return http.StatusConflict, ErrorResponse{Reason: "Срок, в течение которого отзыв можно изменить или удалить, истек."}
+
+	case e.ErrInvalidWebhookUrl:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Адрес подписки должен быть абсолютным http или https адресом."}
+
+	case e.ErrWebhookUrlNotAllowed:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Адрес подписки должен разрешаться только во внешние адреса: localhost и внутренние сети запрещены."}
+
+	case e.ErrUnknownEventType:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестный тип события."}
+
+	case e.ErrInvalidWebhookSecret:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Ключ подписи должен быть длиной от 16 до 256 символов."}
+
+	case p.ErrWebhookNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Подписка не найдена."}
+
+	case p.ErrWebhookDeliveryNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Доставка не найдена."}
+
+	case p.ErrWebhookDeliveryPending:
+		return http.StatusConflict, ErrorResponse{Reason: "Доставка еще не завершена, повторить ее нельзя."}
+
+	case e.ErrUnknownOrganizationType:
+		return http.StatusBadRequest, ErrorResponse{Reason: "Неизвестный тип организации. Допустимые значения: 'IE', 'LLC', 'JSC'."}
+
+	case e.ErrNotProfileOwner:
+		return http.StatusForbidden, ErrorResponse{Reason: "Изменить профиль сотрудника может только его владелец."}
+
 	case p.ErrTenderNotFound:
 		return http.StatusNotFound, ErrorResponse{Reason: "Тендер не найден."}
 
@@ -45,6 +233,15 @@ func getStatusByError(err error) (int, ErrorResponse) {
 	case p.ErrBidNotFound:
 		return http.StatusNotFound, ErrorResponse{Reason: "Заявка не найдена."}
 
+	case p.ErrAttachmentNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Вложение не найдено."}
+
+	case p.ErrCriterionNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Критерий оценки не найден в тендере."}
+
+	case p.ErrCriteriaScored:
+		return http.StatusConflict, ErrorResponse{Reason: "По критериям тендера уже выставлены оценки, изменить их нельзя."}
+
 	case p.ErrVersionNotFound:
 		return http.StatusNotFound, ErrorResponse{Reason: "Версия не найдена."}
 
@@ -54,6 +251,45 @@ func getStatusByError(err error) (int, ErrorResponse) {
 	case p.ErrNotAuthor:
 		return http.StatusForbidden, ErrorResponse{Reason: "Пользователь не является автором заявки."}
 
+	case p.ErrBidAlreadyDecided:
+		return http.StatusConflict, ErrorResponse{Reason: "Решение по заявке уже принято."}
+
+	case p.ErrTenderAlreadyAwarded:
+		return http.StatusConflict, ErrorResponse{Reason: "По тендеру уже одобрена другая заявка."}
+
+	case p.ErrDecisionAlreadySubmitted:
+		return http.StatusConflict, ErrorResponse{Reason: "Пользователь уже принял решение по заявке."}
+
+	case p.ErrTenderStatusChanged:
+		return http.StatusConflict, ErrorResponse{Reason: "Статус тендера был изменен другим пользователем."}
+
+	case p.ErrBidStatusChanged:
+		return http.StatusConflict, ErrorResponse{Reason: "Статус заявки был изменен другим пользователем."}
+
+	case p.ErrVersionConflict:
+		return http.StatusPreconditionFailed, ErrorResponse{Reason: "Версия была изменена другим пользователем."}
+
+	case p.ErrEmployeeAlreadyExists:
+		return http.StatusConflict, ErrorResponse{Reason: "Пользователь с таким username уже существует."}
+
+	case p.ErrAlreadyResponsible:
+		return http.StatusConflict, ErrorResponse{Reason: "Сотрудник уже является ответственным за организацию."}
+
+	case p.ErrResponsibleNotFound:
+		return http.StatusNotFound, ErrorResponse{Reason: "Сотрудник не является ответственным за организацию."}
+
+	case p.ErrLastResponsible:
+		return http.StatusConflict, ErrorResponse{Reason: "У организации должен остаться хотя бы один ответственный."}
+
+	case p.ErrOrganizationHasTenders:
+		return http.StatusConflict, ErrorResponse{Reason: "Нельзя удалить организацию, у которой есть тендеры."}
+
+	case p.ErrOrganizationHasBids:
+		return http.StatusConflict, ErrorResponse{Reason: "Нельзя удалить организацию, от имени которой поданы предложения."}
+
+	case p.ErrEmployeeHasActivity:
+		return http.StatusConflict, ErrorResponse{Reason: "Нельзя удалить сотрудника, у которого есть предложения, решения, оценки, вопросы или отзывы."}
+
 	case p.ErrNoBidsForAuthor:
 		return http.StatusNoContent, ErrorResponse{Reason: "Заявки для данного автора не найдены."}
 	}
@@ -62,3 +298,30 @@ func getStatusByError(err error) (int, ErrorResponse) {
 	// поэтому 500 status
 	return http.StatusInternalServerError, ErrorResponse{Reason: "Внутренняя ошибка сервера."}
 }
+
+// setETag проставляет ETag, построенный из номера версии тендера или предложения
+func setETag(ctx echo.Context, version int32) {
+	ctx.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(int(version))))
+}
+
+// getExpectedVersion достает ожидаемую версию из заголовка If-Match или из поля expectedVersion тела запроса.
+// Возвращает nil, если клиент не передал предусловие или передал If-Match: *
+func getExpectedVersion(ctx echo.Context, bodyVersion *int32) (*int32, error) {
+	header := strings.TrimSpace(ctx.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return bodyVersion, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	parsed, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header: %s", header)
+	}
+
+	version := int32(parsed)
+	if bodyVersion != nil && *bodyVersion != version {
+		return nil, fmt.Errorf("If-Match %s conflicts with expectedVersion %d", header, *bodyVersion)
+	}
+
+	return &version, nil
+}
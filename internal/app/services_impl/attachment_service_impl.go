@@ -0,0 +1,276 @@
+package servicesimpl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+	"github.com/0x0FACED/tender-service/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+type AttachmentServiceImpl struct {
+	db      database.AttachmentRepository
+	tenders database.TenderRepository
+	bids    database.BidRepository
+	users   database.UserRepository
+	blobs   storage.BlobStore
+
+	// maxSize максимальный размер одного файла в байтах
+	maxSize int64
+}
+
+func NewAttachmentService(
+	db database.AttachmentRepository,
+	tenders database.TenderRepository,
+	bids database.BidRepository,
+	users database.UserRepository,
+	blobs storage.BlobStore,
+	maxSize int64,
+) repos.AttachmentService {
+	return &AttachmentServiceImpl{
+		db:      db,
+		tenders: tenders,
+		bids:    bids,
+		users:   users,
+		blobs:   blobs,
+		maxSize: maxSize,
+	}
+}
+
+func (a *AttachmentServiceImpl) UploadTenderAttachment(ctx context.Context, tenderId repos.TenderId, params repos.UploadAttachmentParams) (models.Attachment, error) {
+	if err := validateUploadAttachment(&params, a.maxSize); err != nil {
+		return models.Attachment{}, err.Error()
+	}
+	tender, err := a.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if !isTenderEditable(repos.TenderStatus(tender.Status)) {
+		return models.Attachment{}, e.New("tender is "+string(tender.Status), e.ErrTenderNotEditable).Error()
+	}
+	// Права проверяются до загрузки, чтобы посторонний не мог писать в хранилище
+	if err := a.db.CheckTenderUploader(ctx, tenderId, params.Username); err != nil {
+		return models.Attachment{}, err
+	}
+
+	created, err := a.storeBlob(ctx, "tenders/"+tenderId, params)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment, err := a.db.CreateTenderAttachment(ctx, tenderId, created)
+	if err != nil {
+		// Метаданные не сохранились, поэтому файл в хранилище больше никому не нужен
+		a.blobs.Delete(context.Background(), created.StorageKey)
+		return models.Attachment{}, err
+	}
+	return *attachment, nil
+}
+
+func (a *AttachmentServiceImpl) GetTenderAttachments(ctx context.Context, tenderId repos.TenderId, params repos.GetAttachmentsParams) ([]*models.Attachment, error) {
+	if err := validateGetAttachments(params); err != nil {
+		return nil, err.Error()
+	}
+	if err := a.checkTenderVisible(ctx, tenderId, params.Username); err != nil {
+		return nil, err
+	}
+	return a.db.GetTenderAttachments(ctx, tenderId)
+}
+
+func (a *AttachmentServiceImpl) DownloadTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId, params repos.GetAttachmentsParams) (models.Attachment, io.ReadCloser, error) {
+	if err := validateGetAttachments(params); err != nil {
+		return models.Attachment{}, nil, err.Error()
+	}
+	if err := a.checkTenderVisible(ctx, tenderId, params.Username); err != nil {
+		return models.Attachment{}, nil, err
+	}
+	attachment, err := a.db.GetTenderAttachment(ctx, tenderId, attachmentId)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+	return a.openBlob(ctx, attachment)
+}
+
+func (a *AttachmentServiceImpl) DeleteTenderAttachment(ctx context.Context, tenderId repos.TenderId, attachmentId repos.AttachmentId, params repos.DeleteAttachmentParams) error {
+	if err := validateDeleteAttachment(params); err != nil {
+		return err.Error()
+	}
+	tender, err := a.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if !isTenderEditable(repos.TenderStatus(tender.Status)) {
+		return e.New("tender is "+string(tender.Status), e.ErrTenderNotEditable).Error()
+	}
+	return a.db.DeleteTenderAttachment(ctx, tenderId, attachmentId, params.Username)
+}
+
+func (a *AttachmentServiceImpl) UploadBidAttachment(ctx context.Context, bidId repos.BidId, params repos.UploadAttachmentParams) (models.Attachment, error) {
+	if err := validateUploadAttachment(&params, a.maxSize); err != nil {
+		return models.Attachment{}, err.Error()
+	}
+	if err := a.checkBidEditable(ctx, bidId); err != nil {
+		return models.Attachment{}, err
+	}
+	if err := a.db.CheckBidUploader(ctx, bidId, params.Username); err != nil {
+		return models.Attachment{}, err
+	}
+
+	created, err := a.storeBlob(ctx, "bids/"+bidId, params)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment, err := a.db.CreateBidAttachment(ctx, bidId, created)
+	if err != nil {
+		a.blobs.Delete(context.Background(), created.StorageKey)
+		return models.Attachment{}, err
+	}
+	return *attachment, nil
+}
+
+func (a *AttachmentServiceImpl) GetBidAttachments(ctx context.Context, bidId repos.BidId, params repos.GetAttachmentsParams) ([]*models.Attachment, error) {
+	if err := validateGetAttachments(params); err != nil {
+		return nil, err.Error()
+	}
+	if err := a.checkBidVisible(ctx, bidId, params.Username); err != nil {
+		return nil, err
+	}
+	return a.db.GetBidAttachments(ctx, bidId)
+}
+
+func (a *AttachmentServiceImpl) DownloadBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId, params repos.GetAttachmentsParams) (models.Attachment, io.ReadCloser, error) {
+	if err := validateGetAttachments(params); err != nil {
+		return models.Attachment{}, nil, err.Error()
+	}
+	if err := a.checkBidVisible(ctx, bidId, params.Username); err != nil {
+		return models.Attachment{}, nil, err
+	}
+	attachment, err := a.db.GetBidAttachment(ctx, bidId, attachmentId)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+	return a.openBlob(ctx, attachment)
+}
+
+func (a *AttachmentServiceImpl) DeleteBidAttachment(ctx context.Context, bidId repos.BidId, attachmentId repos.AttachmentId, params repos.DeleteAttachmentParams) error {
+	if err := validateDeleteAttachment(params); err != nil {
+		return err.Error()
+	}
+	if err := a.checkBidEditable(ctx, bidId); err != nil {
+		return err
+	}
+	return a.db.DeleteBidAttachment(ctx, bidId, attachmentId, params.Username)
+}
+
+// storeBlob сохраняет содержимое в хранилище под новым ключом, по пути считая размер и sha256
+func (a *AttachmentServiceImpl) storeBlob(ctx context.Context, prefix string, params repos.UploadAttachmentParams) (repos.CreateAttachmentParams, error) {
+	key := prefix + "/" + uuid.NewString()
+
+	hash := sha256.New()
+	// Читаем на байт больше лимита, чтобы отличить файл ровно максимального размера от большего
+	content := &countingReader{r: io.TeeReader(io.LimitReader(params.Content, a.maxSize+1), hash)}
+
+	if err := a.blobs.Put(ctx, key, content, params.Size, params.ContentType); err != nil {
+		a.blobs.Delete(context.Background(), key)
+		if content.n > a.maxSize {
+			return repos.CreateAttachmentParams{}, e.New("attachment size exceeded", e.ErrAttachmentTooLarge).Error()
+		}
+		return repos.CreateAttachmentParams{}, err
+	}
+	if content.n > a.maxSize {
+		a.blobs.Delete(context.Background(), key)
+		return repos.CreateAttachmentParams{}, e.New("attachment size exceeded", e.ErrAttachmentTooLarge).Error()
+	}
+
+	return repos.CreateAttachmentParams{
+		Username:    params.Username,
+		FileName:    params.FileName,
+		ContentType: params.ContentType,
+		Size:        content.n,
+		Sha256:      hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}, nil
+}
+
+func (a *AttachmentServiceImpl) openBlob(ctx context.Context, attachment *models.Attachment) (models.Attachment, io.ReadCloser, error) {
+	content, err := a.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+	return *attachment, content, nil
+}
+
+func (a *AttachmentServiceImpl) checkTenderVisible(ctx context.Context, tenderId repos.TenderId, username *repos.Username) error {
+	v, err := resolveViewer(ctx, a.users, username)
+	if err != nil {
+		return err
+	}
+	tender, err := a.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	return v.checkTenderVisible(tender)
+}
+
+func (a *AttachmentServiceImpl) checkBidVisible(ctx context.Context, bidId repos.BidId, username *repos.Username) error {
+	v, err := resolveViewer(ctx, a.users, username)
+	if err != nil {
+		return err
+	}
+	bid, err := a.bids.GetBidByID(ctx, bidId)
+	if err != nil {
+		return err
+	}
+	tender, err := a.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return err
+	}
+	// Вложения запечатанного предложения раскрываются вместе с его содержимым
+	if !v.canViewBidContent(bid, tender) {
+		return e.New("bid is not visible", e.ErrBidNotVisible).Error()
+	}
+	return nil
+}
+
+// checkBidEditable файлы предложения меняются по тем же правилам, что и само предложение:
+// только у действующего предложения и пока тендер принимает предложения
+func (a *AttachmentServiceImpl) checkBidEditable(ctx context.Context, bidId repos.BidId) error {
+	bid, err := a.bids.GetBidByID(ctx, bidId)
+	if err != nil {
+		return err
+	}
+	if status := repos.BidStatus(bid.Status); !isBidEditable(status) {
+		return e.New("bid is "+string(status), e.ErrBidNotEditable).Error()
+	}
+	tender, err := a.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return err
+	}
+	if !isTenderBiddable(repos.TenderStatus(tender.Status)) {
+		return e.New("tender is "+string(tender.Status), e.ErrTenderNotBiddable).Error()
+	}
+	if err := checkBidWindow(tender.BidsOpenAt, tender.BidsCloseAt, time.Now()); err != nil {
+		return err.Error()
+	}
+	return nil
+}
+
+// countingReader считает прочитанные байты
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,109 @@
+package servicesimpl
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+	"github.com/0x0FACED/tender-service/internal/app/storage"
+)
+
+// memoryBlobs хранилище в памяти. strict повторяет поведение S3: тело длиннее заявленного размера - ошибка
+type memoryBlobs struct {
+	strict  bool
+	objects map[string][]byte
+}
+
+func (m *memoryBlobs) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if m.strict && int64(len(body)) != size {
+		return fmt.Errorf("body length %d does not match size %d", len(body), size)
+	}
+	m.objects[key] = body
+	return nil
+}
+
+func (m *memoryBlobs) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, ok := m.objects[key]
+	if !ok {
+		return nil, storage.ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+func (m *memoryBlobs) Delete(ctx context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func TestStoreBlobSizeLimit(t *testing.T) {
+	const maxSize = 16
+
+	cases := []struct {
+		name    string
+		content string
+		// size размер, заявленный клиентом
+		size   int64
+		strict bool
+		tooBig bool
+	}{
+		{name: "below limit", content: "small", size: 5},
+		{name: "exactly limit", content: strings.Repeat("a", maxSize), size: maxSize},
+		{name: "declared small, sent more", content: strings.Repeat("a", maxSize+1), size: 1, tooBig: true},
+		{name: "much larger than limit", content: strings.Repeat("a", 10*maxSize), size: maxSize, tooBig: true},
+		{name: "store fails on oversized body", content: strings.Repeat("a", maxSize+1), size: maxSize, strict: true, tooBig: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			blobs := &memoryBlobs{strict: tc.strict, objects: make(map[string][]byte)}
+			a := &AttachmentServiceImpl{blobs: blobs, maxSize: maxSize}
+
+			created, err := a.storeBlob(context.Background(), "tenders/t1", repos.UploadAttachmentParams{
+				Username:    "user",
+				FileName:    "file.txt",
+				ContentType: "text/plain",
+				Size:        tc.size,
+				Content:     strings.NewReader(tc.content),
+			})
+
+			if tc.tooBig {
+				if !errors.Is(err, e.ErrAttachmentTooLarge) {
+					t.Fatalf("got %v, want ErrAttachmentTooLarge", err)
+				}
+				if len(blobs.objects) != 0 {
+					t.Fatalf("oversized blob left in store: %d objects", len(blobs.objects))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("storeBlob: %v", err)
+			}
+			if created.Size != int64(len(tc.content)) {
+				t.Errorf("Size = %d, want %d", created.Size, len(tc.content))
+			}
+			sum := sha256.Sum256([]byte(tc.content))
+			if created.Sha256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("Sha256 = %s, want %x", created.Sha256, sum)
+			}
+			if !strings.HasPrefix(created.StorageKey, "tenders/t1/") {
+				t.Errorf("StorageKey = %q, want prefix tenders/t1/", created.StorageKey)
+			}
+			if got := string(blobs.objects[created.StorageKey]); got != tc.content {
+				t.Errorf("stored %q, want %q", got, tc.content)
+			}
+		})
+	}
+}
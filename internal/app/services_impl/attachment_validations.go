@@ -0,0 +1,77 @@
+package servicesimpl
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+var MAX_ATTACHMENT_FILE_NAME_SIZE = 255
+
+// allowedAttachmentTypes документы, таблицы, чертежи и архивы, которые обычно прикладывают к закупкам
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf":          true,
+	"application/zip":          true,
+	"application/msword":       true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       true,
+	"image/png":  true,
+	"image/jpeg": true,
+	"text/plain": true,
+	"text/csv":   true,
+}
+
+// validateUploadAttachment проверяет заявленные клиентом метаданные и нормализует имя и тип файла.
+// Фактический размер дополнительно проверяется при чтении содержимого
+func validateUploadAttachment(params *repos.UploadAttachmentParams, maxSize int64) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	// Клиенты иногда передают полный путь, в том числе в стиле Windows
+	name := path.Base(strings.ReplaceAll(params.FileName, `\`, "/"))
+	if name == "" || name == "." || name == "/" {
+		err := e.New("empty file name", e.ErrEmpty)
+		return err
+	}
+	if len(name) > MAX_ATTACHMENT_FILE_NAME_SIZE {
+		err := e.New("file name length exceeded", e.ErrExceededLength)
+		return err
+	}
+	params.FileName = name
+
+	mediaType, _, err := mime.ParseMediaType(params.ContentType)
+	if err != nil || !allowedAttachmentTypes[mediaType] {
+		err := e.New("content type "+params.ContentType+" is not allowed", e.ErrUnsupportedContentType)
+		return err
+	}
+	params.ContentType = mediaType
+
+	if params.Size < 0 || params.Size > maxSize {
+		err := e.New("attachment size exceeded", e.ErrAttachmentTooLarge)
+		return err
+	}
+
+	return nil
+}
+
+func validateGetAttachments(params repos.GetAttachmentsParams) *e.ServiceError {
+	if params.Username != nil && *params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateDeleteAttachment(params repos.DeleteAttachmentParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package servicesimpl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+// Редукцион (аукцион на понижение цены).
+//
+//	до auction.startsAt    - участники подают и публикуют предложения, как в обычном тендере
+//	с auction.startsAt     - новые предложения не принимаются, опубликованные участвуют в торгах
+//	                         и снижают цену не меньше чем на шаг от лучшей
+//	до bidsCloseAt         - поздняя цена сдвигает bidsCloseAt на extensionSeconds,
+//	                         тендер закрывает планировщик, как обычно
+//
+// Участникам видны лучшая цена, своя цена и свое место, владельцу тендера - лучшая цена и число участников.
+
+// isAuctionStarted торги по тендеру начались: состав участников и цены меняются только через торги
+func isAuctionStarted(tender *models.Tender, now time.Time) bool {
+	return tender.Auction != nil && !now.Before(tender.Auction.StartsAt)
+}
+
+// auctionPhase этап торгов. Истекший, но еще не закрытый планировщиком тендер уже считается завершенным
+func auctionPhase(tender *models.Tender, now time.Time) models.AuctionPhase {
+	if repos.TenderStatus(tender.Status) != repos.TenderStatusPublished {
+		if repos.TenderStatus(tender.Status) == repos.TenderStatusCreated {
+			return models.AuctionPhaseScheduled
+		}
+		return models.AuctionPhaseFinished
+	}
+	if now.Before(tender.Auction.StartsAt) {
+		return models.AuctionPhaseScheduled
+	}
+	if tender.BidsCloseAt != nil && !now.Before(*tender.BidsCloseAt) {
+		return models.AuctionPhaseFinished
+	}
+	return models.AuctionPhaseRunning
+}
+
+// auctionState состояние торгов для пользователя v. Участвуют цены только в валюте шага торгов
+func auctionState(tender *models.Tender, bids []*models.Bid, v viewer, now time.Time) models.AuctionState {
+	state := models.AuctionState{
+		TenderId:     tender.Id,
+		Phase:        auctionPhase(tender, now),
+		StartsAt:     tender.Auction.StartsAt,
+		EndsAt:       tender.BidsCloseAt,
+		Step:         tender.Auction.Step,
+		Participants: len(bids),
+	}
+
+	inAuction := func(bid *models.Bid) bool {
+		return bid.Price != nil && bid.Price.Currency == tender.Auction.Step.Currency
+	}
+
+	for _, bid := range bids {
+		if inAuction(bid) && (state.BestPrice == nil || moneyCents(bid.Price.Amount) < moneyCents(state.BestPrice.Amount)) {
+			state.BestPrice = bid.Price
+		}
+	}
+
+	// Владелец тендера видит торги целиком, но не как участник
+	if v.isResponsible(tender.OrganizationId) {
+		return state
+	}
+	for _, bid := range bids {
+		if !v.isBidAuthor(bid) {
+			continue
+		}
+		state.BidId = &bid.Id
+		state.Price = bid.Price
+		if inAuction(bid) {
+			rank := 1
+			for _, other := range bids {
+				if inAuction(other) && moneyCents(other.Price.Amount) < moneyCents(bid.Price.Amount) {
+					rank++
+				}
+			}
+			state.Rank = &rank
+		}
+		break
+	}
+
+	return state
+}
+
+// auctionHub уведомляет подписчиков о новых ценах торгов в пределах одного инстанса.
+// Цены с других инстансов подписчики замечают при периодической перепроверке
+type auctionHub struct {
+	mu   sync.Mutex
+	subs map[repos.TenderId]map[chan struct{}]struct{}
+}
+
+func newAuctionHub() *auctionHub {
+	return &auctionHub{subs: map[repos.TenderId]map[chan struct{}]struct{}{}}
+}
+
+// subscribe возвращает канал уведомлений по тендеру и функцию отписки
+func (h *auctionHub) subscribe(tenderId repos.TenderId) (<-chan struct{}, func()) {
+	// Буфер на одно уведомление: подписчику важен сам факт изменения, а не их число
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.subs[tenderId] == nil {
+		h.subs[tenderId] = map[chan struct{}]struct{}{}
+	}
+	h.subs[tenderId][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[tenderId], ch)
+		if len(h.subs[tenderId]) == 0 {
+			delete(h.subs, tenderId)
+		}
+	}
+}
+
+func (h *auctionHub) notify(tenderId repos.TenderId) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[tenderId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
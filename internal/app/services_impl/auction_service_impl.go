@@ -0,0 +1,157 @@
+package servicesimpl
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// AUCTION_POLL_INTERVAL период перепроверки торгов для подписчиков: смена этапа по времени
+// и цены, поданные через другие инстансы сервиса
+const AUCTION_POLL_INTERVAL = 2 * time.Second
+
+type AuctionServiceImpl struct {
+	bids    database.BidRepository
+	tenders database.TenderRepository
+	users   database.UserRepository
+	hub     *auctionHub
+}
+
+func NewAuctionService(bids database.BidRepository, tenders database.TenderRepository, users database.UserRepository) repos.AuctionService {
+	return &AuctionServiceImpl{
+		bids:    bids,
+		tenders: tenders,
+		users:   users,
+		hub:     newAuctionHub(),
+	}
+}
+
+func (s *AuctionServiceImpl) PlaceAuctionPrice(ctx context.Context, bidId repos.BidId, params repos.PlaceAuctionPriceParams) (models.AuctionState, error) {
+	if err := validatePlaceAuctionPrice(&params); err != nil {
+		return models.AuctionState{}, err.Error()
+	}
+	bid, err := s.bids.GetBidByID(ctx, bidId)
+	if err != nil {
+		return models.AuctionState{}, err
+	}
+	tender, err := s.getAuctionTender(ctx, bid.TenderId)
+	if err != nil {
+		return models.AuctionState{}, err
+	}
+	if phase := auctionPhase(tender, time.Now()); phase != models.AuctionPhaseRunning {
+		return models.AuctionState{}, e.New("auction is "+string(phase), e.ErrAuctionNotRunning).Error()
+	}
+	if params.Price.Currency != tender.Auction.Step.Currency {
+		return models.AuctionState{}, e.New("price currency "+params.Price.Currency+" does not match auction currency "+tender.Auction.Step.Currency, e.ErrCurrencyMismatch).Error()
+	}
+
+	// Шаг, окончание торгов и продление проверяются в БД под блокировкой тендера
+	if _, err := s.bids.PlaceAuctionPrice(ctx, bidId, params.Username, params.Price); err != nil {
+		return models.AuctionState{}, err
+	}
+	s.hub.notify(tender.Id)
+
+	return s.GetAuctionState(ctx, tender.Id, repos.GetAuctionStateParams{Username: params.Username})
+}
+
+func (s *AuctionServiceImpl) GetAuctionState(ctx context.Context, tenderId repos.TenderId, params repos.GetAuctionStateParams) (models.AuctionState, error) {
+	if err := validateGetAuctionState(params); err != nil {
+		return models.AuctionState{}, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return models.AuctionState{}, err
+	}
+	return s.state(ctx, tenderId, v)
+}
+
+func (s *AuctionServiceImpl) WatchAuction(ctx context.Context, tenderId repos.TenderId, params repos.GetAuctionStateParams) (<-chan models.AuctionState, error) {
+	if err := validateGetAuctionState(params); err != nil {
+		return nil, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return nil, err
+	}
+	// Первое состояние заодно проверяет права, чтобы ошибка вернулась до начала потока
+	state, err := s.state(ctx, tenderId, v)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, unsubscribe := s.hub.subscribe(tenderId)
+	states := make(chan models.AuctionState, 1)
+	states <- state
+
+	go func() {
+		defer close(states)
+		defer unsubscribe()
+
+		ticker := time.NewTicker(AUCTION_POLL_INTERVAL)
+		defer ticker.Stop()
+
+		last := state
+		for last.Phase != models.AuctionPhaseFinished {
+			select {
+			case <-ctx.Done():
+				return
+			case <-updates:
+			case <-ticker.C:
+			}
+
+			next, err := s.state(ctx, tenderId, v)
+			if err != nil {
+				return
+			}
+			if reflect.DeepEqual(next, last) {
+				continue
+			}
+			select {
+			case states <- next:
+			case <-ctx.Done():
+				return
+			}
+			last = next
+		}
+	}()
+
+	return states, nil
+}
+
+// state состояние торгов для пользователя v. Торги видят только владелец тендера и участники
+func (s *AuctionServiceImpl) state(ctx context.Context, tenderId repos.TenderId, v viewer) (models.AuctionState, error) {
+	tender, err := s.getAuctionTender(ctx, tenderId)
+	if err != nil {
+		return models.AuctionState{}, err
+	}
+	bids, err := s.bids.GetAuctionBids(ctx, tenderId)
+	if err != nil {
+		return models.AuctionState{}, err
+	}
+
+	allowed := v.isResponsible(tender.OrganizationId)
+	for _, bid := range bids {
+		allowed = allowed || v.isBidAuthor(bid)
+	}
+	if !allowed {
+		return models.AuctionState{}, e.New("user is not an auction participant", e.ErrNotAuctionParticipant).Error()
+	}
+
+	return auctionState(tender, bids, v, time.Now()), nil
+}
+
+func (s *AuctionServiceImpl) getAuctionTender(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Auction == nil {
+		return nil, e.New("tender is not an auction", e.ErrNotAuction).Error()
+	}
+	return tender, nil
+}
@@ -0,0 +1,109 @@
+package servicesimpl
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+const (
+	// MAX_AUCTION_EXTENSION_SECONDS верхняя граница продления торгов за одну позднюю цену
+	MAX_AUCTION_EXTENSION_SECONDS int32 = 3600
+)
+
+// validateAuctionSettings проверяет параметры редукциона нового тендера и приводит шаг к виду с двумя знаками.
+// Вызывается после validateCreateTender, поэтому бюджет уже проверен
+func validateAuctionSettings(params repos.CreateTenderParams) *e.ServiceError {
+	a := params.Auction
+
+	if *params.ServiceType != repos.TenderServiceTypeDelivery && *params.ServiceType != repos.TenderServiceTypeManufacture {
+		err := e.New("auction is allowed only for Delivery and Manufacture", e.ErrInvalidAuction)
+		return err
+	}
+
+	// В запечатанном тендере цены не видны до закрытия, торговаться не о чем
+	if params.Sealed != nil && *params.Sealed {
+		err := e.New("auction tender cannot be sealed", e.ErrInvalidAuction)
+		return err
+	}
+
+	if params.Budget == nil {
+		err := e.New("auction requires budget", e.ErrInvalidAuction)
+		return err
+	}
+
+	if params.BidsCloseAt == nil {
+		err := e.New("auction requires bidsCloseAt", e.ErrInvalidAuction)
+		return err
+	}
+
+	if err := validateMoney("auction.step", &a.Step, true); err != nil {
+		return e.New(err.Message(), e.ErrInvalidAuction)
+	}
+
+	if err := checkAuctionBudget(a, params.Budget); err != nil {
+		return err
+	}
+
+	if err := checkAuctionWindow(a, params.BidsOpenAt, params.BidsCloseAt); err != nil {
+		return err
+	}
+
+	if a.ExtensionSeconds < 0 || a.ExtensionSeconds > MAX_AUCTION_EXTENSION_SECONDS {
+		err := e.New("auction.extensionSeconds must be between 0 and "+strconv.Itoa(int(MAX_AUCTION_EXTENSION_SECONDS)), e.ErrInvalidAuction)
+		return err
+	}
+
+	return nil
+}
+
+// checkAuctionBudget шаг торгов задается в валюте бюджета и должен быть меньше него
+func checkAuctionBudget(a *models.AuctionSettings, budget *models.Money) *e.ServiceError {
+	if a.Step.Currency != budget.Currency {
+		err := e.New("auction step currency "+a.Step.Currency+" does not match budget currency "+budget.Currency, e.ErrInvalidAuction)
+		return err
+	}
+	if moneyCents(a.Step.Amount) >= moneyCents(budget.Amount) {
+		err := e.New("auction step must be less than budget", e.ErrInvalidAuction)
+		return err
+	}
+	return nil
+}
+
+// checkAuctionWindow торги начинаются внутри окна приема предложений: до начала торгов участники
+// подают и публикуют предложения, а с его начала только снижают цены
+func checkAuctionWindow(a *models.AuctionSettings, openAt, closeAt *time.Time) *e.ServiceError {
+	if closeAt == nil || !a.StartsAt.Before(*closeAt) {
+		err := e.New("auction must start before bids close", e.ErrInvalidAuction)
+		return err
+	}
+	if openAt != nil && a.StartsAt.Before(*openAt) {
+		err := e.New("auction cannot start before bids open", e.ErrInvalidAuction)
+		return err
+	}
+	return nil
+}
+
+func validatePlaceAuctionPrice(params *repos.PlaceAuctionPriceParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if err := validateMoney("price", &params.Price, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateGetAuctionState(params repos.GetAuctionStateParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package servicesimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// Отзывы на предложения.
+//
+//	отзыв с необязательной оценкой оставляют ответственные за тендер
+//	в ветке отзыва отвечают автор предложения и ответственные за тендер
+//	автор правит и удаляет свою запись только в течение FEEDBACK_EDIT_WINDOW
+//	отзывы видят те же, кому видно предложение
+const (
+	MAX_FEEDBACK_SIZE    = 1000
+	MIN_FEEDBACK_RATING  = 1
+	MAX_FEEDBACK_RATING  = 5
+	FEEDBACK_EDIT_WINDOW = 15 * time.Minute
+)
+
+func (b *BidServiceImpl) SubmitBidFeedback(ctx context.Context, bidId repos.BidId, params repos.SubmitBidFeedbackParams) (models.Bid, error) {
+	if err := validateSubmitBidFeedback(params); err != nil {
+		return models.Bid{}, err.Error()
+	}
+	v, bid, tender, err := b.getFeedbackBid(ctx, bidId, params.Username)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	if !v.isResponsible(tender.OrganizationId) {
+		return models.Bid{}, e.New("user is not tender responsible", e.ErrFeedbackNotAllowed).Error()
+	}
+	// Отзыв на предложение, содержимое которого еще не видно, не имеет смысла
+	if err := b.sealer.checkRevealed(ctx, tender); err != nil {
+		return models.Bid{}, err
+	}
+	if _, err := b.db.CreateBidFeedback(ctx, bidId, params.Username, nil, params.BidFeedback, params.Rating); err != nil {
+		return models.Bid{}, err
+	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
+	return *bid, nil
+}
+
+func (b *BidServiceImpl) ReplyBidFeedback(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId, params repos.ReplyBidFeedbackParams) (models.BidFeedbackEntry, error) {
+	if err := validateReplyBidFeedback(params); err != nil {
+		return models.BidFeedbackEntry{}, err.Error()
+	}
+	v, bid, tender, err := b.getFeedbackBid(ctx, bidId, params.Username)
+	if err != nil {
+		return models.BidFeedbackEntry{}, err
+	}
+	if !v.isBidAuthor(bid) && !v.isResponsible(tender.OrganizationId) {
+		return models.BidFeedbackEntry{}, e.New("user is neither bid author nor tender responsible", e.ErrFeedbackNotAllowed).Error()
+	}
+	reply, err := b.db.CreateBidFeedback(ctx, bidId, params.Username, &feedbackId, params.Description, nil)
+	if err != nil {
+		return models.BidFeedbackEntry{}, err
+	}
+	return *reply, nil
+}
+
+func (b *BidServiceImpl) EditBidFeedback(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId, params repos.EditBidFeedbackParams) (models.BidFeedbackEntry, error) {
+	if err := validateEditBidFeedback(params); err != nil {
+		return models.BidFeedbackEntry{}, err.Error()
+	}
+	if _, _, _, err := b.getFeedbackBid(ctx, bidId, params.Username); err != nil {
+		return models.BidFeedbackEntry{}, err
+	}
+	current, err := b.db.GetBidFeedbackEntry(ctx, bidId, feedbackId)
+	if err != nil {
+		return models.BidFeedbackEntry{}, err
+	}
+	if current.ParentId != nil && params.Rating != nil {
+		return models.BidFeedbackEntry{}, e.New("reply cannot be rated", e.ErrInvalidFeedbackRating).Error()
+	}
+	// Автора и срок правки проверяет БД под блокировкой записи
+	feedback, err := b.db.EditBidFeedback(ctx, feedbackId, params.Username, params, FEEDBACK_EDIT_WINDOW)
+	if err != nil {
+		return models.BidFeedbackEntry{}, err
+	}
+	return *feedback, nil
+}
+
+func (b *BidServiceImpl) DeleteBidFeedback(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId, params repos.DeleteBidFeedbackParams) error {
+	if err := validateDeleteBidFeedback(params); err != nil {
+		return err.Error()
+	}
+	if _, _, _, err := b.getFeedbackBid(ctx, bidId, params.Username); err != nil {
+		return err
+	}
+	if _, err := b.db.GetBidFeedbackEntry(ctx, bidId, feedbackId); err != nil {
+		return err
+	}
+	return b.db.DeleteBidFeedback(ctx, feedbackId, params.Username, FEEDBACK_EDIT_WINDOW)
+}
+
+func (b *BidServiceImpl) GetBidFeedback(ctx context.Context, bidId repos.BidId, params repos.GetBidFeedbackParams) (models.Page[*models.BidFeedbackEntry], error) {
+	if err := validateGetBidFeedback(params); err != nil {
+		return models.Page[*models.BidFeedbackEntry]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.BidFeedbackEntry]{}, verr.Error()
+	}
+	if _, _, _, err := b.getFeedbackBid(ctx, bidId, params.Username); err != nil {
+		return models.Page[*models.BidFeedbackEntry]{}, err
+	}
+	page, err := b.db.GetBidFeedback(ctx, bidId, pageReq)
+	if err != nil {
+		return models.Page[*models.BidFeedbackEntry]{}, err
+	}
+	setNextCursor(page, pageReq, feedbackPageKey)
+	return *page, nil
+}
+
+func (b *BidServiceImpl) GetBidFeedbackVersions(ctx context.Context, bidId repos.BidId, feedbackId repos.BidReviewId, params repos.GetBidFeedbackVersionsParams) ([]*models.BidFeedbackVersion, error) {
+	if err := validateGetBidFeedbackVersions(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	if _, _, _, err := b.getFeedbackBid(ctx, bidId, params.Username); err != nil {
+		return nil, err
+	}
+	if _, err := b.db.GetBidFeedbackEntry(ctx, bidId, feedbackId); err != nil {
+		return nil, err
+	}
+	return b.db.GetBidFeedbackVersions(ctx, feedbackId, limit, offset)
+}
+
+// getFeedbackBid предложение и тендер для работы с отзывами. Отзывы видны тем же, кому видно предложение
+func (b *BidServiceImpl) getFeedbackBid(ctx context.Context, bidId repos.BidId, username repos.Username) (viewer, *models.Bid, *models.Tender, error) {
+	v, err := resolveViewer(ctx, b.users, &username)
+	if err != nil {
+		return viewer{}, nil, nil, err
+	}
+	bid, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return viewer{}, nil, nil, err
+	}
+	tender, err := b.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return viewer{}, nil, nil, err
+	}
+	if !v.canViewBid(bid, tender) {
+		return viewer{}, nil, nil, e.New("bid is not visible", e.ErrBidNotVisible).Error()
+	}
+	return v, bid, tender, nil
+}
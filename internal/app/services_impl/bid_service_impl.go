@@ -2,10 +2,12 @@ package servicesimpl
 
 import (
 	"context"
+	"time"
 
 	"github.com/0x0FACED/tender-service/internal/app/database"
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
 )
 
 // TODO: после вызова методов обращения к БД добавить проверку ошибки
@@ -19,12 +21,18 @@ const (
 
 // Всякие валидации здесь будут и вызовы БД
 type BidServiceImpl struct {
-	db database.BidRepository
+	db      database.BidRepository
+	tenders database.TenderRepository
+	users   database.UserRepository
+	sealer  *BidSealer
 }
 
-func NewBidService(db database.BidRepository) repos.BidService {
+func NewBidService(db database.BidRepository, tenders database.TenderRepository, users database.UserRepository, sealer *BidSealer) repos.BidService {
 	return &BidServiceImpl{
-		db: db,
+		db:      db,
+		tenders: tenders,
+		users:   users,
+		sealer:  sealer,
 	}
 }
 
@@ -32,28 +40,143 @@ func (b *BidServiceImpl) CreateBid(ctx context.Context, params repos.CreateBidPa
 	if err := validateCreateBid(params); err != nil {
 		return models.Bid{}, err.Error()
 	}
+	tender, err := b.checkTenderBiddable(ctx, *params.TenderID)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	v, err := resolveViewer(ctx, b.users, params.CreatorUsername)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	// Закрытый тендер для неприглашенного не существует, как и скрытый
+	if !v.isInvited(tender) {
+		return models.Bid{}, e.New("user is not invited to tender", e.ErrTenderNotVisible).Error()
+	}
+	if isAuctionStarted(tender, time.Now()) {
+		return models.Bid{}, e.New("auction started at "+tender.Auction.StartsAt.Format(time.RFC3339), e.ErrAuctionStarted).Error()
+	}
+	if err := checkWithinBudget(params.Price, tender.Budget); err != nil {
+		return models.Bid{}, err.Error()
+	}
+	if tender.Sealed {
+		payload, err := b.sealer.seal(tender.Id, models.SealedBidContent{
+			Name:         *params.Name,
+			Description:  *params.Description,
+			Price:        params.Price,
+			DeliveryDays: params.DeliveryDays,
+		})
+		if err != nil {
+			return models.Bid{}, err
+		}
+		// Название и описание в БД обязательны, поэтому вместо них сохраняются пустые строки
+		empty := ""
+		params.Name, params.Description, params.Price, params.DeliveryDays, params.SealedPayload = &empty, &empty, nil, nil, payload
+	}
 
 	bid, err := b.db.CreateBid(ctx, params)
 	if err != nil {
 		return models.Bid{}, err
 	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
 	return *bid, nil
 }
 
-func (b *BidServiceImpl) GetUserBids(ctx context.Context, params repos.GetUserBidsParams) ([]*models.Bid, error) {
+func (b *BidServiceImpl) GetUserBids(ctx context.Context, params repos.GetUserBidsParams) (models.Page[*models.Bid], error) {
 
 	if err := validateGetUserBids(params); err != nil {
-		return nil, err.Error()
+		return models.Page[*models.Bid]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.Bid]{}, verr.Error()
 	}
-	return b.db.GetUserBids(ctx, params)
+	params.Page = pageReq
+
+	page, err := b.db.GetUserBids(ctx, params)
+	if err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	if err := b.sealer.openBids(page.Items); err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	setNextCursor(page, pageReq, bidPageKey)
+	return *page, nil
 }
 
-func (b *BidServiceImpl) GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) ([]*models.Bid, error) {
+func (b *BidServiceImpl) GetBidsForTender(ctx context.Context, tenderId repos.TenderId, params repos.GetBidsForTenderParams) (models.Page[*models.Bid], error) {
 
 	if err := validateGetBidsForTender(params); err != nil {
-		return nil, err.Error()
+		return models.Page[*models.Bid]{}, err.Error()
 	}
-	return b.db.GetBidsForTender(ctx, tenderId, params)
+	searching := params.Q != nil && *params.Q != ""
+	// Результаты поиска отсортированы по релевантности, курсор по created_at для них не подходит
+	if searching && params.Cursor != nil && *params.Cursor != "" {
+		return models.Page[*models.Bid]{}, e.New("cursor is not supported for search", e.ErrInvalidCursor).Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.Bid]{}, verr.Error()
+	}
+	params.Page = pageReq
+
+	v, err := resolveViewer(ctx, b.users, &params.Username)
+	if err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	tender, err := b.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	// Закрытый тендер, который не успели раскрыть, раскрываем до выборки
+	if isTenderSealed(tender) && repos.TenderStatus(tender.Status) == repos.TenderStatusClosed {
+		if err := b.sealer.checkRevealed(ctx, tender); err != nil {
+			return models.Page[*models.Bid]{}, err
+		}
+		if tender, err = b.tenders.GetTenderByID(ctx, tenderId); err != nil {
+			return models.Page[*models.Bid]{}, err
+		}
+	}
+	params.TenderOwnerStatuses = bidTenderOwnerStatuses
+
+	page, err := b.db.GetBidsForTender(ctx, tenderId, params)
+	if err != nil {
+		return models.Page[*models.Bid]{}, err
+	}
+	if !searching {
+		setNextCursor(page, pageReq, bidPageKey)
+	}
+	for i, bid := range page.Items {
+		if bid.SealedPayload == nil {
+			continue
+		}
+		if !v.canViewBidContent(bid, tender) {
+			page.Items[i] = sealedBidView(bid)
+			continue
+		}
+		if err := b.sealer.openBid(bid); err != nil {
+			return models.Page[*models.Bid]{}, err
+		}
+	}
+	return *page, nil
+}
+
+func (b *BidServiceImpl) GetBid(ctx context.Context, bidId repos.BidId, params repos.GetBidParams) (models.Bid, error) {
+	if err := validateGetBid(params); err != nil {
+		return models.Bid{}, err.Error()
+	}
+	bid, err := b.db.GetBid(ctx, bidId, params.Username)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
+	return *bid, nil
 }
 
 func (b *BidServiceImpl) GetBidStatus(ctx context.Context, bidId repos.BidId, params repos.GetBidStatusParams) (repos.BidStatus, error) {
@@ -61,7 +184,22 @@ func (b *BidServiceImpl) GetBidStatus(ctx context.Context, bidId repos.BidId, pa
 	if err := validateGetBidStatus(params); err != nil {
 		return "", err.Error()
 	}
-	return b.db.GetBidStatus(ctx, bidId, params)
+	v, err := resolveViewer(ctx, b.users, &params.Username)
+	if err != nil {
+		return "", err
+	}
+	bid, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return "", err
+	}
+	tender, err := b.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return "", err
+	}
+	if !v.canViewBid(bid, tender) {
+		return "", e.New("bid is not visible", e.ErrBidNotVisible).Error()
+	}
+	return repos.BidStatus(bid.Status), nil
 }
 
 func (b *BidServiceImpl) UpdateBidStatus(ctx context.Context, bidId repos.BidId, params repos.UpdateBidStatusParams) (models.Bid, error) {
@@ -69,10 +207,33 @@ func (b *BidServiceImpl) UpdateBidStatus(ctx context.Context, bidId repos.BidId,
 	if err := validateUpdateBidStatus(params); err != nil {
 		return models.Bid{}, err.Error()
 	}
+
+	current, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	tender, err := b.checkTenderNotFinished(ctx, current.TenderId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	from := repos.BidStatus(current.Status)
+	if !canTransitBidByAuthor(from, params.Status) {
+		return models.Bid{}, e.New("transition "+string(from)+" -> "+string(params.Status)+" not allowed", e.ErrInvalidStatusTransition).Error()
+	}
+	// Участники торгов определяются на момент их начала, отозвать предложение при этом можно
+	if params.Status == repos.BidStatusPublished && isAuctionStarted(tender, time.Now()) {
+		return models.Bid{}, e.New("auction started at "+tender.Auction.StartsAt.Format(time.RFC3339), e.ErrAuctionStarted).Error()
+	}
+	params.CurrentStatus = from
+
 	bid, err := b.db.UpdateBidStatus(ctx, bidId, params)
 	if err != nil {
 		return models.Bid{}, err
 	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
 	return *bid, nil
 }
 
@@ -81,10 +242,62 @@ func (b *BidServiceImpl) EditBid(ctx context.Context, bidId repos.BidId, usernam
 	if err := validateEditBid(params); err != nil {
 		return models.Bid{}, err.Error()
 	}
+	current, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	if status := repos.BidStatus(current.Status); !isBidEditable(status) {
+		return models.Bid{}, e.New("bid is "+string(status), e.ErrBidNotEditable).Error()
+	}
+	tender, err := b.checkTenderBiddable(ctx, current.TenderId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	// На торгах цена снижается только через подачу цены, где проверяется шаг
+	if params.Price != nil && isAuctionStarted(tender, time.Now()) {
+		return models.Bid{}, e.New("auction started at "+tender.Auction.StartsAt.Format(time.RFC3339), e.ErrAuctionStarted).Error()
+	}
+	// Прежняя цена могла оказаться выше сниженного бюджета, но новую цену проверяем всегда
+	if err := checkWithinBudget(params.Price, tender.Budget); err != nil {
+		return models.Bid{}, err.Error()
+	}
+	if tender.Sealed {
+		// Шифруется все содержимое целиком, поэтому незаданные поля берем из текущего содержимого
+		if err := b.sealer.openBid(current); err != nil {
+			return models.Bid{}, err
+		}
+		content := models.SealedBidContent{
+			Name:         current.Name,
+			Description:  current.Description,
+			Price:        current.Price,
+			DeliveryDays: current.DeliveryDays,
+		}
+		if params.Name != nil {
+			content.Name = *params.Name
+		}
+		if params.Description != nil {
+			content.Description = *params.Description
+		}
+		if params.Price != nil {
+			content.Price = params.Price
+		}
+		if params.DeliveryDays != nil {
+			content.DeliveryDays = params.DeliveryDays
+		}
+		payload, err := b.sealer.seal(tender.Id, content)
+		if err != nil {
+			return models.Bid{}, err
+		}
+		empty := ""
+		params.Name, params.Description, params.Price, params.DeliveryDays, params.SealedPayload = &empty, &empty, nil, nil, payload
+	}
 	bid, err := b.db.EditBid(ctx, bidId, username, params)
 	if err != nil {
 		return models.Bid{}, err
 	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
 	return *bid, nil
 }
 
@@ -93,6 +306,19 @@ func (b *BidServiceImpl) SubmitBidDecision(ctx context.Context, bidId repos.BidI
 	if err := validateSubmitBidDecision(params); err != nil {
 		return models.Bid{}, err.Error()
 	}
+
+	current, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	if err := b.checkTenderDecidable(ctx, current.TenderId); err != nil {
+		return models.Bid{}, err
+	}
+	// Решение принимается только по опубликованным предложениям,
+	// повторное решение по уже принятому/отклоненному отсекает БД
+	if status := repos.BidStatus(current.Status); status != repos.BidStatusPublished && !isBidDecisionStatus(status) {
+		return models.Bid{}, e.New("bid is "+string(status), e.ErrBidNotPublished).Error()
+	}
 	bid, err := b.db.SubmitBidDecision(ctx, bidId, params)
 	if err != nil {
 		return models.Bid{}, err
@@ -100,25 +326,56 @@ func (b *BidServiceImpl) SubmitBidDecision(ctx context.Context, bidId repos.BidI
 	return *bid, nil
 }
 
-func (b *BidServiceImpl) SubmitBidFeedback(ctx context.Context, bidId repos.BidId, params repos.SubmitBidFeedbackParams) (models.Bid, error) {
-	if err := validateSubmitBidFeedback(params); err != nil {
-		return models.Bid{}, err.Error()
+// GetBidDecisions голоса ответственных: повторно проголосовать нельзя, а посмотреть, как идет голосование, нужно
+func (b *BidServiceImpl) GetBidDecisions(ctx context.Context, bidId repos.BidId, params repos.GetBidDecisionsParams) (models.BidDecisionTally, error) {
+	if err := validateGetBidDecisions(params); err != nil {
+		return models.BidDecisionTally{}, err.Error()
 	}
-	bid, err := b.db.SubmitBidFeedback(ctx, bidId, params)
+	tally, err := b.db.GetBidDecisions(ctx, bidId, params.Username)
 	if err != nil {
-		return models.Bid{}, err
+		return models.BidDecisionTally{}, err
 	}
-	return *bid, nil
+	return *tally, nil
 }
 
 func (b *BidServiceImpl) RollbackBid(ctx context.Context, bidId repos.BidId, version int32, params repos.RollbackBidParams) (models.Bid, error) {
 	if err := validateRollbackBid(params); err != nil {
 		return models.Bid{}, err.Error()
 	}
+	current, err := b.db.GetBidByID(ctx, bidId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	// Откат - та же правка содержимого, поэтому ограничения те же, что у EditBid
+	if status := repos.BidStatus(current.Status); !isBidEditable(status) {
+		return models.Bid{}, e.New("bid is "+string(status), e.ErrBidNotEditable).Error()
+	}
+	tender, err := b.checkTenderBiddable(ctx, current.TenderId)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	// Откат восстановил бы цену в обход шага торгов
+	if isAuctionStarted(tender, time.Now()) {
+		return models.Bid{}, e.New("auction started at "+tender.Auction.StartsAt.Format(time.RFC3339), e.ErrAuctionStarted).Error()
+	}
+	// Цена версии могла быть выше нынешнего бюджета или в другой валюте
+	target, err := b.db.GetBidVersion(ctx, bidId, version, params.Username)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	if err := b.openVersions(ctx, bidId, target); err != nil {
+		return models.Bid{}, err
+	}
+	if err := checkWithinBudget(target.Price, tender.Budget); err != nil {
+		return models.Bid{}, err.Error()
+	}
 	bid, err := b.db.RollbackBid(ctx, bidId, version, params)
 	if err != nil {
 		return models.Bid{}, err
 	}
+	if err := b.sealer.openBid(bid); err != nil {
+		return models.Bid{}, err
+	}
 	return *bid, nil
 }
 
@@ -132,3 +389,135 @@ func (b *BidServiceImpl) GetBidReviews(ctx context.Context, tenderId repos.Tende
 	}
 	return reviews, nil
 }
+
+func (b *BidServiceImpl) GetBidVersions(ctx context.Context, bidId repos.BidId, params repos.GetBidVersionsParams) ([]*models.BidVersionInfo, error) {
+	if err := validateGetBidVersions(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	params.Limit, params.Offset = &limit, &offset
+	versions, err := b.db.GetBidVersions(ctx, bidId, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.openVersions(ctx, bidId, versions...); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (b *BidServiceImpl) DiffBidVersions(ctx context.Context, bidId repos.BidId, from, to int32, params repos.DiffBidVersionsParams) (models.VersionDiff, error) {
+	if err := validateDiffBidVersions(params); err != nil {
+		return models.VersionDiff{}, err.Error()
+	}
+
+	fromVersion, err := b.db.GetBidVersion(ctx, bidId, from, params.Username)
+	if err != nil {
+		return models.VersionDiff{}, err
+	}
+
+	toVersion, err := b.db.GetBidVersion(ctx, bidId, to, params.Username)
+	if err != nil {
+		return models.VersionDiff{}, err
+	}
+
+	if err := b.openVersions(ctx, bidId, fromVersion, toVersion); err != nil {
+		return models.VersionDiff{}, err
+	}
+
+	return models.VersionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffVersionFields(bidVersionFields(fromVersion), bidVersionFields(toVersion)),
+	}, nil
+}
+
+func (b *BidServiceImpl) GetBidRanking(ctx context.Context, tenderId repos.TenderId, params repos.GetBidRankingParams) (models.BidRanking, error) {
+	if err := validateGetBidRanking(params); err != nil {
+		return models.BidRanking{}, err.Error()
+	}
+	tender, err := b.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.BidRanking{}, err
+	}
+	if err := b.sealer.checkRevealed(ctx, tender); err != nil {
+		return models.BidRanking{}, err
+	}
+	bids, err := b.db.GetBidsForRanking(ctx, tenderId, params.Username)
+	if err != nil {
+		return models.BidRanking{}, err
+	}
+	return rankBids(tender, bids), nil
+}
+
+// checkTenderBiddable не дает создавать и редактировать предложения по тендерам,
+// которые не принимают предложения (не опубликованы, закрыты, отменены или вне срока приема)
+func (b *BidServiceImpl) checkTenderBiddable(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
+	tender, err := b.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isTenderBiddable(repos.TenderStatus(tender.Status)) {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrTenderNotBiddable).Error()
+	}
+	// Планировщик закрывает просроченные тендеры с задержкой до одного тика, поэтому срок проверяем и здесь
+	if err := checkBidWindow(tender.BidsOpenAt, tender.BidsCloseAt, time.Now()); err != nil {
+		return nil, err.Error()
+	}
+	return tender, nil
+}
+
+// checkTenderDecidable решения по обычному тендеру принимаются, пока он не закрыт и не отменен.
+// Запечатанный тендер, наоборот, решается после закрытия, когда предложения раскрыты
+func (b *BidServiceImpl) checkTenderDecidable(ctx context.Context, tenderId repos.TenderId) error {
+	tender, err := b.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if !tender.Sealed {
+		if isTenderFinished(repos.TenderStatus(tender.Status)) {
+			return e.New("tender is "+string(tender.Status), e.ErrTenderClosed).Error()
+		}
+		return nil
+	}
+	if repos.TenderStatus(tender.Status) == repos.TenderStatusCanceled {
+		return e.New("tender is "+string(tender.Status), e.ErrTenderClosed).Error()
+	}
+	return b.sealer.checkRevealed(ctx, tender)
+}
+
+// openVersions расшифровывает запечатанные версии предложения. Версии видит только автор
+func (b *BidServiceImpl) openVersions(ctx context.Context, bidId repos.BidId, versions ...*models.BidVersionInfo) error {
+	var tenderId repos.TenderId
+	for _, version := range versions {
+		if version.SealedPayload == nil {
+			continue
+		}
+		if tenderId == "" {
+			bid, err := b.db.GetBidByID(ctx, bidId)
+			if err != nil {
+				return err
+			}
+			tenderId = bid.TenderId
+		}
+		if err := b.sealer.openVersion(tenderId, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTenderNotFinished не дает менять предложения по закрытым и отмененным тендерам
+func (b *BidServiceImpl) checkTenderNotFinished(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
+	tender, err := b.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if isTenderFinished(repos.TenderStatus(tender.Status)) {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrTenderClosed).Error()
+	}
+	return tender, nil
+}
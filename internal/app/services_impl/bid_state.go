@@ -0,0 +1,54 @@
+package servicesimpl
+
+import "github.com/0x0FACED/tender-service/internal/app/domain/repos"
+
+// Жизненный цикл предложения.
+//
+// Действия автора (PUT /api/bids/:bidId/status):
+//
+//	Created -> Published
+//	Created -> Canceled
+//	Published -> Canceled
+//
+// Решения владельца тендера (только через PUT /api/bids/:bidId/submit_decision):
+//
+//	Published -> Approved
+//	Published -> Rejected
+var bidAuthorTransitions = map[repos.BidStatus][]repos.BidStatus{
+	repos.BidStatusCreated:   {repos.BidStatusPublished, repos.BidStatusCanceled},
+	repos.BidStatusPublished: {repos.BidStatusCanceled},
+}
+
+func isKnownBidStatus(status repos.BidStatus) bool {
+	switch status {
+	case repos.BidStatusCreated, repos.BidStatusPublished, repos.BidStatusCanceled, repos.BidStatusApproved, repos.BidStatusRejected:
+		return true
+	}
+	return false
+}
+
+// isBidDecisionStatus статусы, которые выставляются только решением владельца тендера
+func isBidDecisionStatus(status repos.BidStatus) bool {
+	return status == repos.BidStatusApproved || status == repos.BidStatusRejected
+}
+
+// isBidEditable содержимое меняется только у действующих предложений: отмененное или решенное
+// предложение правкой или откатом вернулось бы в игру в обход жизненного цикла
+func isBidEditable(status repos.BidStatus) bool {
+	return status == repos.BidStatusCreated || status == repos.BidStatusPublished
+}
+
+// canTransitBidByAuthor проверяет, может ли автор перевести предложение из статуса from в статус to
+func canTransitBidByAuthor(from, to repos.BidStatus) bool {
+	for _, allowed := range bidAuthorTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// isTenderFinished по закрытым и отмененным тендерам предложения больше не меняются
+func isTenderFinished(status repos.TenderStatus) bool {
+	return status == repos.TenderStatusClosed || status == repos.TenderStatusCanceled
+}
@@ -39,7 +39,14 @@ func validateCreateBid(params repos.CreateBidParams) *e.ServiceError {
 		return err
 	}
 
-	return nil
+	// Цена необязательна, но если указана, то в корректном формате
+	if params.Price != nil {
+		if err := validateMoney("price", params.Price, false); err != nil {
+			return err
+		}
+	}
+
+	return validateDeliveryDays(params.DeliveryDays)
 }
 
 func validateGetUserBids(params repos.GetUserBidsParams) *e.ServiceError {
@@ -57,6 +64,15 @@ func validateGetBidsForTender(params repos.GetBidsForTenderParams) *e.ServiceErr
 		return err
 	}
 
+	return validateSearchQuery(params.Q)
+}
+
+func validateGetBid(params repos.GetBidParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
 	return nil
 }
 
@@ -71,13 +87,14 @@ func validateGetBidStatus(params repos.GetBidStatusParams) *e.ServiceError {
 }
 
 func validateUpdateBidStatus(params repos.UpdateBidStatusParams) *e.ServiceError {
-	if params.Status == "Created" {
-		err := e.New("already exists status", e.ErrAlreadyExists)
+	if !isKnownBidStatus(params.Status) {
+		err := e.New("unknown status", e.ErrUnknownStatus)
 		return err
 	}
 
-	if params.Status != "Published" && params.Status != "Canceled" && params.Status != "Approved" && params.Status != "Rejected" {
-		err := e.New("unknown status", e.ErrUnknownStatus)
+	// Approved и Rejected выставляются только через принятие решения
+	if isBidDecisionStatus(params.Status) {
+		err := e.New("use submit_decision to approve or reject", e.ErrBidDecisionRequired)
 		return err
 	}
 
@@ -90,16 +107,41 @@ func validateUpdateBidStatus(params repos.UpdateBidStatusParams) *e.ServiceError
 }
 
 func validateEditBid(params repos.EditBidParams) *e.ServiceError {
-	if len(*params.Name) > MAX_BID_NAME_SIZE {
+	// Можно менять только цену или срок, поэтому имя и описание тоже необязательны
+	if params.Name != nil && len(*params.Name) > MAX_BID_NAME_SIZE {
 		err := e.New("name length exceeded", e.ErrExceededLength)
 		return err
 	}
 
-	if len(*params.Description) > MAX_BID_DESCRIPTION_SIZE {
+	if params.Description != nil && len(*params.Description) > MAX_BID_DESCRIPTION_SIZE {
 		err := e.New("desc length exceeded", e.ErrExceededLength)
 		return err
 	}
 
+	if params.Price != nil {
+		if err := validateMoney("price", params.Price, false); err != nil {
+			return err
+		}
+	}
+
+	return validateDeliveryDays(params.DeliveryDays)
+}
+
+func validateGetBidRanking(params repos.GetBidRankingParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	return nil
+}
+
+func validateGetBidDecisions(params repos.GetBidDecisionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
 	return nil
 }
 
@@ -118,7 +160,7 @@ func validateSubmitBidDecision(params repos.SubmitBidDecisionParams) *e.ServiceE
 }
 
 func validateSubmitBidFeedback(params repos.SubmitBidFeedbackParams) *e.ServiceError {
-	if len(params.BidFeedback) > 1000 {
+	if len(params.BidFeedback) > MAX_FEEDBACK_SIZE {
 		err := e.New("feedback length exceeded", e.ErrExceededLength)
 		return err
 	}
@@ -128,6 +170,82 @@ func validateSubmitBidFeedback(params repos.SubmitBidFeedbackParams) *e.ServiceE
 		return err
 	}
 
+	return validateFeedbackRating(params.Rating)
+}
+
+func validateFeedbackRating(rating *int32) *e.ServiceError {
+	if rating != nil && (*rating < MIN_FEEDBACK_RATING || *rating > MAX_FEEDBACK_RATING) {
+		err := e.New("rating must be between 1 and 5", e.ErrInvalidFeedbackRating)
+		return err
+	}
+	return nil
+}
+
+func validateReplyBidFeedback(params repos.ReplyBidFeedbackParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Description == "" {
+		err := e.New("empty reply", e.ErrEmpty)
+		return err
+	}
+
+	if len(params.Description) > MAX_FEEDBACK_SIZE {
+		err := e.New("reply length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	return nil
+}
+
+func validateEditBidFeedback(params repos.EditBidFeedbackParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Description == nil && params.Rating == nil {
+		err := e.New("nothing to edit", e.ErrEmpty)
+		return err
+	}
+
+	if params.Description != nil {
+		if *params.Description == "" {
+			err := e.New("empty feedback", e.ErrEmpty)
+			return err
+		}
+		if len(*params.Description) > MAX_FEEDBACK_SIZE {
+			err := e.New("feedback length exceeded", e.ErrExceededLength)
+			return err
+		}
+	}
+
+	return validateFeedbackRating(params.Rating)
+}
+
+func validateDeleteBidFeedback(params repos.DeleteBidFeedbackParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetBidFeedback(params repos.GetBidFeedbackParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetBidFeedbackVersions(params repos.GetBidFeedbackVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
 	return nil
 }
 
@@ -153,3 +271,21 @@ func validateGetBidReviews(params repos.GetBidReviewsParams) *e.ServiceError {
 
 	return nil
 }
+
+func validateGetBidVersions(params repos.GetBidVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	return nil
+}
+
+func validateDiffBidVersions(params repos.DiffBidVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+type EmployeeServiceImpl struct {
+	db database.EmployeeRepository
+}
+
+func NewEmployeeService(db database.EmployeeRepository) repos.EmployeeService {
+	return &EmployeeServiceImpl{
+		db: db,
+	}
+}
+
+func (s *EmployeeServiceImpl) GetEmployees(ctx context.Context, params repos.GetEmployeesParams) ([]*models.Employee, error) {
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	params.Limit, params.Offset = &limit, &offset
+	return s.db.GetEmployees(ctx, params)
+}
+
+func (s *EmployeeServiceImpl) GetEmployee(ctx context.Context, employee repos.Username) (models.Employee, error) {
+	emp, err := s.db.GetEmployee(ctx, employee)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return *emp, nil
+}
+
+func (s *EmployeeServiceImpl) CreateEmployee(ctx context.Context, params repos.CreateEmployeeParams) (models.Employee, error) {
+	if err := validateCreateEmployee(params); err != nil {
+		return models.Employee{}, err.Error()
+	}
+	emp, err := s.db.CreateEmployee(ctx, params)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return *emp, nil
+}
+
+func (s *EmployeeServiceImpl) EditEmployee(ctx context.Context, employee repos.Username, params repos.EditEmployeeParams) (models.Employee, error) {
+	if err := validateEditEmployee(params); err != nil {
+		return models.Employee{}, err.Error()
+	}
+	// Профиль меняет только его владелец
+	if params.Username != employee {
+		return models.Employee{}, e.New("only owner can edit employee profile", e.ErrNotProfileOwner).Error()
+	}
+	emp, err := s.db.EditEmployee(ctx, employee, params)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return *emp, nil
+}
+
+func (s *EmployeeServiceImpl) DeleteEmployee(ctx context.Context, employee repos.Username, params repos.DeleteEmployeeParams) error {
+	if err := validateDeleteEmployee(params); err != nil {
+		return err.Error()
+	}
+	if params.Username != employee {
+		return e.New("only owner can delete employee profile", e.ErrNotProfileOwner).Error()
+	}
+	return s.db.DeleteEmployee(ctx, employee)
+}
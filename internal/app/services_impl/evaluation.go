@@ -0,0 +1,160 @@
+package servicesimpl
+
+import (
+	"math"
+	"sort"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// evaluateBids считает взвешенные оценки предложений и упорядочивает их по убыванию.
+// Каждый критерий приводится к диапазону [0, 1]:
+//   - Manual: средняя оценка ответственных, деленная на максимальную;
+//   - Price: минимальная цена, деленная на цену предложения. Цены сравниваются только в валюте оценки,
+//     предложение в другой валюте получает 0 и неполную оценку;
+//   - DeliveryTime: минимальный срок поставки, деленный на срок предложения.
+//
+// Итог - сумма нормированных оценок с весами, деленная на сумму весов, в процентах.
+// Критерий без оценок или без данных в предложении дает 0 и делает оценку неполной
+func evaluateBids(tender *models.Tender, criteria []*models.EvaluationCriterion, bids []*models.Bid, scores []*models.BidScore) models.TenderEvaluation {
+	// Суммы оценок: предложение -> критерий
+	type scoreSum struct {
+		total int64
+		count int
+	}
+	sums := make(map[models.BidId]map[models.CriterionId]*scoreSum)
+	for _, s := range scores {
+		byCriterion, ok := sums[s.BidId]
+		if !ok {
+			byCriterion = make(map[models.CriterionId]*scoreSum)
+			sums[s.BidId] = byCriterion
+		}
+		sum, ok := byCriterion[s.CriterionId]
+		if !ok {
+			sum = &scoreSum{}
+			byCriterion[s.CriterionId] = sum
+		}
+		sum.total += int64(s.Score)
+		sum.count++
+	}
+
+	currency := evaluationCurrency(tender, bids)
+	var minPrice int64
+	var hasPrice bool
+	var minDays int32
+	for _, bid := range bids {
+		if bid.Price != nil && bid.Price.Currency == currency {
+			if cents := moneyCents(bid.Price.Amount); !hasPrice || cents < minPrice {
+				minPrice, hasPrice = cents, true
+			}
+		}
+		if bid.DeliveryDays != nil && (minDays == 0 || *bid.DeliveryDays < minDays) {
+			minDays = *bid.DeliveryDays
+		}
+	}
+
+	var weights int64
+	for _, c := range criteria {
+		weights += int64(c.Weight)
+	}
+
+	items := make([]models.BidEvaluation, 0, len(bids))
+	for _, bid := range bids {
+		item := models.BidEvaluation{
+			Bid:      bid,
+			Complete: true,
+			Criteria: make([]models.CriterionEvaluation, 0, len(criteria)),
+		}
+
+		var weighted float64
+		for _, c := range criteria {
+			ce := models.CriterionEvaluation{CriterionId: c.Id}
+
+			switch c.Kind {
+			case models.CriterionKindManual:
+				if sum := sums[bid.Id][c.Id]; sum != nil {
+					avg := float64(sum.total) / float64(sum.count)
+					ce.AverageScore = &avg
+					ce.Evaluations = sum.count
+					ce.Normalized = avg / float64(c.MaxScore)
+				} else {
+					item.Complete = false
+				}
+			case models.CriterionKindPrice:
+				if bid.Price != nil && bid.Price.Currency == currency {
+					ce.Normalized = ratioToBest(minPrice, moneyCents(bid.Price.Amount))
+				} else {
+					item.Complete = false
+				}
+			case models.CriterionKindDeliveryTime:
+				if bid.DeliveryDays != nil {
+					ce.Normalized = ratioToBest(int64(minDays), int64(*bid.DeliveryDays))
+				} else {
+					item.Complete = false
+				}
+			}
+
+			ce.Normalized = roundTo(ce.Normalized, 4)
+			weighted += ce.Normalized * float64(c.Weight)
+			item.Criteria = append(item.Criteria, ce)
+		}
+
+		if weights > 0 {
+			item.Total = roundTo(100*weighted/float64(weights), 2)
+		}
+		items = append(items, item)
+	}
+
+	// Порядок БД (по цене) сохраняется среди равных оценок
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Total > items[j].Total
+	})
+	for i := range items {
+		items[i].Rank = i + 1
+		if i > 0 && items[i].Total == items[i-1].Total {
+			items[i].Rank = items[i-1].Rank
+		}
+	}
+
+	return models.TenderEvaluation{
+		TenderId: tender.Id,
+		Criteria: criteria,
+		Currency: currency,
+		Items:    items,
+	}
+}
+
+// evaluationCurrency валюта бюджета, без бюджета - самая частая валюта предложений.
+// При равенстве выбирается первая по алфавиту, чтобы результат не зависел от порядка предложений
+func evaluationCurrency(tender *models.Tender, bids []*models.Bid) string {
+	if tender.Budget != nil {
+		return tender.Budget.Currency
+	}
+
+	counts := make(map[string]int)
+	var currency string
+	for _, bid := range bids {
+		if bid.Price == nil {
+			continue
+		}
+		c := bid.Price.Currency
+		counts[c]++
+		if currency == "" || counts[c] > counts[currency] || (counts[c] == counts[currency] && c < currency) {
+			currency = c
+		}
+	}
+	return currency
+}
+
+// ratioToBest лучшее (минимальное) значение получает 1, остальные - долю от него
+func ratioToBest(best, value int64) float64 {
+	if value <= 0 {
+		return 1
+	}
+	return float64(best) / float64(value)
+}
+
+func roundTo(v float64, digits int) float64 {
+	p := math.Pow10(digits)
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,138 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+type EvaluationServiceImpl struct {
+	db      database.EvaluationRepository
+	tenders database.TenderRepository
+	bids    database.BidRepository
+	users   database.UserRepository
+	sealer  *BidSealer
+}
+
+func NewEvaluationService(
+	db database.EvaluationRepository,
+	tenders database.TenderRepository,
+	bids database.BidRepository,
+	users database.UserRepository,
+	sealer *BidSealer,
+) repos.EvaluationService {
+	return &EvaluationServiceImpl{
+		db:      db,
+		tenders: tenders,
+		bids:    bids,
+		users:   users,
+		sealer:  sealer,
+	}
+}
+
+func (s *EvaluationServiceImpl) SetTenderCriteria(ctx context.Context, tenderId repos.TenderId, params repos.SetTenderCriteriaParams) ([]*models.EvaluationCriterion, error) {
+	if err := validateSetTenderCriteria(&params); err != nil {
+		return nil, err.Error()
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isTenderEditable(repos.TenderStatus(tender.Status)) {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrTenderNotEditable).Error()
+	}
+	return s.db.ReplaceTenderCriteria(ctx, tenderId, params.Username, params.Criteria)
+}
+
+func (s *EvaluationServiceImpl) GetTenderCriteria(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderCriteriaParams) ([]*models.EvaluationCriterion, error) {
+	if err := validateGetTenderCriteria(params); err != nil {
+		return nil, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return nil, err
+	}
+	return s.db.GetTenderCriteria(ctx, tenderId)
+}
+
+func (s *EvaluationServiceImpl) SubmitBidScores(ctx context.Context, bidId repos.BidId, params repos.SubmitBidScoresParams) ([]*models.BidScore, error) {
+	if err := validateSubmitBidScores(params); err != nil {
+		return nil, err.Error()
+	}
+	bid, err := s.bids.GetBidByID(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if repos.BidStatus(bid.Status) != repos.BidStatusPublished {
+		return nil, e.New("bid is "+string(bid.Status), e.ErrBidNotPublished).Error()
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, bid.TenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isTenderEvaluable(repos.TenderStatus(tender.Status)) {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrTenderNotEvaluable).Error()
+	}
+	if err := s.sealer.checkRevealed(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.db.GetTenderCriteria(ctx, bid.TenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScoresAgainstCriteria(params.Scores, criteria); err != nil {
+		return nil, err.Error()
+	}
+	return s.db.UpsertBidScores(ctx, bidId, params.Username, params.Scores)
+}
+
+func (s *EvaluationServiceImpl) GetBidScores(ctx context.Context, bidId repos.BidId, params repos.GetBidScoresParams) ([]*models.BidScore, error) {
+	if err := validateGetBidScores(params); err != nil {
+		return nil, err.Error()
+	}
+	return s.db.GetBidScores(ctx, bidId, params.Username)
+}
+
+func (s *EvaluationServiceImpl) GetTenderEvaluation(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderEvaluationParams) (models.TenderEvaluation, error) {
+	if err := validateGetTenderEvaluation(params); err != nil {
+		return models.TenderEvaluation{}, err.Error()
+	}
+
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.TenderEvaluation{}, err
+	}
+	if err := s.sealer.checkRevealed(ctx, tender); err != nil {
+		return models.TenderEvaluation{}, err
+	}
+
+	// Права проверяет выборка предложений: итоги видят только ответственные за тендер
+	bids, err := s.bids.GetBidsForRanking(ctx, tenderId, params.Username)
+	if err != nil {
+		return models.TenderEvaluation{}, err
+	}
+	criteria, err := s.db.GetTenderCriteria(ctx, tenderId)
+	if err != nil {
+		return models.TenderEvaluation{}, err
+	}
+	if len(criteria) == 0 {
+		return models.TenderEvaluation{}, e.New("tender has no criteria", e.ErrNoCriteria).Error()
+	}
+	scores, err := s.db.GetTenderScores(ctx, tenderId, params.Username)
+	if err != nil {
+		return models.TenderEvaluation{}, err
+	}
+
+	return evaluateBids(tender, criteria, bids, scores), nil
+}
@@ -0,0 +1,173 @@
+package servicesimpl
+
+import (
+	"strconv"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+var (
+	MAX_CRITERIA_COUNT                   = 20
+	MAX_CRITERION_NAME_SIZE              = 100
+	MAX_CRITERION_DESCRIPTION_SIZE       = 500
+	MAX_CRITERION_WEIGHT           int32 = 100
+	MAX_CRITERION_SCORE            int32 = 100
+	DEFAULT_CRITERION_SCORE        int32 = 10
+	MAX_SCORE_COMMENT_SIZE               = 1000
+)
+
+// validateSetTenderCriteria проверяет набор критериев и заполняет значения по умолчанию
+func validateSetTenderCriteria(params *repos.SetTenderCriteriaParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if len(params.Criteria) > MAX_CRITERIA_COUNT {
+		err := e.New("too many criteria, max "+strconv.Itoa(MAX_CRITERIA_COUNT), e.ErrInvalidCriteria)
+		return err
+	}
+
+	names := make(map[string]bool, len(params.Criteria))
+	calculated := make(map[models.CriterionKind]bool)
+	for i := range params.Criteria {
+		c := &params.Criteria[i]
+
+		if c.Name == "" {
+			err := e.New("empty criterion name", e.ErrEmpty)
+			return err
+		}
+		if len(c.Name) > MAX_CRITERION_NAME_SIZE {
+			err := e.New("criterion name length exceeded", e.ErrExceededLength)
+			return err
+		}
+		if names[c.Name] {
+			err := e.New("duplicate criterion "+c.Name, e.ErrInvalidCriteria)
+			return err
+		}
+		names[c.Name] = true
+
+		if c.Description != nil && len(*c.Description) > MAX_CRITERION_DESCRIPTION_SIZE {
+			err := e.New("criterion desc length exceeded", e.ErrExceededLength)
+			return err
+		}
+
+		if c.Kind == nil {
+			kind := models.CriterionKindManual
+			c.Kind = &kind
+		}
+		switch *c.Kind {
+		case models.CriterionKindManual:
+		case models.CriterionKindPrice, models.CriterionKindDeliveryTime:
+			// Цена и срок у предложения одни, второй такой критерий удвоил бы их вес незаметно для читателя
+			if calculated[*c.Kind] {
+				err := e.New("duplicate "+string(*c.Kind)+" criterion", e.ErrInvalidCriteria)
+				return err
+			}
+			calculated[*c.Kind] = true
+		default:
+			err := e.New("unknown criterion kind "+string(*c.Kind), e.ErrInvalidCriteria)
+			return err
+		}
+
+		if c.Weight < 1 || c.Weight > MAX_CRITERION_WEIGHT {
+			err := e.New(c.Name+": weight must be between 1 and "+strconv.Itoa(int(MAX_CRITERION_WEIGHT)), e.ErrInvalidCriteria)
+			return err
+		}
+
+		if c.MaxScore == nil {
+			maxScore := DEFAULT_CRITERION_SCORE
+			c.MaxScore = &maxScore
+		}
+		if *c.MaxScore < 1 || *c.MaxScore > MAX_CRITERION_SCORE {
+			err := e.New(c.Name+": maxScore must be between 1 and "+strconv.Itoa(int(MAX_CRITERION_SCORE)), e.ErrInvalidCriteria)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateGetTenderCriteria(params repos.GetTenderCriteriaParams) *e.ServiceError {
+	if params.Username != nil && *params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateSubmitBidScores(params repos.SubmitBidScoresParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if len(params.Scores) == 0 {
+		err := e.New("empty scores", e.ErrEmpty)
+		return err
+	}
+
+	seen := make(map[repos.CriterionId]bool, len(params.Scores))
+	for _, s := range params.Scores {
+		if s.CriterionId == "" {
+			err := e.New("empty criterion id", e.ErrEmpty)
+			return err
+		}
+		if seen[s.CriterionId] {
+			err := e.New("duplicate score for criterion "+s.CriterionId, e.ErrInvalidScore)
+			return err
+		}
+		seen[s.CriterionId] = true
+
+		if s.Comment != nil && len(*s.Comment) > MAX_SCORE_COMMENT_SIZE {
+			err := e.New("comment length exceeded", e.ErrExceededLength)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkScoresAgainstCriteria оценки ставятся только по ручным критериям тендера в пределах их шкалы.
+// Неизвестные критерии отсекает БД
+func checkScoresAgainstCriteria(scores []repos.ScoreParams, criteria []*models.EvaluationCriterion) *e.ServiceError {
+	byId := make(map[models.CriterionId]*models.EvaluationCriterion, len(criteria))
+	for _, c := range criteria {
+		byId[c.Id] = c
+	}
+
+	for _, s := range scores {
+		c, ok := byId[s.CriterionId]
+		if !ok {
+			continue
+		}
+		if c.Kind != models.CriterionKindManual {
+			err := e.New(c.Name+" is calculated from the bid", e.ErrCriterionNotScorable)
+			return err
+		}
+		if s.Score < 0 || s.Score > c.MaxScore {
+			err := e.New(c.Name+": score must be between 0 and "+strconv.Itoa(int(c.MaxScore)), e.ErrInvalidScore)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateGetBidScores(params repos.GetBidScoresParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetTenderEvaluation(params repos.GetTenderEvaluationParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
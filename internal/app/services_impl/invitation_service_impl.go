@@ -0,0 +1,101 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+type InvitationServiceImpl struct {
+	db      database.InvitationRepository
+	tenders database.TenderRepository
+	users   database.UserRepository
+}
+
+func NewInvitationService(db database.InvitationRepository, tenders database.TenderRepository, users database.UserRepository) repos.InvitationService {
+	return &InvitationServiceImpl{
+		db:      db,
+		tenders: tenders,
+		users:   users,
+	}
+}
+
+func (s *InvitationServiceImpl) InviteToTender(ctx context.Context, tenderId repos.TenderId, params repos.InviteToTenderParams) (models.TenderInvitation, error) {
+	if err := validateInviteToTender(params); err != nil {
+		return models.TenderInvitation{}, err.Error()
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.TenderInvitation{}, err
+	}
+	if tender.Access != models.TenderAccessInviteOnly {
+		return models.TenderInvitation{}, e.New("tender access is "+string(tender.Access), e.ErrTenderNotInviteOnly).Error()
+	}
+	if isTenderFinished(repos.TenderStatus(tender.Status)) {
+		return models.TenderInvitation{}, e.New("tender is "+string(tender.Status), e.ErrTenderClosed).Error()
+	}
+	invitation, err := s.db.CreateTenderInvitation(ctx, tenderId, params.Username, params.OrganizationId, params.EmployeeUsername)
+	if err != nil {
+		return models.TenderInvitation{}, err
+	}
+	return *invitation, nil
+}
+
+func (s *InvitationServiceImpl) GetTenderInvitations(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderInvitationsParams) ([]*models.TenderInvitation, error) {
+	if err := validateGetTenderInvitations(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	return s.db.GetTenderInvitations(ctx, tenderId, params.Username, limit, offset)
+}
+
+// RevokeTenderInvitation отзывает приглашение. Поданные по нему предложения остаются у авторов
+func (s *InvitationServiceImpl) RevokeTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, params repos.RevokeTenderInvitationParams) error {
+	if err := validateRevokeTenderInvitation(params); err != nil {
+		return err.Error()
+	}
+	return s.db.DeleteTenderInvitation(ctx, tenderId, invitationId, params.Username)
+}
+
+func (s *InvitationServiceImpl) GetUserInvitations(ctx context.Context, params repos.GetUserInvitationsParams) ([]*models.TenderInvitation, error) {
+	if err := validateGetUserInvitations(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	userId, err := s.users.GetUserIDByUsername(ctx, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.GetUserInvitations(ctx, userId, params.Status, limit, offset)
+}
+
+func (s *InvitationServiceImpl) RespondTenderInvitation(ctx context.Context, tenderId repos.TenderId, invitationId repos.InvitationId, params repos.RespondTenderInvitationParams) (models.TenderInvitation, error) {
+	if err := validateRespondTenderInvitation(params); err != nil {
+		return models.TenderInvitation{}, err.Error()
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.TenderInvitation{}, err
+	}
+	if isTenderFinished(repos.TenderStatus(tender.Status)) {
+		return models.TenderInvitation{}, e.New("tender is "+string(tender.Status), e.ErrTenderClosed).Error()
+	}
+	userId, err := s.users.GetUserIDByUsername(ctx, params.Username)
+	if err != nil {
+		return models.TenderInvitation{}, err
+	}
+	invitation, err := s.db.RespondTenderInvitation(ctx, tenderId, invitationId, userId, params.Status)
+	if err != nil {
+		return models.TenderInvitation{}, err
+	}
+	return *invitation, nil
+}
@@ -0,0 +1,76 @@
+package servicesimpl
+
+import (
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+func validateInviteToTender(params repos.InviteToTenderParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	hasOrganization := params.OrganizationId != nil && *params.OrganizationId != ""
+	hasEmployee := params.EmployeeUsername != nil && *params.EmployeeUsername != ""
+	if hasOrganization == hasEmployee {
+		err := e.New("exactly one of organizationId and employeeUsername is required", e.ErrInvalidInvitation)
+		return err
+	}
+
+	return nil
+}
+
+func validateGetTenderInvitations(params repos.GetTenderInvitationsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateRevokeTenderInvitation(params repos.RevokeTenderInvitationParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetUserInvitations(params repos.GetUserInvitationsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Status != nil && !isKnownInvitationStatus(*params.Status) {
+		err := e.New("unknown invitation status "+string(*params.Status), e.ErrUnknownStatus)
+		return err
+	}
+
+	return nil
+}
+
+// validateRespondTenderInvitation на приглашение можно только ответить, вернуть его в Pending нельзя
+func validateRespondTenderInvitation(params repos.RespondTenderInvitationParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Status != models.InvitationStatusAccepted && params.Status != models.InvitationStatusDeclined {
+		err := e.New("invitation status must be Accepted or Declined", e.ErrUnknownStatus)
+		return err
+	}
+
+	return nil
+}
+
+func isKnownInvitationStatus(status repos.InvitationStatus) bool {
+	switch status {
+	case models.InvitationStatusPending, models.InvitationStatusAccepted, models.InvitationStatusDeclined:
+		return true
+	}
+	return false
+}
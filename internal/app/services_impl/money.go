@@ -0,0 +1,79 @@
+package servicesimpl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+var (
+	// Сумма хранится как NUMERIC(18, 2): до 16 цифр целой части и до 2 знаков после точки
+	moneyAmountRe   = regexp.MustCompile(`^\d{1,16}(\.\d{1,2})?$`)
+	moneyCurrencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+	MAX_DELIVERY_DAYS int32 = 3650
+)
+
+// validateMoney проверяет формат суммы и кода валюты и приводит сумму к виду с двумя знаками
+// после точки, как ее вернет БД. positive запрещает нулевую сумму
+func validateMoney(field string, m *models.Money, positive bool) *e.ServiceError {
+	if !moneyAmountRe.MatchString(m.Amount) {
+		err := e.New(field+": invalid amount "+m.Amount, e.ErrInvalidMoney)
+		return err
+	}
+
+	if !moneyCurrencyRe.MatchString(m.Currency) {
+		err := e.New(field+": invalid currency "+m.Currency, e.ErrInvalidMoney)
+		return err
+	}
+
+	cents := moneyCents(m.Amount)
+	if positive && cents == 0 {
+		err := e.New(field+": amount must be positive", e.ErrInvalidMoney)
+		return err
+	}
+	m.Amount = fmt.Sprintf("%d.%02d", cents/100, cents%100)
+
+	return nil
+}
+
+func validateDeliveryDays(days *int32) *e.ServiceError {
+	if days != nil && (*days < 1 || *days > MAX_DELIVERY_DAYS) {
+		err := e.New("deliveryDays must be between 1 and "+strconv.Itoa(int(MAX_DELIVERY_DAYS)), e.ErrInvalidMoney)
+		return err
+	}
+	return nil
+}
+
+// moneyCents переводит проверенную validateMoney или прочитанную из БД сумму в копейки.
+// 16 цифр целой части и 2 дробной помещаются в int64, поэтому сравнение точное
+func moneyCents(amount string) int64 {
+	whole, frac, _ := strings.Cut(amount, ".")
+	frac = (frac + "00")[:2]
+	cents, _ := strconv.ParseInt(whole+frac, 10, 64)
+	return cents
+}
+
+// checkWithinBudget цена предложения должна быть в валюте бюджета и не выше него.
+// Тендер без бюджета принимает любую цену
+func checkWithinBudget(price, budget *models.Money) *e.ServiceError {
+	if price == nil || budget == nil {
+		return nil
+	}
+
+	if price.Currency != budget.Currency {
+		err := e.New("price currency "+price.Currency+" does not match budget currency "+budget.Currency, e.ErrCurrencyMismatch)
+		return err
+	}
+
+	if moneyCents(price.Amount) > moneyCents(budget.Amount) {
+		err := e.New("price "+price.Amount+" exceeds budget "+budget.Amount, e.ErrBudgetExceeded)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type OrganizationServiceImpl struct {
+	db database.OrganizationRepository
+}
+
+func NewOrganizationService(db database.OrganizationRepository) repos.OrganizationService {
+	return &OrganizationServiceImpl{
+		db: db,
+	}
+}
+
+func (o *OrganizationServiceImpl) GetOrganizations(ctx context.Context, params repos.GetOrganizationsParams) ([]*models.Organization, error) {
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	params.Limit, params.Offset = &limit, &offset
+	return o.db.GetOrganizations(ctx, params)
+}
+
+func (o *OrganizationServiceImpl) GetOrganization(ctx context.Context, organizationId repos.OrganizationId) (models.Organization, error) {
+	org, err := o.db.GetOrganization(ctx, organizationId)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return *org, nil
+}
+
+func (o *OrganizationServiceImpl) CreateOrganization(ctx context.Context, params repos.CreateOrganizationParams) (models.Organization, error) {
+	if err := validateCreateOrganization(params); err != nil {
+		return models.Organization{}, err.Error()
+	}
+	org, err := o.db.CreateOrganization(ctx, params)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return *org, nil
+}
+
+func (o *OrganizationServiceImpl) EditOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.EditOrganizationParams) (models.Organization, error) {
+	if err := validateEditOrganization(params); err != nil {
+		return models.Organization{}, err.Error()
+	}
+	org, err := o.db.EditOrganization(ctx, organizationId, params)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return *org, nil
+}
+
+func (o *OrganizationServiceImpl) DeleteOrganization(ctx context.Context, organizationId repos.OrganizationId, params repos.DeleteOrganizationParams) error {
+	if err := validateDeleteOrganization(params); err != nil {
+		return err.Error()
+	}
+	return o.db.DeleteOrganization(ctx, organizationId, params)
+}
+
+func (o *OrganizationServiceImpl) GetResponsibles(ctx context.Context, organizationId repos.OrganizationId) ([]*models.Employee, error) {
+	return o.db.GetResponsibles(ctx, organizationId)
+}
+
+func (o *OrganizationServiceImpl) AddResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.AddResponsibleParams) ([]*models.Employee, error) {
+	if err := validateAddResponsible(params); err != nil {
+		return nil, err.Error()
+	}
+	if err := o.db.AddResponsible(ctx, organizationId, params); err != nil {
+		return nil, err
+	}
+	return o.db.GetResponsibles(ctx, organizationId)
+}
+
+func (o *OrganizationServiceImpl) RemoveResponsible(ctx context.Context, organizationId repos.OrganizationId, params repos.RemoveResponsibleParams) ([]*models.Employee, error) {
+	if err := validateRemoveResponsible(params); err != nil {
+		return nil, err.Error()
+	}
+	if err := o.db.RemoveResponsible(ctx, organizationId, params); err != nil {
+		return nil, err
+	}
+	return o.db.GetResponsibles(ctx, organizationId)
+}
@@ -0,0 +1,143 @@
+package servicesimpl
+
+import (
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+var (
+	MAX_ORGANIZATION_NAME_SIZE = 100
+	MAX_EMPLOYEE_USERNAME_SIZE = 50
+	MAX_EMPLOYEE_NAME_SIZE     = 50
+)
+
+func isKnownOrganizationType(t repos.OrganizationType) bool {
+	switch t {
+	case repos.OrganizationTypeIE, repos.OrganizationTypeLLC, repos.OrganizationTypeJSC:
+		return true
+	}
+	return false
+}
+
+func validateCreateOrganization(params repos.CreateOrganizationParams) *e.ServiceError {
+	if params.Name == nil || *params.Name == "" {
+		err := e.New("empty organization name", e.ErrEmpty)
+		return err
+	}
+
+	if len(*params.Name) > MAX_ORGANIZATION_NAME_SIZE {
+		err := e.New("name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.Type == nil || !isKnownOrganizationType(*params.Type) {
+		err := e.New("unknown organization type", e.ErrUnknownOrganizationType)
+		return err
+	}
+
+	if params.CreatorUsername == nil || *params.CreatorUsername == "" {
+		err := e.New("empty creator username", e.ErrEmpty)
+		return err
+	}
+
+	return nil
+}
+
+func validateEditOrganization(params repos.EditOrganizationParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Name != nil && *params.Name == "" {
+		err := e.New("empty organization name", e.ErrEmpty)
+		return err
+	}
+
+	if params.Name != nil && len(*params.Name) > MAX_ORGANIZATION_NAME_SIZE {
+		err := e.New("name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.Type != nil && !isKnownOrganizationType(*params.Type) {
+		err := e.New("unknown organization type", e.ErrUnknownOrganizationType)
+		return err
+	}
+
+	return nil
+}
+
+func validateDeleteOrganization(params repos.DeleteOrganizationParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateAddResponsible(params repos.AddResponsibleParams) *e.ServiceError {
+	if params.Username == "" || params.Employee == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateRemoveResponsible(params repos.RemoveResponsibleParams) *e.ServiceError {
+	if params.Username == "" || params.Employee == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateCreateEmployee(params repos.CreateEmployeeParams) *e.ServiceError {
+	if params.Username == nil || *params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if len(*params.Username) > MAX_EMPLOYEE_USERNAME_SIZE {
+		err := e.New("username length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.FirstName != nil && len(*params.FirstName) > MAX_EMPLOYEE_NAME_SIZE {
+		err := e.New("first name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.LastName != nil && len(*params.LastName) > MAX_EMPLOYEE_NAME_SIZE {
+		err := e.New("last name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	return nil
+}
+
+func validateEditEmployee(params repos.EditEmployeeParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.FirstName != nil && len(*params.FirstName) > MAX_EMPLOYEE_NAME_SIZE {
+		err := e.New("first name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.LastName != nil && len(*params.LastName) > MAX_EMPLOYEE_NAME_SIZE {
+		err := e.New("last name length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	return nil
+}
+
+func validateDeleteEmployee(params repos.DeleteEmployeeParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package servicesimpl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// Границы пагинации общие для всех списков
+const (
+	DEFAULT_PAGE_LIMIT = 5
+	MAX_PAGE_LIMIT     = 50
+)
+
+// pageBounds подставляет значения по умолчанию и ограничивает limit сверху.
+// Используется для списков, которые листаются только через offset
+func pageBounds(limit *repos.PaginationLimit, offset *repos.PaginationOffset) (repos.PaginationLimit, repos.PaginationOffset, *e.ServiceError) {
+	l := repos.PaginationLimit(DEFAULT_PAGE_LIMIT)
+	if limit != nil {
+		if *limit < 0 {
+			return 0, 0, e.New("negative limit", e.ErrInvalidPagination)
+		}
+		l = min(*limit, MAX_PAGE_LIMIT)
+	}
+
+	var o repos.PaginationOffset
+	if offset != nil {
+		if *offset < 0 {
+			return 0, 0, e.New("negative offset", e.ErrInvalidPagination)
+		}
+		o = *offset
+	}
+
+	return l, o, nil
+}
+
+// newPageRequest проверяет параметры страницы. Курсор и offset взаимоисключающие
+func newPageRequest(limit *repos.PaginationLimit, offset *repos.PaginationOffset, cursor *repos.PageCursor, withTotal *bool) (repos.PageRequest, *e.ServiceError) {
+	l, o, err := pageBounds(limit, offset)
+	if err != nil {
+		return repos.PageRequest{}, err
+	}
+
+	page := repos.PageRequest{
+		Limit:     l,
+		Offset:    o,
+		WithTotal: withTotal != nil && *withTotal,
+	}
+
+	if cursor != nil && *cursor != "" {
+		if o != 0 {
+			return repos.PageRequest{}, e.New("cursor and offset are mutually exclusive", e.ErrInvalidPagination)
+		}
+		key, err := decodeCursor(*cursor)
+		if err != nil {
+			return repos.PageRequest{}, err
+		}
+		page.After = key
+	}
+
+	return page, nil
+}
+
+func encodeCursor(key repos.PageKey) repos.PageCursor {
+	raw, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor repos.PageCursor) (*repos.PageKey, *e.ServiceError) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, e.New("cursor is not base64", e.ErrInvalidCursor)
+	}
+
+	var key repos.PageKey
+	if err := json.Unmarshal(raw, &key); err != nil || key.CreatedAt == "" || key.Id == "" {
+		return nil, e.New("malformed cursor", e.ErrInvalidCursor)
+	}
+
+	return &key, nil
+}
+
+// setNextCursor выдает курсор на последний объект, если страница заполнена целиком.
+// Неполная страница означает, что список закончился
+func setNextCursor[T any](page *models.Page[T], req repos.PageRequest, key func(T) repos.PageKey) {
+	if req.Limit == 0 || len(page.Items) < int(req.Limit) {
+		return
+	}
+	cursor := encodeCursor(key(page.Items[len(page.Items)-1]))
+	page.NextCursor = &cursor
+}
+
+func tenderPageKey(t *models.Tender) repos.PageKey {
+	return repos.PageKey{CreatedAt: t.CreatedAt, Id: t.Id}
+}
+
+func bidPageKey(b *models.Bid) repos.PageKey {
+	return repos.PageKey{CreatedAt: b.CreatedAt, Id: b.Id}
+}
+
+func questionPageKey(q *models.TenderQuestion) repos.PageKey {
+	return repos.PageKey{CreatedAt: q.CreatedAt, Id: q.Id}
+}
+
+func feedbackPageKey(f *models.BidFeedbackEntry) repos.PageKey {
+	return repos.PageKey{CreatedAt: f.CreatedAt, Id: f.Id}
+}
+
+func webhookPageKey(w *models.Webhook) repos.PageKey {
+	return repos.PageKey{CreatedAt: w.CreatedAt, Id: w.Id}
+}
+
+func webhookDeliveryPageKey(d *models.WebhookDelivery) repos.PageKey {
+	return repos.PageKey{CreatedAt: d.CreatedAt, Id: d.Id}
+}
@@ -0,0 +1,156 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+type QuestionServiceImpl struct {
+	db      database.QuestionRepository
+	tenders database.TenderRepository
+	users   database.UserRepository
+}
+
+func NewQuestionService(db database.QuestionRepository, tenders database.TenderRepository, users database.UserRepository) repos.QuestionService {
+	return &QuestionServiceImpl{
+		db:      db,
+		tenders: tenders,
+		users:   users,
+	}
+}
+
+func (s *QuestionServiceImpl) AskQuestion(ctx context.Context, tenderId repos.TenderId, params repos.AskQuestionParams) (models.TenderQuestion, error) {
+	if err := validateAskQuestion(params); err != nil {
+		return models.TenderQuestion{}, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	if _, err := s.getOpenTender(ctx, tenderId, v); err != nil {
+		return models.TenderQuestion{}, err
+	}
+	question, err := s.db.CreateTenderQuestion(ctx, tenderId, params.Username, params.Question)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	return *question, nil
+}
+
+func (s *QuestionServiceImpl) EditQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, params repos.EditQuestionParams) (models.TenderQuestion, error) {
+	if err := validateEditQuestion(params); err != nil {
+		return models.TenderQuestion{}, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	if _, err := s.getOpenTender(ctx, tenderId, v); err != nil {
+		return models.TenderQuestion{}, err
+	}
+	// Автора и отсутствие ответа проверяет БД под блокировкой вопроса
+	question, err := s.db.EditTenderQuestion(ctx, tenderId, questionId, params.Username, params.Question)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	return *question, nil
+}
+
+func (s *QuestionServiceImpl) AnswerQuestion(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, params repos.AnswerQuestionParams) (models.TenderQuestion, error) {
+	if err := validateAnswerQuestion(&params); err != nil {
+		return models.TenderQuestion{}, err.Error()
+	}
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	if _, err := s.getOpenTender(ctx, tenderId, v); err != nil {
+		return models.TenderQuestion{}, err
+	}
+	question, err := s.db.AnswerTenderQuestion(ctx, tenderId, questionId, params.Username, params.Answer, *params.Visibility)
+	if err != nil {
+		return models.TenderQuestion{}, err
+	}
+	return *question, nil
+}
+
+func (s *QuestionServiceImpl) GetTenderQuestions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderQuestionsParams) (models.Page[*models.TenderQuestion], error) {
+	if err := validateGetTenderQuestions(params); err != nil {
+		return models.Page[*models.TenderQuestion]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.TenderQuestion]{}, verr.Error()
+	}
+	params.Page = pageReq
+
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return models.Page[*models.TenderQuestion]{}, err
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.Page[*models.TenderQuestion]{}, err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return models.Page[*models.TenderQuestion]{}, err
+	}
+	params.ViewerId = v.userId
+	params.All = v.isResponsible(tender.OrganizationId)
+
+	page, err := s.db.GetTenderQuestions(ctx, tenderId, params)
+	if err != nil {
+		return models.Page[*models.TenderQuestion]{}, err
+	}
+	setNextCursor(page, pageReq, questionPageKey)
+	return *page, nil
+}
+
+func (s *QuestionServiceImpl) GetQuestionVersions(ctx context.Context, tenderId repos.TenderId, questionId repos.QuestionId, params repos.GetQuestionVersionsParams) ([]*models.TenderQuestionVersion, error) {
+	if err := validateGetQuestionVersions(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+
+	v, err := resolveViewer(ctx, s.users, &params.Username)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return nil, err
+	}
+	question, err := s.db.GetTenderQuestion(ctx, tenderId, questionId)
+	if err != nil {
+		return nil, err
+	}
+	if !v.canViewQuestion(question, tender) {
+		return nil, e.New("question is not visible", e.ErrQuestionNotVisible).Error()
+	}
+	return s.db.GetTenderQuestionVersions(ctx, questionId, limit, offset)
+}
+
+// getOpenTender тендер, по которому можно задавать вопросы и отвечать на них: только опубликованный
+func (s *QuestionServiceImpl) getOpenTender(ctx context.Context, tenderId repos.TenderId, v viewer) (*models.Tender, error) {
+	tender, err := s.tenders.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return nil, err
+	}
+	if repos.TenderStatus(tender.Status) != repos.TenderStatusPublished {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrQuestionsClosed).Error()
+	}
+	return tender, nil
+}
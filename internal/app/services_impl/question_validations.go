@@ -0,0 +1,86 @@
+package servicesimpl
+
+import (
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+var (
+	MAX_QUESTION_SIZE = 1000
+	MAX_ANSWER_SIZE   = 2000
+)
+
+func validateQuestionText(username repos.Username, question string) *e.ServiceError {
+	if username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if question == "" {
+		err := e.New("empty question", e.ErrEmpty)
+		return err
+	}
+
+	if len(question) > MAX_QUESTION_SIZE {
+		err := e.New("question length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	return nil
+}
+
+func validateAskQuestion(params repos.AskQuestionParams) *e.ServiceError {
+	return validateQuestionText(params.Username, params.Question)
+}
+
+func validateEditQuestion(params repos.EditQuestionParams) *e.ServiceError {
+	return validateQuestionText(params.Username, params.Question)
+}
+
+// validateAnswerQuestion проверяет ответ и подставляет видимость по умолчанию
+func validateAnswerQuestion(params *repos.AnswerQuestionParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Answer == "" {
+		err := e.New("empty answer", e.ErrEmpty)
+		return err
+	}
+
+	if len(params.Answer) > MAX_ANSWER_SIZE {
+		err := e.New("answer length exceeded", e.ErrExceededLength)
+		return err
+	}
+
+	if params.Visibility == nil {
+		visibility := models.AnswerVisibilityPrivate
+		params.Visibility = &visibility
+	}
+	switch *params.Visibility {
+	case models.AnswerVisibilityPublic, models.AnswerVisibilityPrivate:
+	default:
+		err := e.New("unknown answer visibility "+string(*params.Visibility), e.ErrUnknownAnswerVisibility)
+		return err
+	}
+
+	return nil
+}
+
+func validateGetTenderQuestions(params repos.GetTenderQuestionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetQuestionVersions(params repos.GetQuestionVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package servicesimpl
+
+import (
+	"sort"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// MIN_BIDS_FOR_OUTLIERS при меньшем числе предложений квартили ничего не говорят о разбросе цен
+var MIN_BIDS_FOR_OUTLIERS = 4
+
+// rankBids строит рейтинг по предложениям, уже упорядоченным БД по валюте, цене и сроку поставки.
+// Места и выбросы считаются отдельно для каждой валюты: суммы в разных валютах несравнимы
+func rankBids(tender *models.Tender, bids []*models.Bid) models.BidRanking {
+	ranking := models.BidRanking{
+		TenderId: tender.Id,
+		Budget:   tender.Budget,
+		Items:    []models.BidRankingItem{},
+	}
+
+	var group []*models.Bid
+	flush := func() {
+		ranking.Items = append(ranking.Items, rankCurrencyGroup(group, tender.Budget)...)
+		group = nil
+	}
+
+	for _, bid := range bids {
+		if bid.Price == nil {
+			ranking.Unpriced++
+			continue
+		}
+		if len(group) > 0 && group[0].Price.Currency != bid.Price.Currency {
+			flush()
+		}
+		group = append(group, bid)
+	}
+	flush()
+
+	return ranking
+}
+
+// rankCurrencyGroup одинаковые цена и срок поставки делят место, следующее место пропускается (1, 2, 2, 4)
+func rankCurrencyGroup(bids []*models.Bid, budget *models.Money) []models.BidRankingItem {
+	if len(bids) == 0 {
+		return nil
+	}
+
+	cents := make([]int64, len(bids))
+	for i, bid := range bids {
+		cents[i] = moneyCents(bid.Price.Amount)
+	}
+	low, high, ok := tukeyFences(cents)
+
+	items := make([]models.BidRankingItem, len(bids))
+	for i, bid := range bids {
+		rank := i + 1
+		if i > 0 && cents[i] == cents[i-1] && sameDeliveryDays(bid, bids[i-1]) {
+			rank = items[i-1].Rank
+		}
+		items[i] = models.BidRankingItem{Rank: rank, Bid: bid}
+
+		if ok {
+			switch {
+			case float64(cents[i]) < low:
+				outlier := models.PriceOutlierLow
+				items[i].Outlier = &outlier
+			case float64(cents[i]) > high:
+				outlier := models.PriceOutlierHigh
+				items[i].Outlier = &outlier
+			}
+		}
+
+		if budget != nil && budget.Currency == bid.Price.Currency {
+			items[i].ExceedsBudget = cents[i] > moneyCents(budget.Amount)
+		}
+	}
+
+	return items
+}
+
+func sameDeliveryDays(a, b *models.Bid) bool {
+	if a.DeliveryDays == nil || b.DeliveryDays == nil {
+		return a.DeliveryDays == nil && b.DeliveryDays == nil
+	}
+	return *a.DeliveryDays == *b.DeliveryDays
+}
+
+// tukeyFences границы выбросов Q1 - 1.5*IQR и Q3 + 1.5*IQR.
+// ok = false, если предложений слишком мало для оценки
+func tukeyFences(values []int64) (low, high float64, ok bool) {
+	if len(values) < MIN_BIDS_FOR_OUTLIERS {
+		return 0, 0, false
+	}
+
+	sorted := make([]float64, len(values))
+	for i, v := range values {
+		sorted[i] = float64(v)
+	}
+	sort.Float64s(sorted)
+
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	iqr := q3 - q1
+	return q1 - 1.5*iqr, q3 + 1.5*iqr, true
+}
+
+// quantile квантиль отсортированной выборки с линейной интерполяцией между соседними значениями
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
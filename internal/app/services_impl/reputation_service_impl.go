@@ -0,0 +1,69 @@
+package servicesimpl
+
+import (
+	"context"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+)
+
+type ReputationServiceImpl struct {
+	db            database.ReputationRepository
+	employees     database.EmployeeRepository
+	organizations database.OrganizationRepository
+}
+
+func NewReputationService(db database.ReputationRepository, employees database.EmployeeRepository, organizations database.OrganizationRepository) repos.ReputationService {
+	return &ReputationServiceImpl{
+		db:            db,
+		employees:     employees,
+		organizations: organizations,
+	}
+}
+
+func (s *ReputationServiceImpl) GetEmployeeReputation(ctx context.Context, employee repos.Username) (models.BidderReputation, error) {
+	emp, err := s.employees.GetEmployee(ctx, employee)
+	if err != nil {
+		return models.BidderReputation{}, err
+	}
+	reputation, err := s.db.GetBidderReputation(ctx, repos.BidAuthorTypeUser, emp.Id)
+	if err != nil {
+		return models.BidderReputation{}, err
+	}
+	fillReputationRates(reputation)
+	return *reputation, nil
+}
+
+func (s *ReputationServiceImpl) GetOrganizationReputation(ctx context.Context, organizationId repos.OrganizationId) (models.BidderReputation, error) {
+	org, err := s.organizations.GetOrganization(ctx, organizationId)
+	if err != nil {
+		return models.BidderReputation{}, err
+	}
+	reputation, err := s.db.GetBidderReputation(ctx, repos.BidAuthorTypeOrganization, org.Id)
+	if err != nil {
+		return models.BidderReputation{}, err
+	}
+	fillReputationRates(reputation)
+	return *reputation, nil
+}
+
+// fillReputationRates считает доли и средние из накопленных сумм. Без данных значение остается пустым
+func fillReputationRates(r *models.BidderReputation) {
+	if decided := r.Wins + r.Losses; decided > 0 {
+		rate := float64(r.Wins) / float64(decided)
+		r.WinRate = &rate
+	}
+	if finished := r.Wins + r.Losses + r.Canceled; finished > 0 {
+		rate := float64(r.Canceled) / float64(finished)
+		r.CancellationRate = &rate
+	}
+	if r.RatingCount > 0 {
+		avg := float64(r.RatingSum) / float64(r.RatingCount)
+		r.AverageRating = &avg
+	}
+	if r.DecisionCount > 0 {
+		avg := r.DecisionSeconds / r.DecisionCount
+		r.AverageDecisionSeconds = &avg
+	}
+}
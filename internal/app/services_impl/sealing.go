@@ -0,0 +1,177 @@
+package servicesimpl
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// Запечатанные тендеры.
+//
+// Пока тендер опубликован, название, описание, цена и срок поставки его предложений хранятся
+// только зашифрованными (AES-256-GCM). Ключ тендера выводится из мастер-ключа сервиса
+// как HMAC-SHA256(мастер-ключ, id тендера), поэтому в БД ключи не хранятся.
+// Автор предложения видит его содержимое всегда, владелец тендера - только id и время подачи.
+// При закрытии тендера предложения расшифровываются и сохраняются открытыми, раскрытие пишется в журнал тендера.
+
+// Причины раскрытия, которые попадают в журнал тендера
+const (
+	// RevealTriggerStatus тендер закрыт вручную
+	RevealTriggerStatus = "status"
+	// RevealTriggerScheduler тендер закрыт планировщиком по сроку приема предложений
+	RevealTriggerScheduler = "scheduler"
+	// RevealTriggerAccess тендер закрыт, но раскрытие не состоялось и выполняется при первом обращении
+	RevealTriggerAccess = "access"
+)
+
+var errSealedPayloadTooShort = errors.New("sealed payload is too short")
+
+// BidSealer шифрует и раскрывает содержимое предложений запечатанных тендеров.
+// Без мастер-ключа запечатанные тендеры создавать нельзя
+type BidSealer struct {
+	key     []byte
+	tenders database.TenderRepository
+}
+
+func NewBidSealer(key []byte, tenders database.TenderRepository) *BidSealer {
+	return &BidSealer{
+		key:     key,
+		tenders: tenders,
+	}
+}
+
+func (s *BidSealer) available() bool {
+	return len(s.key) > 0
+}
+
+// aead шифр тендера. Id тендера передается еще и как associated data,
+// чтобы содержимое нельзя было перенести в предложение другого тендера
+func (s *BidSealer) aead(tenderId repos.TenderId) (cipher.AEAD, error) {
+	if !s.available() {
+		return nil, e.New("sealing key is not configured", e.ErrSealingUnavailable).Error()
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(tenderId))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal шифрует содержимое предложения. Результат: nonce || шифртекст
+func (s *BidSealer) seal(tenderId repos.TenderId, content models.SealedBidContent) ([]byte, error) {
+	aead, err := s.aead(tenderId)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, []byte(tenderId)), nil
+}
+
+func (s *BidSealer) open(tenderId repos.TenderId, payload []byte) (*models.SealedBidContent, error) {
+	aead, err := s.aead(tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < aead.NonceSize() {
+		return nil, errSealedPayloadTooShort
+	}
+	nonce, ciphertext := payload[:aead.NonceSize()], payload[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(tenderId))
+	if err != nil {
+		return nil, err
+	}
+	var content models.SealedBidContent
+	if err := json.Unmarshal(plaintext, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// openBid подставляет в запечатанное предложение его расшифрованное содержимое. Только для автора предложения
+func (s *BidSealer) openBid(bid *models.Bid) error {
+	if bid.SealedPayload == nil {
+		return nil
+	}
+	content, err := s.open(bid.TenderId, bid.SealedPayload)
+	if err != nil {
+		return err
+	}
+	bid.Name, bid.Description, bid.Price, bid.DeliveryDays = content.Name, content.Description, content.Price, content.DeliveryDays
+	return nil
+}
+
+func (s *BidSealer) openBids(bids []*models.Bid) error {
+	for _, bid := range bids {
+		if err := s.openBid(bid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BidSealer) openVersion(tenderId repos.TenderId, version *models.BidVersionInfo) error {
+	if version.SealedPayload == nil {
+		return nil
+	}
+	content, err := s.open(tenderId, version.SealedPayload)
+	if err != nil {
+		return err
+	}
+	version.Name, version.Description, version.Price, version.DeliveryDays = content.Name, content.Description, content.Price, content.DeliveryDays
+	return nil
+}
+
+// RevealTender раскрывает предложения закрытого запечатанного тендера.
+// Повторный вызов и вызов для незапечатанного тендера ничего не делают
+func (s *BidSealer) RevealTender(ctx context.Context, tenderId repos.TenderId, trigger string, actor *repos.Username) error {
+	_, err := s.tenders.RevealTenderBids(ctx, tenderId, trigger, actor, func(payload []byte) (*models.SealedBidContent, error) {
+		return s.open(tenderId, payload)
+	})
+	return err
+}
+
+// checkRevealed не дает работать с содержимым чужих предложений, пока тендер запечатан.
+// Закрытый, но еще не раскрытый тендер раскрывается сразу
+func (s *BidSealer) checkRevealed(ctx context.Context, tender *models.Tender) error {
+	if !isTenderSealed(tender) {
+		return nil
+	}
+	if repos.TenderStatus(tender.Status) != repos.TenderStatusClosed {
+		return e.New("tender bids are sealed until it closes", e.ErrTenderSealed).Error()
+	}
+	return s.RevealTender(ctx, tender.Id, RevealTriggerAccess, nil)
+}
+
+// isTenderSealed содержимое предложений тендера еще зашифровано
+func isTenderSealed(tender *models.Tender) bool {
+	return tender.Sealed && tender.RevealedAt == nil
+}
+
+// sealedBidView запечатанное предложение глазами владельца тендера: только id и время подачи
+func sealedBidView(bid *models.Bid) *models.Bid {
+	return &models.Bid{
+		Id:        bid.Id,
+		TenderId:  bid.TenderId,
+		CreatedAt: bid.CreatedAt,
+		Sealed:    true,
+	}
+}
@@ -2,10 +2,14 @@ package servicesimpl
 
 import (
 	"context"
+	"time"
 
 	"github.com/0x0FACED/tender-service/internal/app/database"
 	"github.com/0x0FACED/tender-service/internal/app/domain/models"
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+	"go.uber.org/zap"
 )
 
 // TODO: после вызова методов обращения к БД добавить проверку ошибки
@@ -13,33 +17,86 @@ import (
 // В методах бд надо возвращать разные ошибки, чтобы потом определять, какой http код вернуть юзеру
 
 type TenderServiceImpl struct {
-	db database.TenderRepository
+	db     database.TenderRepository
+	users  database.UserRepository
+	sealer *BidSealer
+
+	logger *zaplog.ZapLogger
 }
 
-func NewTenderService(db database.TenderRepository) repos.TenderService {
+func NewTenderService(db database.TenderRepository, users database.UserRepository, sealer *BidSealer, logger *zaplog.ZapLogger) repos.TenderService {
 	return &TenderServiceImpl{
-		db: db,
+		db:     db,
+		users:  users,
+		sealer: sealer,
+		logger: logger,
 	}
 }
 
-func (b *TenderServiceImpl) GetTenders(ctx context.Context, params repos.GetTendersParams) ([]*models.Tender, error) {
+func (b *TenderServiceImpl) GetTenders(ctx context.Context, params repos.GetTendersParams) (models.Page[*models.Tender], error) {
 	if err := validateGetTenders(params); err != nil {
-		return nil, err.Error()
+		return models.Page[*models.Tender]{}, err.Error()
+	}
+	// Курсор задает позицию в порядке created_at DESC, id, поэтому работает только с сортировкой по умолчанию
+	keyset := isDefaultTenderOrder(params)
+	if !keyset && params.Cursor != nil && *params.Cursor != "" {
+		return models.Page[*models.Tender]{}, e.New("cursor is supported only for default order", e.ErrInvalidCursor).Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.Tender]{}, verr.Error()
+	}
+	params.Page = pageReq
+
+	v, err := resolveViewer(ctx, b.users, params.Username)
+	if err != nil {
+		return models.Page[*models.Tender]{}, err
+	}
+	params.VisibleStatuses = tenderPublicStatuses
+	params.ViewerOrganizations = v.organizations
+	params.ViewerInvitedTenders = v.invitedTenders
+
+	page, err := b.db.GetTenders(ctx, params)
+	if err != nil {
+		return models.Page[*models.Tender]{}, err
 	}
-	return b.db.GetTenders(ctx, params)
+	if keyset {
+		setNextCursor(page, pageReq, tenderPageKey)
+	}
+	return *page, nil
 }
 
-func (b *TenderServiceImpl) GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) ([]*models.Tender, error) {
+func (b *TenderServiceImpl) GetUserTenders(ctx context.Context, params repos.GetUserTendersParams) (models.Page[*models.Tender], error) {
 	if err := validateGetUserTenders(params); err != nil {
-		return nil, err.Error()
+		return models.Page[*models.Tender]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.Tender]{}, verr.Error()
 	}
-	return b.db.GetUserTenders(ctx, params)
+	params.Page = pageReq
+
+	page, err := b.db.GetUserTenders(ctx, params)
+	if err != nil {
+		return models.Page[*models.Tender]{}, err
+	}
+	setNextCursor(page, pageReq, tenderPageKey)
+	return *page, nil
 }
 
 func (b *TenderServiceImpl) CreateTender(ctx context.Context, params repos.CreateTenderParams) (models.Tender, error) {
 	if err := validateCreateTender(params); err != nil {
 		return models.Tender{}, err.Error()
 	}
+	if params.Auction != nil {
+		if err := validateAuctionSettings(params); err != nil {
+			return models.Tender{}, err.Error()
+		}
+	}
+	// Без ключа предложения запечатанного тендера было бы нечем зашифровать
+	if params.Sealed != nil && *params.Sealed && !b.sealer.available() {
+		return models.Tender{}, e.New("sealing key is not configured", e.ErrSealingUnavailable).Error()
+	}
 	tender, err := b.db.CreateTender(ctx, params)
 	if err != nil {
 		return models.Tender{}, err
@@ -51,6 +108,33 @@ func (b *TenderServiceImpl) EditTender(ctx context.Context, tenderId repos.Tende
 	if err := validateEditTender(params); err != nil {
 		return models.Tender{}, err.Error()
 	}
+	current, err := b.checkTenderEditable(ctx, tenderId)
+	if err != nil {
+		return models.Tender{}, err
+	}
+	// Незаданная граница окна остается прежней, поэтому проверяем окно целиком
+	if params.BidsOpenAt != nil || params.BidsCloseAt != nil {
+		openAt, closeAt := current.BidsOpenAt, current.BidsCloseAt
+		if params.BidsOpenAt != nil {
+			openAt = params.BidsOpenAt
+		}
+		if params.BidsCloseAt != nil {
+			closeAt = params.BidsCloseAt
+		}
+		if err := validateBidWindow(openAt, closeAt, time.Now()); err != nil {
+			return models.Tender{}, err.Error()
+		}
+		if current.Auction != nil {
+			if err := checkAuctionWindow(current.Auction, openAt, closeAt); err != nil {
+				return models.Tender{}, err.Error()
+			}
+		}
+	}
+	if current.Auction != nil && params.Budget != nil {
+		if err := checkAuctionBudget(current.Auction, params.Budget); err != nil {
+			return models.Tender{}, err.Error()
+		}
+	}
 	tender, err := b.db.EditTender(ctx, tenderId, username, params)
 	if err != nil {
 		return models.Tender{}, err
@@ -58,10 +142,24 @@ func (b *TenderServiceImpl) EditTender(ctx context.Context, tenderId repos.Tende
 	return *tender, nil
 }
 
+func (b *TenderServiceImpl) GetTender(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderParams) (models.Tender, error) {
+	if err := validateGetTender(params); err != nil {
+		return models.Tender{}, err.Error()
+	}
+	tender, err := b.db.GetTender(ctx, tenderId, params.Username)
+	if err != nil {
+		return models.Tender{}, err
+	}
+	return *tender, nil
+}
+
 func (b *TenderServiceImpl) RollbackTender(ctx context.Context, tenderId repos.TenderId, version int32, params repos.RollbackTenderParams) (models.Tender, error) {
 	if err := validateRollbackTender(params); err != nil {
 		return models.Tender{}, err.Error()
 	}
+	if _, err := b.checkTenderEditable(ctx, tenderId); err != nil {
+		return models.Tender{}, err
+	}
 	tender, err := b.db.RollbackTender(ctx, tenderId, version, params)
 	if err != nil {
 		return models.Tender{}, err
@@ -73,16 +171,116 @@ func (b *TenderServiceImpl) GetTenderStatus(ctx context.Context, tenderId repos.
 	if err := validateGetTenderStatus(params); err != nil {
 		return "", err.Error()
 	}
-	return b.db.GetTenderStatus(ctx, tenderId, params)
+	v, err := resolveViewer(ctx, b.users, params.Username)
+	if err != nil {
+		return "", err
+	}
+	tender, err := b.db.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return "", err
+	}
+	if err := v.checkTenderVisible(tender); err != nil {
+		return "", err
+	}
+	return repos.TenderStatus(tender.Status), nil
 }
 
 func (b *TenderServiceImpl) UpdateTenderStatus(ctx context.Context, tenderId repos.TenderId, params repos.UpdateTenderStatusParams) (models.Tender, error) {
 	if err := validateUpdateTenderStatus(params); err != nil {
 		return models.Tender{}, err.Error()
 	}
+
+	current, err := b.db.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	from := repos.TenderStatus(current.Status)
+	if !canTransitTender(from, params.Status) {
+		return models.Tender{}, e.New("transition "+string(from)+" -> "+string(params.Status)+" not allowed", e.ErrInvalidStatusTransition).Error()
+	}
+	params.CurrentStatus = from
+
 	tender, err := b.db.UpdateTenderStatus(ctx, tenderId, params)
 	if err != nil {
 		return models.Tender{}, err
 	}
+	if params.Status == repos.TenderStatusClosed && isTenderSealed(tender) {
+		// Статус уже сохранен, поэтому ошибка раскрытия не отменяет закрытие:
+		// раскрытие повторится при первом обращении к предложениям
+		if err := b.sealer.RevealTender(ctx, tenderId, RevealTriggerStatus, &params.Username); err != nil {
+			b.logger.Error("cant reveal sealed bids", zap.String("tenderId", tenderId), zap.Error(err))
+			return *tender, nil
+		}
+		tender, err = b.db.GetTenderByID(ctx, tenderId)
+		if err != nil {
+			return models.Tender{}, err
+		}
+	}
 	return *tender, nil
 }
+
+func (b *TenderServiceImpl) GetTenderVersions(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderVersionsParams) ([]*models.TenderVersionInfo, error) {
+	if err := validateGetTenderVersions(params); err != nil {
+		return nil, err.Error()
+	}
+	limit, offset, verr := pageBounds(params.Limit, params.Offset)
+	if verr != nil {
+		return nil, verr.Error()
+	}
+	params.Limit, params.Offset = &limit, &offset
+	return b.db.GetTenderVersions(ctx, tenderId, params)
+}
+
+func (b *TenderServiceImpl) DiffTenderVersions(ctx context.Context, tenderId repos.TenderId, from, to int32, params repos.DiffTenderVersionsParams) (models.VersionDiff, error) {
+	if err := validateDiffTenderVersions(params); err != nil {
+		return models.VersionDiff{}, err.Error()
+	}
+
+	fromVersion, err := b.db.GetTenderVersion(ctx, tenderId, from, params.Username)
+	if err != nil {
+		return models.VersionDiff{}, err
+	}
+
+	toVersion, err := b.db.GetTenderVersion(ctx, tenderId, to, params.Username)
+	if err != nil {
+		return models.VersionDiff{}, err
+	}
+
+	return models.VersionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffVersionFields(tenderVersionFields(fromVersion), tenderVersionFields(toVersion)),
+	}, nil
+}
+
+func (b *TenderServiceImpl) GetTenderAudit(ctx context.Context, tenderId repos.TenderId, params repos.GetTenderAuditParams) ([]*models.TenderAuditEntry, error) {
+	if err := validateGetTenderAudit(params); err != nil {
+		return nil, err.Error()
+	}
+	return b.db.GetTenderAudit(ctx, tenderId, params.Username)
+}
+
+// isDefaultTenderOrder список отсортирован по created_at DESC, id: без поиска и без другой явной сортировки
+func isDefaultTenderOrder(params repos.GetTendersParams) bool {
+	if params.Sort == nil {
+		return params.Q == nil || *params.Q == ""
+	}
+	return *params.Sort == repos.TenderSortCreatedAt && (params.Order == nil || *params.Order == repos.SortOrderDesc)
+}
+
+// checkTenderEditable не дает редактировать и откатывать закрытые и отмененные тендеры,
+// а также редукционы после начала торгов: участники торгуются на известных им условиях
+func (b *TenderServiceImpl) checkTenderEditable(ctx context.Context, tenderId repos.TenderId) (*models.Tender, error) {
+	tender, err := b.db.GetTenderByID(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isTenderEditable(repos.TenderStatus(tender.Status)) {
+		return nil, e.New("tender is "+string(tender.Status), e.ErrTenderNotEditable).Error()
+	}
+	if isAuctionStarted(tender, time.Now()) {
+		return nil, e.New("auction started at "+tender.Auction.StartsAt.Format(time.RFC3339), e.ErrAuctionStarted).Error()
+	}
+	return tender, nil
+}
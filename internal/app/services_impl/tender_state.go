@@ -0,0 +1,65 @@
+package servicesimpl
+
+import (
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// Жизненный цикл тендера:
+//
+//	Created -> Published -> Closed
+//	Created -> Canceled
+//	Published -> Canceled
+//
+// Closed и Canceled - терминальные статусы.
+var tenderTransitions = map[repos.TenderStatus][]repos.TenderStatus{
+	repos.TenderStatusCreated:   {repos.TenderStatusPublished, repos.TenderStatusCanceled},
+	repos.TenderStatusPublished: {repos.TenderStatusClosed, repos.TenderStatusCanceled},
+}
+
+func isKnownTenderStatus(status repos.TenderStatus) bool {
+	switch status {
+	case repos.TenderStatusCreated, repos.TenderStatusPublished, repos.TenderStatusClosed, repos.TenderStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// canTransitTender проверяет, разрешен ли переход тендера из статуса from в статус to
+func canTransitTender(from, to repos.TenderStatus) bool {
+	for _, allowed := range tenderTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// isTenderEditable тендер можно редактировать и откатывать, пока он не закрыт и не отменен
+func isTenderEditable(status repos.TenderStatus) bool {
+	return status == repos.TenderStatusCreated || status == repos.TenderStatusPublished
+}
+
+// isTenderBiddable предложения принимаются только по опубликованным тендерам
+func isTenderBiddable(status repos.TenderStatus) bool {
+	return status == repos.TenderStatusPublished
+}
+
+// isTenderEvaluable предложения оцениваются, пока тендер принимает их, и после закрытия приема
+func isTenderEvaluable(status repos.TenderStatus) bool {
+	return status == repos.TenderStatusPublished || status == repos.TenderStatusClosed
+}
+
+// checkBidWindow проверяет, что момент now попадает в окно приема предложений [openAt, closeAt).
+// Незаданная граница окно не ограничивает
+func checkBidWindow(openAt, closeAt *time.Time, now time.Time) *e.ServiceError {
+	if openAt != nil && now.Before(*openAt) {
+		return e.New("bids open at "+openAt.Format(time.RFC3339), e.ErrBidsNotOpen)
+	}
+	if closeAt != nil && !now.Before(*closeAt) {
+		return e.New("bids closed at "+closeAt.Format(time.RFC3339), e.ErrBidsClosed)
+	}
+	return nil
+}
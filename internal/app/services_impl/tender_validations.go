@@ -1,6 +1,8 @@
 package servicesimpl
 
 import (
+	"time"
+
 	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
 	e "github.com/0x0FACED/tender-service/internal/app/errs"
 )
@@ -8,6 +10,7 @@ import (
 var (
 	MAX_TENDER_NAME_SIZE        = 100
 	MAX_TENDER_DESCRIPTION_SIZE = 1000
+	MAX_SEARCH_QUERY_SIZE       = 200
 )
 
 func validateCreateTender(params repos.CreateTenderParams) *e.ServiceError {
@@ -31,11 +34,17 @@ func validateCreateTender(params repos.CreateTenderParams) *e.ServiceError {
 		return err
 	}
 
-	if *params.Status != "Created" && *params.Status != "Published" && *params.Status != "Closed" {
+	if params.Status == nil || !isKnownTenderStatus(*params.Status) {
 		err := e.New("unknown tender status", e.ErrUnknownStatus)
 		return err
 	}
 
+	// Новый тендер всегда начинает жизненный цикл со статуса Created
+	if *params.Status != repos.TenderStatusCreated {
+		err := e.New("invalid status, must be Created", e.ErrInvalidStatusCreateTender)
+		return err
+	}
+
 	if *params.ServiceType != "Construction" && *params.ServiceType != "Delivery" && *params.ServiceType != "Manufacture" {
 		err := e.New("unknown tender status", e.ErrUnknownStatus)
 		return err
@@ -51,13 +60,108 @@ func validateCreateTender(params repos.CreateTenderParams) *e.ServiceError {
 		return err
 	}
 
+	if err := validateBidWindow(params.BidsOpenAt, params.BidsCloseAt, time.Now()); err != nil {
+		return err
+	}
+
+	if params.Budget != nil {
+		if err := validateMoney("budget", params.Budget, true); err != nil {
+			return err
+		}
+	}
+
+	if params.Access != nil && *params.Access != repos.TenderAccessOpen && *params.Access != repos.TenderAccessInviteOnly {
+		err := e.New("unknown tender access "+string(*params.Access), e.ErrUnknownTenderAccess)
+		return err
+	}
+
 	return nil
 }
 
-// status = 'Created', 'Published', 'Closed'
+// validateBidWindow начало приема предложений должно быть раньше окончания,
+// а окончание не может быть в прошлом
+func validateBidWindow(openAt, closeAt *time.Time, now time.Time) *e.ServiceError {
+	if openAt != nil && closeAt != nil && !openAt.Before(*closeAt) {
+		err := e.New("bidsOpenAt must be before bidsCloseAt", e.ErrInvalidBidWindow)
+		return err
+	}
+
+	if closeAt != nil && !closeAt.After(now) {
+		err := e.New("bidsCloseAt must be in the future", e.ErrInvalidBidWindow)
+		return err
+	}
+
+	return nil
+}
+
+// status = 'Created', 'Published', 'Closed', 'Canceled'
 // type = 'Construction', 'Delivery', 'Manufacture'
 func validateGetTenders(params repos.GetTendersParams) *e.ServiceError {
-	// TODO: валидация слайса типов
+	if params.ServiceType != nil {
+		for _, serviceType := range *params.ServiceType {
+			if !isKnownTenderServiceType(serviceType) {
+				return e.New("unknown service type "+string(serviceType), e.ErrUnknownServiceType)
+			}
+		}
+	}
+
+	if params.Status != nil {
+		for _, status := range *params.Status {
+			if !isKnownTenderStatus(status) {
+				return e.New("unknown tender status "+string(status), e.ErrUnknownStatus)
+			}
+		}
+	}
+
+	if err := validateDateRange("created", params.CreatedFrom, params.CreatedTo); err != nil {
+		return err
+	}
+	if err := validateDateRange("updated", params.UpdatedFrom, params.UpdatedTo); err != nil {
+		return err
+	}
+	if err := validateDateRange("deadline", params.DeadlineFrom, params.DeadlineTo); err != nil {
+		return err
+	}
+
+	if params.Sort != nil && !isKnownTenderSort(*params.Sort) {
+		return e.New("unknown sort field "+string(*params.Sort), e.ErrUnknownSort)
+	}
+	if params.Order != nil && *params.Order != repos.SortOrderAsc && *params.Order != repos.SortOrderDesc {
+		return e.New("unknown sort order "+string(*params.Order), e.ErrUnknownSort)
+	}
+
+	return validateSearchQuery(params.Q)
+}
+
+func isKnownTenderServiceType(serviceType repos.TenderServiceType) bool {
+	switch serviceType {
+	case repos.TenderServiceTypeConstruction, repos.TenderServiceTypeDelivery, repos.TenderServiceTypeManufacture:
+		return true
+	}
+	return false
+}
+
+func isKnownTenderSort(sort repos.TenderSort) bool {
+	switch sort {
+	case repos.TenderSortName, repos.TenderSortCreatedAt, repos.TenderSortUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// validateDateRange проверяет полуинтервал [from, to), незаданные границы допустимы
+func validateDateRange(name string, from, to *time.Time) *e.ServiceError {
+	if from != nil && to != nil && !from.Before(*to) {
+		return e.New(name+" range is empty", e.ErrInvalidDateRange)
+	}
+	return nil
+}
+
+func validateSearchQuery(q *repos.SearchQuery) *e.ServiceError {
+	if q != nil && len(*q) > MAX_SEARCH_QUERY_SIZE {
+		err := e.New("search query length exceeded", e.ErrExceededLength)
+		return err
+	}
 	return nil
 }
 func validateGetUserTenders(params repos.GetUserTendersParams) *e.ServiceError {
@@ -97,6 +201,19 @@ func validateEditTender(params repos.EditTenderParams) *e.ServiceError {
 		err := e.New("unknown tender status", e.ErrUnknownStatus)
 		return err
 	}
+
+	if params.Budget != nil {
+		if err := validateMoney("budget", params.Budget, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func validateGetTender(params repos.GetTenderParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
 	return nil
 }
 func validateRollbackTender(params repos.RollbackTenderParams) *e.ServiceError {
@@ -107,7 +224,8 @@ func validateRollbackTender(params repos.RollbackTenderParams) *e.ServiceError {
 	return nil
 }
 func validateGetTenderStatus(params repos.GetTenderStatusParams) *e.ServiceError {
-	if *params.Username == "" {
+	// Без username статус доступен только для публичных тендеров
+	if params.Username != nil && *params.Username == "" {
 		err := e.New("empty username", e.ErrEmpty)
 		return err
 	}
@@ -119,10 +237,34 @@ func validateUpdateTenderStatus(params repos.UpdateTenderStatusParams) *e.Servic
 		return err
 	}
 
-	if params.Status == "Created" && params.Status != "Published" && params.Status != "Closed" {
+	if !isKnownTenderStatus(params.Status) {
 		err := e.New("unknown tender status", e.ErrUnknownStatus)
 		return err
 	}
 
 	return nil
 }
+
+func validateGetTenderVersions(params repos.GetTenderVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateDiffTenderVersions(params repos.DiffTenderVersionsParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetTenderAudit(params repos.GetTenderAuditParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
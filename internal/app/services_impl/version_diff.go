@@ -0,0 +1,80 @@
+package servicesimpl
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// versionField значение одного поля версии, участвующего в сравнении
+type versionField struct {
+	name  string
+	value any
+}
+
+func tenderVersionFields(v *models.TenderVersionInfo) []versionField {
+	return []versionField{
+		{"name", v.Name},
+		{"description", v.Description},
+		{"serviceType", v.ServiceType},
+		{"status", v.Status},
+		{"bidsOpenAt", timeField(v.BidsOpenAt)},
+		{"bidsCloseAt", timeField(v.BidsCloseAt)},
+		{"budget", moneyField(v.Budget)},
+		{"attachments", v.Attachments},
+	}
+}
+
+// timeField приводит время к сравнимому значению: указатели сравнивать нельзя
+func timeField(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func bidVersionFields(v *models.BidVersionInfo) []versionField {
+	var decision any
+	if v.Decision != nil {
+		decision = *v.Decision
+	}
+	var deliveryDays any
+	if v.DeliveryDays != nil {
+		deliveryDays = *v.DeliveryDays
+	}
+
+	return []versionField{
+		{"name", v.Name},
+		{"description", v.Description},
+		{"status", v.Status},
+		{"decision", decision},
+		{"price", moneyField(v.Price)},
+		{"deliveryDays", deliveryDays},
+		{"attachments", v.Attachments},
+	}
+}
+
+// moneyField разыменовывает сумму, чтобы в изменениях не было типизированного nil
+func moneyField(m *models.Money) any {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
+
+// diffVersionFields сравнивает поля двух версий в порядке их объявления и возвращает только измененные
+func diffVersionFields(from, to []versionField) []models.FieldChange {
+	changes := []models.FieldChange{}
+	for i := range from {
+		// Наборы вложений - срезы, поэтому сравниваем по содержимому
+		if !reflect.DeepEqual(from[i].value, to[i].value) {
+			changes = append(changes, models.FieldChange{
+				Field: from[i].name,
+				Old:   from[i].value,
+				New:   to[i].value,
+			})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,134 @@
+package servicesimpl
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+// Правила видимости тендеров и предложений.
+//
+// Тендеры:
+//
+//	Published, Closed - видны всем, включая анонимных пользователей
+//	Created, Canceled - только ответственным организации-владельца
+//	закрытые (InviteOnly) тендеры - только владельцу и приглашенным, не отклонившим приглашение
+//
+// Предложения:
+//
+//	автор (для предложений от организации - любой ее ответственный) видит предложение в любом статусе
+//	владелец тендера видит чужие предложения, начиная с Published
+//	остальным предложения не видны
+//	содержимое предложений запечатанного тендера до его раскрытия видит только автор
+//
+// Вопросы по тендеру:
+//
+//	ответственные организации-владельца видят все вопросы
+//	остальные - свои вопросы и вопросы с публичным ответом
+var (
+	tenderPublicStatuses   = []repos.TenderStatus{repos.TenderStatusPublished, repos.TenderStatusClosed}
+	bidTenderOwnerStatuses = []repos.BidStatus{repos.BidStatusPublished, repos.BidStatusApproved, repos.BidStatusRejected}
+)
+
+// viewer пользователь, для которого применяются правила видимости.
+// У анонимного пользователя нет id и организаций
+type viewer struct {
+	userId         int
+	organizations  []repos.OrganizationId
+	invitedTenders []repos.TenderId
+}
+
+// resolveViewer находит пользователя и организации, за которые он отвечает.
+// Пустой username означает анонимного пользователя
+func resolveViewer(ctx context.Context, users database.UserRepository, username *repos.Username) (viewer, error) {
+	if username == nil || *username == "" {
+		return viewer{}, nil
+	}
+
+	userId, err := users.GetUserIDByUsername(ctx, *username)
+	if err != nil {
+		return viewer{}, err
+	}
+
+	organizations, err := users.GetUserOrganizations(ctx, *username)
+	if err != nil {
+		return viewer{}, err
+	}
+
+	invitedTenders, err := users.GetUserInvitedTenders(ctx, userId)
+	if err != nil {
+		return viewer{}, err
+	}
+
+	return viewer{userId: userId, organizations: organizations, invitedTenders: invitedTenders}, nil
+}
+
+func (v viewer) isAnonymous() bool {
+	return v.userId == 0
+}
+
+func (v viewer) isResponsible(organizationId repos.OrganizationId) bool {
+	return slices.Contains(v.organizations, organizationId)
+}
+
+// isBidAuthor для предложений от организации authorId - id организации,
+// для предложений от пользователя и старых предложений без организации - id сотрудника
+func (v viewer) isBidAuthor(bid *models.Bid) bool {
+	if v.isAnonymous() {
+		return false
+	}
+	if repos.BidAuthorType(bid.AuthorType) == repos.BidAuthorTypeOrganization && v.isResponsible(bid.AuthorId) {
+		return true
+	}
+	return bid.AuthorId == strconv.Itoa(v.userId)
+}
+
+// isInvited открытый тендер доступен всем, закрытый - приглашенным
+func (v viewer) isInvited(tender *models.Tender) bool {
+	return tender.Access != models.TenderAccessInviteOnly || slices.Contains(v.invitedTenders, tender.Id)
+}
+
+// canViewTender черновики и отмененные тендеры видны только владельцу
+func (v viewer) canViewTender(tender *models.Tender) bool {
+	if v.isResponsible(tender.OrganizationId) {
+		return true
+	}
+	return slices.Contains(tenderPublicStatuses, repos.TenderStatus(tender.Status)) && v.isInvited(tender)
+}
+
+// canViewBid владелец тендера не видит неопубликованные и отозванные чужие предложения
+func (v viewer) canViewBid(bid *models.Bid, tender *models.Tender) bool {
+	if v.isBidAuthor(bid) {
+		return true
+	}
+	return v.isResponsible(tender.OrganizationId) && slices.Contains(bidTenderOwnerStatuses, repos.BidStatus(bid.Status))
+}
+
+// canViewBidContent владелец запечатанного тендера до раскрытия видит только факт подачи предложения
+func (v viewer) canViewBidContent(bid *models.Bid, tender *models.Tender) bool {
+	if v.isBidAuthor(bid) {
+		return true
+	}
+	return v.canViewBid(bid, tender) && !isTenderSealed(tender)
+}
+
+// canViewQuestion приватный ответ и неотвеченный вопрос видны только автору вопроса и владельцу тендера
+func (v viewer) canViewQuestion(question *models.TenderQuestion, tender *models.Tender) bool {
+	if v.isResponsible(tender.OrganizationId) || (!v.isAnonymous() && question.AskerId == v.userId) {
+		return true
+	}
+	return question.AnswerVisibility != nil && *question.AnswerVisibility == models.AnswerVisibilityPublic
+}
+
+// checkTenderVisible возвращает ошибку "не найден", чтобы не раскрывать существование скрытого тендера
+func (v viewer) checkTenderVisible(tender *models.Tender) error {
+	if !v.canViewTender(tender) {
+		return e.New("tender is not visible", e.ErrTenderNotVisible).Error()
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package servicesimpl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+	"github.com/0x0FACED/tender-service/internal/app/webhooks"
+)
+
+// Подписки организаций на доменные события.
+//
+//	подписками управляют ответственные за организацию
+//	событие получают организация-владелец тендера и организация-автор предложения
+//	тело запроса подписывается HMAC-SHA256 ключом подписки
+const (
+	MAX_WEBHOOK_URL_SIZE    = 2048
+	MIN_WEBHOOK_SECRET_SIZE = 16
+	MAX_WEBHOOK_SECRET_SIZE = 256
+	// WEBHOOK_SECRET_BYTES длина ключа, который генерирует сервер
+	WEBHOOK_SECRET_BYTES = 32
+)
+
+type WebhookServiceImpl struct {
+	db database.WebhookRepository
+
+	// allowPrivateNetworks не проверять, что адрес подписки внешний. Только для разработки
+	allowPrivateNetworks bool
+}
+
+func NewWebhookService(db database.WebhookRepository, allowPrivateNetworks bool) repos.WebhookService {
+	return &WebhookServiceImpl{
+		db:                   db,
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, organizationId repos.OrganizationId, params repos.CreateWebhookParams) (models.Webhook, error) {
+	if err := validateCreateWebhook(params); err != nil {
+		return models.Webhook{}, err.Error()
+	}
+	if err := s.checkWebhookUrl(ctx, params.Url); err != nil {
+		return models.Webhook{}, err
+	}
+	var secret string
+	if params.Secret != nil {
+		secret = *params.Secret
+	} else {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return models.Webhook{}, err
+		}
+		secret = generated
+	}
+	webhook, err := s.db.CreateWebhook(ctx, organizationId, params.Username, params.Url, params.EventTypes, secret)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return *webhook, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhooks(ctx context.Context, organizationId repos.OrganizationId, params repos.GetWebhooksParams) (models.Page[*models.Webhook], error) {
+	if err := validateGetWebhooks(params); err != nil {
+		return models.Page[*models.Webhook]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.Webhook]{}, verr.Error()
+	}
+	params.Page = pageReq
+
+	page, err := s.db.GetWebhooks(ctx, organizationId, params.Username, params.Page)
+	if err != nil {
+		return models.Page[*models.Webhook]{}, err
+	}
+	setNextCursor(page, pageReq, webhookPageKey)
+	return *page, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.GetWebhookParams) (models.Webhook, error) {
+	if err := validateGetWebhook(params); err != nil {
+		return models.Webhook{}, err.Error()
+	}
+	webhook, err := s.db.GetWebhook(ctx, organizationId, webhookId, params.Username)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return *webhook, nil
+}
+
+func (s *WebhookServiceImpl) EditWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.EditWebhookParams) (models.Webhook, error) {
+	if err := validateEditWebhook(params); err != nil {
+		return models.Webhook{}, err.Error()
+	}
+	if params.Url != nil {
+		if err := s.checkWebhookUrl(ctx, *params.Url); err != nil {
+			return models.Webhook{}, err
+		}
+	}
+	webhook, err := s.db.EditWebhook(ctx, organizationId, webhookId, params)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return *webhook, nil
+}
+
+// DeleteWebhook удаляет подписку. Недоставленные события ей больше не отправляются
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.DeleteWebhookParams) error {
+	if err := validateDeleteWebhook(params); err != nil {
+		return err.Error()
+	}
+	return s.db.DeleteWebhook(ctx, organizationId, webhookId, params.Username)
+}
+
+func (s *WebhookServiceImpl) GetWebhookDeliveries(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, params repos.GetWebhookDeliveriesParams) (models.Page[*models.WebhookDelivery], error) {
+	if err := validateGetWebhookDeliveries(params); err != nil {
+		return models.Page[*models.WebhookDelivery]{}, err.Error()
+	}
+	pageReq, verr := newPageRequest(params.Limit, params.Offset, params.Cursor, params.WithTotal)
+	if verr != nil {
+		return models.Page[*models.WebhookDelivery]{}, verr.Error()
+	}
+	params.Page = pageReq
+
+	page, err := s.db.GetWebhookDeliveries(ctx, organizationId, webhookId, params.Username, params.Status, params.Page)
+	if err != nil {
+		return models.Page[*models.WebhookDelivery]{}, err
+	}
+	setNextCursor(page, pageReq, webhookDeliveryPageKey)
+	return *page, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhookDelivery(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, params repos.GetWebhookDeliveryParams) (models.WebhookDelivery, error) {
+	if err := validateGetWebhookDelivery(params); err != nil {
+		return models.WebhookDelivery{}, err.Error()
+	}
+	delivery, err := s.db.GetWebhookDelivery(ctx, organizationId, webhookId, deliveryId, params.Username)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return *delivery, nil
+}
+
+// RedeliverWebhook повторяет доставленное или попавшее в DeadLetter событие с тем же EventId
+func (s *WebhookServiceImpl) RedeliverWebhook(ctx context.Context, organizationId repos.OrganizationId, webhookId repos.WebhookId, deliveryId repos.WebhookDeliveryId, params repos.RedeliverWebhookParams) (models.WebhookDelivery, error) {
+	if err := validateRedeliverWebhook(params); err != nil {
+		return models.WebhookDelivery{}, err.Error()
+	}
+	delivery, err := s.db.RedeliverWebhook(ctx, organizationId, webhookId, deliveryId, params.Username)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return *delivery, nil
+}
+
+// checkWebhookUrl отклоняет адреса во внутренних сетях. Sender проверяет адрес еще раз при соединении
+func (s *WebhookServiceImpl) checkWebhookUrl(ctx context.Context, rawUrl string) error {
+	if s.allowPrivateNetworks {
+		return nil
+	}
+	if err := webhooks.CheckURL(ctx, rawUrl); err != nil {
+		return e.New("webhook url is not allowed: "+err.Error(), e.ErrWebhookUrlNotAllowed).Error()
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, WEBHOOK_SECRET_BYTES)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,166 @@
+package servicesimpl
+
+import (
+	"net/url"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/domain/repos"
+	e "github.com/0x0FACED/tender-service/internal/app/errs"
+)
+
+func validateCreateWebhook(params repos.CreateWebhookParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if err := validateWebhookUrl(params.Url); err != nil {
+		return err
+	}
+
+	if err := validateWebhookEventTypes(params.EventTypes); err != nil {
+		return err
+	}
+
+	if params.Secret != nil {
+		if err := validateWebhookSecret(*params.Secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateGetWebhooks(params repos.GetWebhooksParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetWebhook(params repos.GetWebhookParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateEditWebhook(params repos.EditWebhookParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Url != nil {
+		if err := validateWebhookUrl(*params.Url); err != nil {
+			return err
+		}
+	}
+
+	if params.EventTypes != nil {
+		if err := validateWebhookEventTypes(*params.EventTypes); err != nil {
+			return err
+		}
+	}
+
+	if params.Secret != nil {
+		if err := validateWebhookSecret(*params.Secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDeleteWebhook(params repos.DeleteWebhookParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateGetWebhookDeliveries(params repos.GetWebhookDeliveriesParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+
+	if params.Status != nil && !isKnownWebhookDeliveryStatus(*params.Status) {
+		err := e.New("unknown webhook delivery status "+string(*params.Status), e.ErrUnknownStatus)
+		return err
+	}
+
+	return nil
+}
+
+func validateGetWebhookDelivery(params repos.GetWebhookDeliveryParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+func validateRedeliverWebhook(params repos.RedeliverWebhookParams) *e.ServiceError {
+	if params.Username == "" {
+		err := e.New("empty username", e.ErrEmpty)
+		return err
+	}
+	return nil
+}
+
+// validateWebhookUrl допускаются только абсолютные http и https адреса
+func validateWebhookUrl(rawUrl string) *e.ServiceError {
+	if len(rawUrl) > MAX_WEBHOOK_URL_SIZE {
+		err := e.New("webhook url is too long", e.ErrExceededLength)
+		return err
+	}
+
+	u, perr := url.Parse(rawUrl)
+	if perr != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		err := e.New("webhook url must be absolute http or https url", e.ErrInvalidWebhookUrl)
+		return err
+	}
+
+	return nil
+}
+
+func validateWebhookEventTypes(eventTypes []models.DomainEventType) *e.ServiceError {
+	for _, t := range eventTypes {
+		if !isKnownDomainEventType(t) {
+			err := e.New("unknown event type "+string(t), e.ErrUnknownEventType)
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWebhookSecret(secret string) *e.ServiceError {
+	if len(secret) < MIN_WEBHOOK_SECRET_SIZE || len(secret) > MAX_WEBHOOK_SECRET_SIZE {
+		err := e.New("webhook secret length is out of range", e.ErrInvalidWebhookSecret)
+		return err
+	}
+	return nil
+}
+
+func isKnownDomainEventType(t models.DomainEventType) bool {
+	switch t {
+	case models.EventTenderCreated, models.EventTenderPublished, models.EventTenderClosed, models.EventTenderCanceled,
+		models.EventTenderStatusChanged, models.EventTenderUpdated,
+		models.EventBidCreated, models.EventBidSubmitted, models.EventBidCanceled, models.EventBidUpdated, models.EventBidDecided,
+		models.EventFeedbackAdded, models.EventFeedbackEdited, models.EventFeedbackDeleted:
+		return true
+	}
+	return false
+}
+
+func isKnownWebhookDeliveryStatus(status repos.WebhookDeliveryStatus) bool {
+	switch status {
+	case models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryDeadLetter:
+		return true
+	}
+	return false
+}
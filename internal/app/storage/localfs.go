@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore хранит объекты файлами в директории на диске, ключ - относительный путь
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// path не дает ключу выйти за пределы корневой директории или указать на нее саму
+func (l *LocalStore) path(key string) (string, error) {
+	local := filepath.FromSlash(key)
+	if !filepath.IsLocal(local) || filepath.Clean(local) == "." {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.root, local), nil
+}
+
+func (l *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы читатели не увидели недописанный объект
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, readerWithContext(ctx, r)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return f, err
+}
+
+func (l *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// readerWithContext прерывает копирование, если запрос отменен
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return readerFunc(func(p []byte) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return r.Read(p)
+	})
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "tenders/abc/file", strings.NewReader("content"), 7, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	r, err := store.Get(ctx, "tenders/abc/file")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(r)
+	r.Close()
+	if string(got) != "content" {
+		t.Fatalf("Get returned %q, want %q", got, "content")
+	}
+
+	if err := store.Delete(ctx, "tenders/abc/file"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "tenders/abc/file"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("Get after Delete: got %v, want ErrBlobNotFound", err)
+	}
+	if err := store.Delete(ctx, "tenders/abc/file"); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
+
+func TestLocalStoreRejectsTraversal(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	outside := filepath.Join(parent, "x")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"../x", "a/../../x", "/etc/passwd", "", ".", "a/.."} {
+		if err := store.Put(ctx, key, strings.NewReader("evil"), 4, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q): got %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q): got %v, want ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q): got %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	got, err := os.ReadFile(outside)
+	if err != nil || string(got) != "secret" {
+		t.Fatalf("file outside root changed: %q, %v", got, err)
+	}
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+)
+
+// S3Store хранит объекты в бакете S3-совместимого хранилища.
+// Запросы подписываются AWS Signature V4, адресация path-style (endpoint/bucket/key),
+// поэтому подходит и для AWS, и для локального MinIO
+type S3Store struct {
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+}
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3AmzDateFormat = "20060102T150405Z"
+	s3DateFormat    = "20060102"
+
+	// Тело не хешируется: загрузка идет потоком, а целостность проверяется по sha256 из БД
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3SignedHeaders   = "host;x-amz-content-sha256;x-amz-date"
+)
+
+func NewS3Store(cfg config.S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3: endpoint and bucket are required")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("s3: invalid endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("s3: endpoint must be http or https: %q", cfg.Endpoint)
+	}
+
+	return &S3Store{
+		client:    &http.Client{},
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	// S3 не принимает chunked-загрузку без длины, а пустое тело с ContentLength = 0 net/http считает неизвестной длиной
+	if size == 0 {
+		r = http.NoBody
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err == ErrBlobNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, ErrInvalidKey
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = s3EscapePath(u.Path)
+
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do подписывает и выполняет запрос. Ответ не 2xx превращается в ошибку, тело при этом закрывается
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("s3: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// sign добавляет к запросу заголовки подписи AWS Signature V4
+func (s *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(s3AmzDateFormat)
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		s3SignedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, hexSha256(canonicalRequest)}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+s.secretKey), now.Format(s3DateFormat))
+	signingKey = hmacSha256(signingKey, s.region)
+	signingKey = hmacSha256(signingKey, s3Service)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, s3SignedHeaders, signature))
+}
+
+// s3EscapePath кодирует путь по правилам SigV4: все, кроме unreserved-символов и '/', в %XX
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSha256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
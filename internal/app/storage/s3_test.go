@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "us-east-1"
+	testBucket    = "attachments"
+)
+
+// fakeS3 хранит объекты в памяти и отклоняет запросы с неверной подписью
+type fakeS3 struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := verifySigV4(r); err != nil {
+		f.t.Errorf("%s %s: %v", r.Method, r.URL.Path, err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if int64(len(body)) != r.ContentLength {
+			f.t.Errorf("PUT %s: body %d bytes, Content-Length %d", r.URL.Path, len(body), r.ContentLength)
+		}
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		if _, ok := f.objects[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySigV4 независимо пересчитывает подпись запроса по спецификации AWS Signature V4
+func verifySigV4(r *http.Request) error {
+	amzDate := r.Header.Get("X-Amz-Date")
+	if amzDate == "" {
+		return errors.New("missing X-Amz-Date")
+	}
+	if got := r.Header.Get("X-Amz-Content-Sha256"); got != "UNSIGNED-PAYLOAD" {
+		return errors.New("unexpected X-Amz-Content-Sha256: " + got)
+	}
+	date, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return err
+	}
+	day := date.Format("20060102")
+	scope := day + "/" + testRegion + "/s3/aws4_request"
+
+	canonical := r.Method + "\n" +
+		r.URL.EscapedPath() + "\n" +
+		r.URL.RawQuery + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:UNSIGNED-PAYLOAD\n" +
+		"x-amz-date:" + amzDate + "\n" +
+		"\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		"UNSIGNED-PAYLOAD"
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	mac := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	key := mac(mac(mac(mac([]byte("AWS4"+testSecretKey), day), testRegion), "s3"), "aws4_request")
+	signature := hex.EncodeToString(mac(key, stringToSign))
+
+	want := "AWS4-HMAC-SHA256 Credential=" + testAccessKey + "/" + scope +
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + signature
+	if got := r.Header.Get("Authorization"); got != want {
+		return errors.New("signature mismatch:\n got: " + got + "\nwant: " + want)
+	}
+	return nil
+}
+
+func newTestS3(t *testing.T) (*S3Store, *fakeS3) {
+	t.Helper()
+
+	fake := &fakeS3{t: t, objects: make(map[string][]byte), types: make(map[string]string)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	store, err := NewS3Store(config.S3Config{
+		Endpoint:  srv.URL,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return store, fake
+}
+
+func TestS3StorePutGetDelete(t *testing.T) {
+	store, fake := newTestS3(t)
+	ctx := context.Background()
+	key := "tenders/abc/файл отчета.pdf"
+	content := "hello, s3"
+
+	if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	path := "/" + testBucket + "/" + key
+	if got := string(fake.objects[path]); got != content {
+		t.Fatalf("stored %q, want %q", got, content)
+	}
+	if got := fake.types[path]; got != "application/pdf" {
+		t.Fatalf("stored content type %q, want application/pdf", got)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != content {
+		t.Fatalf("Get returned %q, want %q", got, content)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.objects[path]; ok {
+		t.Fatal("object still stored after Delete")
+	}
+}
+
+func TestS3StoreEmptyObject(t *testing.T) {
+	store, fake := newTestS3(t)
+
+	if err := store.Put(context.Background(), "bids/empty", strings.NewReader(""), 0, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if body, ok := fake.objects["/"+testBucket+"/bids/empty"]; !ok || len(body) != 0 {
+		t.Fatalf("stored %q (exists %v), want empty object", body, ok)
+	}
+}
+
+func TestS3StoreNotFound(t *testing.T) {
+	store, _ := newTestS3(t)
+	ctx := context.Background()
+
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("Get missing: got %v, want ErrBlobNotFound", err)
+	}
+	// Удаление отсутствующего объекта не ошибка
+	if err := store.Delete(ctx, "missing"); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
+
+func TestS3StoreInvalidKey(t *testing.T) {
+	store, _ := newTestS3(t)
+
+	for _, key := range []string{"", "/absolute"} {
+		if _, err := store.Get(context.Background(), key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q): got %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
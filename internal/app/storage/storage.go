@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/0x0FACED/tender-service/config"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+// BlobStore хранилище содержимого файлов. Метаданные файлов (имя, тип, контрольная сумма)
+// хранятся в БД, хранилище знает только ключ и байты
+type BlobStore interface {
+	// Put сохраняет size байт из r под ключом key. Существующий объект перезаписывается
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get открывает объект на чтение, вызывающий обязан закрыть его
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект. Удаление отсутствующего объекта не считается ошибкой
+	Delete(ctx context.Context, key string) error
+}
+
+// New создает хранилище по конфигу
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return NewS3Store(cfg.S3)
+	}
+	return nil, fmt.Errorf("unknown storage backend: %q", cfg.Backend)
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// ErrForbiddenAddress адрес получателя во внутренней сети. Запросы туда позволили бы
+// ответственному организации обращаться к сервисам, доступным только серверу
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// sharedAddressSpace диапазон операторского NAT (RFC 6598), снаружи он так же недоступен, как частные сети
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsForbiddenAddr loopback, частные, link-local, multicast и неуказанные адреса
+func IsForbiddenAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// CheckURL проверяет, что все адреса хоста подписки внешние.
+// Проверка при сохранении подписки только подсказывает об ошибке заранее:
+// DNS может измениться, поэтому окончательно адрес проверяется при соединении (dialControl)
+func CheckURL(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if IsForbiddenAddr(addr) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if IsForbiddenAddr(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// dialControl проверяет адрес, с которым фактически устанавливается соединение,
+// поэтому подмена DNS между проверкой и запросом ничего не дает
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if IsForbiddenAddr(addr) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// Пауза перед повторной доставкой растет вдвое с каждой попыткой: 30с, 1м, 2м... но не больше 6 часов
+const (
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 6 * time.Hour
+)
+
+// retryDelay пауза после неудачной попытки с номером attempt (с 1)
+func retryDelay(attempt int32) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < attempt; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+// nextStatus состояние доставки после попытки и пауза до следующей.
+// Ответ 2xx завершает доставку, после maxAttempts неудач она уходит в DeadLetter
+func nextStatus(attempt int32, result models.WebhookAttemptResult, maxAttempts int) (models.WebhookDeliveryStatus, time.Duration) {
+	if result.Error == nil {
+		return models.WebhookDeliveryDelivered, 0
+	}
+	if int(attempt) >= maxAttempts {
+		return models.WebhookDeliveryDeadLetter, 0
+	}
+	return models.WebhookDeliveryPending, retryDelay(attempt)
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+func TestRetryDelay(t *testing.T) {
+	cases := []struct {
+		attempt int32
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{10, 512 * 30 * time.Second},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+	for _, tc := range cases {
+		if got := retryDelay(tc.attempt); got != tc.want {
+			t.Errorf("retryDelay(%d) = %s, want %s", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestNextStatus(t *testing.T) {
+	failed := "unexpected status 500"
+	const maxAttempts = 3
+
+	cases := []struct {
+		name        string
+		attempt     int32
+		result      models.WebhookAttemptResult
+		wantStatus  models.WebhookDeliveryStatus
+		wantRetryIn time.Duration
+	}{
+		{"delivered", 1, models.WebhookAttemptResult{}, models.WebhookDeliveryDelivered, 0},
+		{"delivered on last attempt", maxAttempts, models.WebhookAttemptResult{}, models.WebhookDeliveryDelivered, 0},
+		{"first failure", 1, models.WebhookAttemptResult{Error: &failed}, models.WebhookDeliveryPending, 30 * time.Second},
+		{"second failure", 2, models.WebhookAttemptResult{Error: &failed}, models.WebhookDeliveryPending, time.Minute},
+		{"attempts exhausted", maxAttempts, models.WebhookAttemptResult{Error: &failed}, models.WebhookDeliveryDeadLetter, 0},
+		{"past limit after manual retry", maxAttempts + 1, models.WebhookAttemptResult{Error: &failed}, models.WebhookDeliveryDeadLetter, 0},
+	}
+	for _, tc := range cases {
+		status, retryIn := nextStatus(tc.attempt, tc.result, maxAttempts)
+		if status != tc.wantStatus || retryIn != tc.wantRetryIn {
+			t.Errorf("%s: got (%s, %s), want (%s, %s)", tc.name, status, retryIn, tc.wantStatus, tc.wantRetryIn)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+	"go.uber.org/zap"
+)
+
+// maxDrainSize сколько байт ответа дочитывается перед закрытием, ответ длиннее соединение не переиспользует
+const maxDrainSize = 4 << 10
+
+// Sender периодически отправляет готовые доставки. Доставки одной пачки отправляются параллельно,
+// порядок событий получателю не гарантируется: упорядочивать их можно по seq из тела
+type Sender struct {
+	db     database.WebhookRepository
+	cfg    config.WebhookConfig
+	client *http.Client
+
+	logger *zaplog.ZapLogger
+}
+
+func NewSender(db database.WebhookRepository, cfg config.WebhookConfig, logger *zaplog.ZapLogger) *Sender {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = dialControl
+	}
+	return &Sender{
+		db:  db,
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не получателя
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: cfg.Timeout,
+				MaxIdleConns:        cfg.BatchSize,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// Перенаправление считается неудачной попыткой: подпись выдана для адреса подписки
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+	}
+}
+
+// Run блокируется до отмены ctx, проверяя доставки раз в interval
+func (s *Sender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	s.logger.Info("Webhook sender started", zap.Duration("interval", s.cfg.Interval))
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Webhook sender stopped")
+			return
+		case <-ticker.C:
+			s.sendPending(ctx)
+		}
+	}
+}
+
+func (s *Sender) sendPending(ctx context.Context) {
+	// Аренда с запасом перекрывает таймаут запроса, поэтому доставку не заберет другой инстанс, пока она отправляется
+	requests, err := s.db.ClaimWebhookDeliveries(ctx, s.cfg.BatchSize, 2*s.cfg.Timeout)
+	if err != nil {
+		s.logger.Error("Error claim webhook deliveries", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, request := range requests {
+		wg.Add(1)
+		go func(request models.WebhookRequest) {
+			defer wg.Done()
+			result := s.send(ctx, request)
+			if result.Error != nil {
+				s.logger.Error("Webhook delivery failed",
+					zap.String("deliveryId", request.DeliveryId),
+					zap.Int32("attempt", request.Attempt),
+					zap.String("error", *result.Error))
+			}
+			status, retryIn := nextStatus(request.Attempt, result, s.cfg.MaxAttempts)
+			if err := s.db.CompleteWebhookDelivery(ctx, request, result, status, retryIn); err != nil {
+				s.logger.Error("Error complete webhook delivery", zap.String("deliveryId", request.DeliveryId), zap.Error(err))
+			}
+		}(*request)
+	}
+	wg.Wait()
+}
+
+// send отправляет одну доставку. Успех - любой ответ 2xx.
+// Ни тело ответа, ни текст сетевой ошибки не сохраняются: журнал доставок виден ответственным организации
+// и не должен раскрывать, что отвечает или какие адреса скрываются за адресом подписки
+func (s *Sender) send(ctx context.Context, request models.WebhookRequest) models.WebhookAttemptResult {
+	started := time.Now()
+	var result models.WebhookAttemptResult
+	fail := func(msg string) models.WebhookAttemptResult {
+		result.Error = &msg
+		result.DurationMs = int32(time.Since(started).Milliseconds())
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.Url, bytes.NewReader(request.Payload))
+	if err != nil {
+		return fail("invalid request")
+	}
+	timestamp := started.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tender-service-webhooks")
+	req.Header.Set(HeaderDelivery, request.DeliveryId)
+	req.Header.Set(HeaderEvent, string(request.EventType))
+	req.Header.Set(HeaderEventId, request.EventId)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error("Webhook request failed", zap.String("deliveryId", request.DeliveryId), zap.Error(err))
+		if errors.Is(err, ErrForbiddenAddress) {
+			return fail(ErrForbiddenAddress.Error())
+		}
+		return fail("request failed")
+	}
+	defer resp.Body.Close()
+	// Тело ответа дочитывается, чтобы соединение вернулось в пул
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+
+	statusCode := int32(resp.StatusCode)
+	result.StatusCode = &statusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fail(fmt.Sprintf("unexpected status %d", resp.StatusCode))
+	}
+
+	result.DurationMs = int32(time.Since(started).Milliseconds())
+	return result
+}
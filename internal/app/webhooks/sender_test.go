@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/tender-service/config"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+	"github.com/0x0FACED/tender-service/internal/app/logger/zaplog"
+)
+
+func newTestSender(allowPrivate bool) *Sender {
+	return NewSender(nil, config.WebhookConfig{
+		Timeout:              5 * time.Second,
+		BatchSize:            1,
+		MaxAttempts:          3,
+		AllowPrivateNetworks: allowPrivate,
+	}, zaplog.NewNop())
+}
+
+func testRequest(url string) models.WebhookRequest {
+	return models.WebhookRequest{
+		DeliveryId: "d1",
+		EventId:    "e1",
+		EventType:  "tender.published",
+		Url:        url,
+		Secret:     "secret",
+		Payload:    []byte(`{"seq":1}`),
+		Attempt:    1,
+	}
+}
+
+func TestSendSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("invalid timestamp header: %v", err)
+		}
+		if !Verify("secret", timestamp, body, r.Header.Get(HeaderSignature)) {
+			t.Error("signature does not verify")
+		}
+		if got := r.Header.Get(HeaderDelivery); got != "d1" {
+			t.Errorf("%s = %q, want d1", HeaderDelivery, got)
+		}
+		if got := r.Header.Get(HeaderEvent); got != "tender.published" {
+			t.Errorf("%s = %q, want tender.published", HeaderEvent, got)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	result := newTestSender(true).send(context.Background(), testRequest(srv.URL))
+	if result.Error != nil {
+		t.Fatalf("unexpected error: %s", *result.Error)
+	}
+	if result.StatusCode == nil || *result.StatusCode != http.StatusAccepted {
+		t.Fatalf("StatusCode = %v, want 202", result.StatusCode)
+	}
+}
+
+func TestSendNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("internal details that must not be stored"))
+	}))
+	defer srv.Close()
+
+	result := newTestSender(true).send(context.Background(), testRequest(srv.URL))
+	if result.Error == nil || *result.Error != "unexpected status 500" {
+		t.Fatalf("Error = %v, want unexpected status 500", result.Error)
+	}
+	if result.StatusCode == nil || *result.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("StatusCode = %v, want 500", result.StatusCode)
+	}
+}
+
+func TestSendRedirectIsFailure(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	result := newTestSender(true).send(context.Background(), testRequest(srv.URL))
+	if result.Error == nil {
+		t.Fatal("redirect treated as success")
+	}
+	if result.StatusCode == nil || *result.StatusCode != http.StatusTemporaryRedirect {
+		t.Fatalf("StatusCode = %v, want 307", result.StatusCode)
+	}
+	if followed {
+		t.Fatal("redirect was followed")
+	}
+}
+
+func TestSendBlocksPrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	result := newTestSender(false).send(context.Background(), testRequest(srv.URL))
+	if result.Error == nil || *result.Error != ErrForbiddenAddress.Error() {
+		t.Fatalf("Error = %v, want %q", result.Error, ErrForbiddenAddress.Error())
+	}
+	if result.StatusCode != nil {
+		t.Fatalf("StatusCode = %d, want none", *result.StatusCode)
+	}
+	if called {
+		t.Fatal("request reached loopback receiver")
+	}
+}
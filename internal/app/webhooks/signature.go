@@ -0,0 +1,35 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Заголовки запроса к получателю
+const (
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventId   = "X-Webhook-Event-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signaturePrefix префикс значения HeaderSignature
+const signaturePrefix = "sha256="
+
+// Sign подпись тела запроса: HMAC-SHA256 от "<timestamp>.<body>" в hex с префиксом sha256=.
+// Метка времени входит в подпись, чтобы получатель мог отбрасывать старые перехваченные запросы
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись за постоянное время. Для получателей, написанных на Go
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
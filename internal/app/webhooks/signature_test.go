@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"tender.published"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("secret", 1700000000, body); got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"seq":1}`)
+	signature := Sign("secret", 1700000000, body)
+
+	if !Verify("secret", 1700000000, body, signature) {
+		t.Fatal("valid signature rejected")
+	}
+
+	cases := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+	}{
+		{"wrong secret", "other", 1700000000, body, signature},
+		{"wrong timestamp", "secret", 1700000001, body, signature},
+		{"modified body", "secret", 1700000000, []byte(`{"seq":2}`), signature},
+		{"missing prefix", "secret", 1700000000, body, strings.TrimPrefix(signature, "sha256=")},
+		{"empty signature", "secret", 1700000000, body, ""},
+	}
+	for _, tc := range cases {
+		if Verify(tc.secret, tc.timestamp, tc.body, tc.signature) {
+			t.Errorf("%s: invalid signature accepted", tc.name)
+		}
+	}
+}
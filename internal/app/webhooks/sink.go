@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/0x0FACED/tender-service/internal/app/database"
+	"github.com/0x0FACED/tender-service/internal/app/domain/models"
+)
+
+// Sink получатель outbox, раскладывающий события по доставкам подписок.
+// Сами запросы отправляет Sender, поэтому медленный получатель не задерживает outbox
+type Sink struct {
+	db database.WebhookRepository
+}
+
+func NewSink(db database.WebhookRepository) *Sink {
+	return &Sink{db: db}
+}
+
+func (s *Sink) Name() string {
+	return "webhooks"
+}
+
+func (s *Sink) Deliver(ctx context.Context, event models.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.EnqueueWebhookDeliveries(ctx, event, body)
+	return err
+}
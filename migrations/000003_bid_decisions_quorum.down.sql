@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bid_decisions_bid_author;
+ALTER TABLE bid_decisions DROP COLUMN IF EXISTS created_at;
+ALTER TABLE bid_decisions DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,6 @@
+-- Решения храним по каждому ответственному, чтобы считать кворум
+ALTER TABLE bid_decisions ADD COLUMN IF NOT EXISTS author_id INT REFERENCES employee(id) ON DELETE CASCADE;
+ALTER TABLE bid_decisions ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+-- Один ответственный - одно решение по предложению
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_decisions_bid_author ON bid_decisions(bid_id, author_id);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_tender_status_transitions_tender;
+DROP TABLE IF EXISTS tender_status_transitions;
+
+-- Значение из ENUM удалить нельзя, поэтому пересоздаем тип без 'Canceled'
+UPDATE tenders SET status = 'Closed' WHERE status = 'Canceled';
+UPDATE tender_versions SET status = 'Closed' WHERE status = 'Canceled';
+
+ALTER TYPE tender_status RENAME TO tender_status_old;
+CREATE TYPE tender_status AS ENUM ('Created', 'Published', 'Closed');
+ALTER TABLE tenders ALTER COLUMN status TYPE tender_status USING status::text::tender_status;
+ALTER TABLE tender_versions ALTER COLUMN status TYPE tender_status USING status::text::tender_status;
+DROP TYPE tender_status_old;
@@ -0,0 +1,13 @@
+ALTER TYPE tender_status ADD VALUE IF NOT EXISTS 'Canceled';
+
+-- Журнал переходов статусов тендера: кто и когда перевел тендер
+CREATE TABLE IF NOT EXISTS tender_status_transitions (
+    id SERIAL PRIMARY KEY,
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    from_status tender_status,
+    to_status tender_status NOT NULL,
+    actor_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_status_transitions_tender ON tender_status_transitions(tender_id, created_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_bid_versions_number;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS rolled_back_from;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS edited_by;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS author_type;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS tender_id;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS description;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS name;
@@ -0,0 +1,22 @@
+-- Каждая версия предложения хранит полное редактируемое содержимое
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS name VARCHAR(100);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS description VARCHAR(500);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS author_type bid_author_type;
+
+-- Кто создал версию и из какой версии был сделан откат
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS edited_by INT REFERENCES employee(id) ON DELETE SET NULL;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS rolled_back_from INT;
+
+-- Содержимое старых версий не сохранялось, поэтому заполняем их текущим содержимым предложения
+UPDATE bid_versions v
+SET name = b.name, description = b.description, tender_id = b.tender_id, author_type = b.author_type
+FROM bids b
+WHERE b.id = v.bid_id AND v.name IS NULL;
+
+ALTER TABLE bid_versions ALTER COLUMN name SET NOT NULL;
+ALTER TABLE bid_versions ALTER COLUMN description SET NOT NULL;
+ALTER TABLE bid_versions ALTER COLUMN tender_id SET NOT NULL;
+ALTER TABLE bid_versions ALTER COLUMN author_type SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_versions_number ON bid_versions(bid_id, version_number);
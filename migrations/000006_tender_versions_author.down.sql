@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tender_versions_number;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS rolled_back_from;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS edited_by;
@@ -0,0 +1,5 @@
+-- Автор версии тендера и источник отката, по аналогии с bid_versions
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS edited_by INT REFERENCES employee(id) ON DELETE SET NULL;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS rolled_back_from INT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_versions_number ON tender_versions(tender_id, version_number);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tenders_published_close_at;
+
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS bids_close_at;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS bids_open_at;
+
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS tenders_bid_window_check;
+ALTER TABLE tenders DROP COLUMN IF EXISTS bids_close_at;
+ALTER TABLE tenders DROP COLUMN IF EXISTS bids_open_at;
@@ -0,0 +1,13 @@
+-- Окно приема предложений по тендеру. NULL означает отсутствие ограничения с соответствующей стороны
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS bids_open_at TIMESTAMPTZ;
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS bids_close_at TIMESTAMPTZ;
+
+ALTER TABLE tenders ADD CONSTRAINT tenders_bid_window_check
+    CHECK (bids_open_at IS NULL OR bids_close_at IS NULL OR bids_open_at < bids_close_at);
+
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS bids_open_at TIMESTAMPTZ;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS bids_close_at TIMESTAMPTZ;
+
+-- Планировщик ищет опубликованные тендеры с истекшим сроком приема предложений
+CREATE INDEX IF NOT EXISTS idx_tenders_published_close_at ON tenders(bids_close_at)
+    WHERE status = 'Published' AND bids_close_at IS NOT NULL;
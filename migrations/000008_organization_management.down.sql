@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_organization_responsible_user;
+DROP INDEX IF EXISTS idx_organization_responsible_unique;
+
+ALTER TABLE organization_responsible ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE organization_responsible ALTER COLUMN organization_id DROP NOT NULL;
@@ -0,0 +1,12 @@
+-- Удаляем дубли назначений, чтобы один сотрудник был ответственным за организацию не более одного раза
+DELETE FROM organization_responsible a
+USING organization_responsible b
+WHERE a.organization_id = b.organization_id AND a.user_id = b.user_id AND a.id > b.id;
+
+DELETE FROM organization_responsible WHERE organization_id IS NULL OR user_id IS NULL;
+
+ALTER TABLE organization_responsible ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE organization_responsible ALTER COLUMN user_id SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_responsible_unique ON organization_responsible(organization_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_responsible_user ON organization_responsible(user_id);
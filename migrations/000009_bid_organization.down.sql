@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bids_organization;
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS bids_author_organization_check;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE bids DROP COLUMN IF EXISTS organization_id;
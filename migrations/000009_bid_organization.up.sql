@@ -0,0 +1,28 @@
+-- Организация, от имени которой подано предложение
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organization(id);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organization(id);
+
+-- Старые предложения от организации: восстанавливаем организацию, если автор отвечает ровно за одну
+UPDATE bids b
+SET organization_id = r.organization_id
+FROM organization_responsible r
+WHERE b.author_type = 'Organization' AND b.organization_id IS NULL AND r.user_id = b.author_id
+    AND (SELECT COUNT(*) FROM organization_responsible r2 WHERE r2.user_id = b.author_id) = 1;
+
+-- Организацию восстановить не удалось: такие предложения считаем поданными от имени автора
+UPDATE bids
+SET author_type = 'User'
+WHERE author_type = 'Organization' AND organization_id IS NULL;
+
+UPDATE bid_versions v
+SET organization_id = b.organization_id, author_type = b.author_type
+FROM bids b
+WHERE b.id = v.bid_id AND v.organization_id IS NULL;
+
+-- Организация указывается тогда и только тогда, когда автор - организация.
+-- Ограничение добавляется без проверки и валидируется отдельно, чтобы не держать эксклюзивную блокировку на время проверки
+ALTER TABLE bids ADD CONSTRAINT bids_author_organization_check
+    CHECK ((author_type = 'Organization') = (organization_id IS NOT NULL)) NOT VALID;
+ALTER TABLE bids VALIDATE CONSTRAINT bids_author_organization_check;
+
+CREATE INDEX IF NOT EXISTS idx_bids_organization ON bids(organization_id);
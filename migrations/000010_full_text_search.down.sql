@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bids_search_vector;
+DROP INDEX IF EXISTS idx_tenders_search_vector;
+ALTER TABLE bids DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE tenders DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,20 @@
+-- Поисковый вектор по названию и описанию в русской и английской конфигурациях.
+-- Колонка вычисляемая, поэтому пересчитывается при каждом создании, редактировании и откате
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tenders_search_vector ON tenders USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_bids_search_vector ON bids USING GIN (search_vector);
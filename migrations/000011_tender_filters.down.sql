@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tenders_organization;
+DROP INDEX IF EXISTS idx_tenders_bids_close_at;
+DROP INDEX IF EXISTS idx_tenders_updated_at;
+DROP INDEX IF EXISTS idx_tenders_created_at_id;
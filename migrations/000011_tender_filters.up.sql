@@ -0,0 +1,5 @@
+-- Индексы под фильтры и сортировки списка тендеров
+CREATE INDEX IF NOT EXISTS idx_tenders_created_at_id ON tenders(created_at DESC, id);
+CREATE INDEX IF NOT EXISTS idx_tenders_updated_at ON tenders(updated_at);
+CREATE INDEX IF NOT EXISTS idx_tenders_bids_close_at ON tenders(bids_close_at);
+CREATE INDEX IF NOT EXISTS idx_tenders_organization ON tenders(organization_id);
@@ -0,0 +1,3 @@
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS attachment_ids;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS attachment_ids;
+DROP TABLE IF EXISTS attachments;
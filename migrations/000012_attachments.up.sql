@@ -0,0 +1,24 @@
+-- Вложения тендеров и предложений. Содержимое лежит в хранилище файлов по storage_key,
+-- в БД - метаданные и контрольная сумма
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bids(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(512) NOT NULL UNIQUE,
+    uploaded_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- Удаление мягкое: откат к версии, в которой файл был, возвращает его
+    deleted_at TIMESTAMP,
+    CONSTRAINT attachments_owner_check CHECK ((tender_id IS NULL) <> (bid_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_tender ON attachments(tender_id, created_at) WHERE tender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_attachments_bid ON attachments(bid_id, created_at) WHERE bid_id IS NOT NULL;
+
+-- Набор вложений, актуальный на момент версии
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS attachment_ids UUID[] NOT NULL DEFAULT '{}';
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS attachment_ids UUID[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_bids_ranking;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS budget_currency;
+ALTER TABLE tender_versions DROP COLUMN IF EXISTS budget_amount;
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS tenders_budget_check;
+ALTER TABLE tenders DROP COLUMN IF EXISTS budget_currency;
+ALTER TABLE tenders DROP COLUMN IF EXISTS budget_amount;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS price_currency;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS price_amount;
+ALTER TABLE bids DROP CONSTRAINT IF EXISTS bids_price_check;
+ALTER TABLE bids DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bids DROP COLUMN IF EXISTS price_currency;
+ALTER TABLE bids DROP COLUMN IF EXISTS price_amount;
@@ -0,0 +1,20 @@
+-- Цена и срок поставки предложения. У старых предложений цены нет
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS price_amount NUMERIC(18, 2) CHECK (price_amount >= 0);
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS price_currency CHAR(3);
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS delivery_days INT CHECK (delivery_days > 0);
+ALTER TABLE bids ADD CONSTRAINT bids_price_check CHECK ((price_amount IS NULL) = (price_currency IS NULL));
+
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS price_amount NUMERIC(18, 2);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS price_currency CHAR(3);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS delivery_days INT;
+
+-- Бюджет тендера - потолок цены предложений
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(18, 2) CHECK (budget_amount > 0);
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS budget_currency CHAR(3);
+ALTER TABLE tenders ADD CONSTRAINT tenders_budget_check CHECK ((budget_amount IS NULL) = (budget_currency IS NULL));
+
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(18, 2);
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS budget_currency CHAR(3);
+
+-- Рейтинг опубликованных предложений тендера по цене
+CREATE INDEX IF NOT EXISTS idx_bids_ranking ON bids(tender_id, price_currency, price_amount) WHERE status = 'Published';
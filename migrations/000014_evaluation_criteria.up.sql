@@ -0,0 +1,38 @@
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'criterion_kind') THEN
+        -- Manual оценивают ответственные, Price и DeliveryTime считаются по предложению
+        CREATE TYPE criterion_kind AS ENUM ('Manual', 'Price', 'DeliveryTime');
+    END IF;
+END $$;
+
+-- Критерии оценки предложений тендера с весами
+CREATE TABLE IF NOT EXISTS tender_criteria (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    kind criterion_kind NOT NULL DEFAULT 'Manual',
+    weight INT NOT NULL CHECK (weight BETWEEN 1 AND 100),
+    max_score INT NOT NULL DEFAULT 10 CHECK (max_score BETWEEN 1 AND 100),
+    position INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_criteria_tender ON tender_criteria(tender_id, position);
+
+-- Оценки предложений по критериям. Каждый ответственный ставит одну оценку на критерий,
+-- повторная отправка ее заменяет
+CREATE TABLE IF NOT EXISTS bid_scores (
+    id SERIAL PRIMARY KEY,
+    bid_id UUID REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    criterion_id UUID REFERENCES tender_criteria(id) ON DELETE CASCADE NOT NULL,
+    evaluator_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    score INT NOT NULL CHECK (score >= 0),
+    comment VARCHAR(1000),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, criterion_id, evaluator_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bid_scores_criterion ON bid_scores(criterion_id);
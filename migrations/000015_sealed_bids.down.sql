@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tender_audit_log;
+ALTER TABLE bid_versions DROP COLUMN IF EXISTS sealed_payload;
+ALTER TABLE bids DROP COLUMN IF EXISTS sealed_payload;
+ALTER TABLE tenders DROP COLUMN IF EXISTS revealed_at;
+ALTER TABLE tenders DROP COLUMN IF EXISTS sealed;
@@ -0,0 +1,20 @@
+-- Запечатанные тендеры: содержимое предложений хранится зашифрованным до закрытия тендера
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS revealed_at TIMESTAMPTZ;
+
+-- Зашифрованные название, описание, цена и срок поставки. Открытые колонки у запечатанного предложения пустые
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS sealed_payload BYTEA;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS sealed_payload BYTEA;
+
+-- Журнал действий с тендером, которые важно уметь восстановить задним числом
+CREATE TABLE IF NOT EXISTS tender_audit_log (
+    id SERIAL PRIMARY KEY,
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    -- Пусто, если действие выполнил сам сервис (например, планировщик)
+    actor_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_audit_log_tender ON tender_audit_log(tender_id, created_at);
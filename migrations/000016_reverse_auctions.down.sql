@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS auction_prices;
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS tenders_auction_check;
+ALTER TABLE tenders DROP COLUMN IF EXISTS auction_extension_seconds;
+ALTER TABLE tenders DROP COLUMN IF EXISTS auction_step_currency;
+ALTER TABLE tenders DROP COLUMN IF EXISTS auction_step_amount;
+ALTER TABLE tenders DROP COLUMN IF EXISTS auction_starts_at;
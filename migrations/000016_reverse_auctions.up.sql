@@ -0,0 +1,23 @@
+-- Редукцион: торги идут с auction_starts_at до bids_close_at, поздние цены продлевают bids_close_at
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS auction_starts_at TIMESTAMPTZ;
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS auction_step_amount NUMERIC(18, 2);
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS auction_step_currency VARCHAR(3);
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS auction_extension_seconds INT;
+
+ALTER TABLE tenders ADD CONSTRAINT tenders_auction_check CHECK (
+    (auction_starts_at IS NULL AND auction_step_amount IS NULL AND auction_step_currency IS NULL AND auction_extension_seconds IS NULL)
+    OR (auction_starts_at IS NOT NULL AND auction_step_amount > 0 AND auction_step_currency IS NOT NULL AND auction_extension_seconds >= 0)
+);
+
+-- История цен, поданных на торгах
+CREATE TABLE IF NOT EXISTS auction_prices (
+    id SERIAL PRIMARY KEY,
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    bid_id UUID REFERENCES bids(id) ON DELETE CASCADE NOT NULL,
+    price_amount NUMERIC(18, 2) NOT NULL,
+    price_currency VARCHAR(3) NOT NULL,
+    placed_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_prices_tender ON auction_prices(tender_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tender_question_versions;
+DROP TABLE IF EXISTS tender_questions;
+DROP TYPE IF EXISTS answer_visibility;
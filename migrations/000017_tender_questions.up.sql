@@ -0,0 +1,36 @@
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'answer_visibility') THEN
+        -- Public видят все, кому виден тендер, Private - только автор вопроса
+        CREATE TYPE answer_visibility AS ENUM ('Public', 'Private');
+    END IF;
+END $$;
+
+-- Вопросы по тендеру и ответы организации-владельца
+CREATE TABLE IF NOT EXISTS tender_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    asker_id INT REFERENCES employee(id) ON DELETE CASCADE NOT NULL,
+    question VARCHAR(1000) NOT NULL,
+    answer VARCHAR(2000),
+    answer_visibility answer_visibility,
+    answered_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    answered_at TIMESTAMPTZ,
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((answer IS NULL) = (answer_visibility IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_questions_tender ON tender_questions(tender_id, created_at DESC, id);
+
+-- Каждое изменение вопроса или ответа сохраняется отдельной версией
+CREATE TABLE IF NOT EXISTS tender_question_versions (
+    id SERIAL PRIMARY KEY,
+    question_id UUID REFERENCES tender_questions(id) ON DELETE CASCADE NOT NULL,
+    version INT NOT NULL,
+    question VARCHAR(1000) NOT NULL,
+    answer VARCHAR(2000),
+    answer_visibility answer_visibility,
+    editor_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (question_id, version)
+);
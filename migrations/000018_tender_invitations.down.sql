@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tender_invitations;
+ALTER TABLE tenders DROP COLUMN IF EXISTS access;
+DROP TYPE IF EXISTS invitation_status;
+DROP TYPE IF EXISTS tender_access;
@@ -0,0 +1,30 @@
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'tender_access') THEN
+        -- Open - тендер доступен всем, InviteOnly - только приглашенным
+        CREATE TYPE tender_access AS ENUM ('Open', 'InviteOnly');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'invitation_status') THEN
+        CREATE TYPE invitation_status AS ENUM ('Pending', 'Accepted', 'Declined');
+    END IF;
+END $$;
+
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS access tender_access NOT NULL DEFAULT 'Open';
+
+-- Приглашения в закрытый тендер: организации (отвечает любой ее ответственный) или отдельного сотрудника
+CREATE TABLE IF NOT EXISTS tender_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE NOT NULL,
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    employee_id INT REFERENCES employee(id) ON DELETE CASCADE,
+    status invitation_status NOT NULL DEFAULT 'Pending',
+    invited_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    responded_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((organization_id IS NULL) <> (employee_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_invitations_organization ON tender_invitations(tender_id, organization_id) WHERE organization_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_invitations_employee ON tender_invitations(tender_id, employee_id) WHERE employee_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tender_invitations_invitee_org ON tender_invitations(organization_id);
+CREATE INDEX IF NOT EXISTS idx_tender_invitations_invitee_employee ON tender_invitations(employee_id);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS bid_feedback_versions;
+DROP INDEX IF EXISTS idx_bid_feedbacks_parent;
+DROP INDEX IF EXISTS idx_bid_feedbacks_bid;
+-- Ответы без ветки превратились бы в отзывы, поэтому удаляются
+DELETE FROM bid_feedbacks WHERE parent_id IS NOT NULL;
+ALTER TABLE bid_feedbacks
+    DROP CONSTRAINT IF EXISTS bid_feedbacks_reply_rating_check,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS rating,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,36 @@
+-- Ответы в ветке отзыва, оценка, правки и мягкое удаление
+ALTER TABLE bid_feedbacks
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES bid_feedbacks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS rating INT CHECK (rating BETWEEN 1 AND 5),
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'bid_feedbacks_reply_rating_check') THEN
+        -- Оценку ставит только владелец тендера в самом отзыве, в ответах ее нет
+        ALTER TABLE bid_feedbacks ADD CONSTRAINT bid_feedbacks_reply_rating_check CHECK (parent_id IS NULL OR rating IS NULL);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_bid_feedbacks_bid ON bid_feedbacks(bid_id, created_at DESC, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bid_feedbacks_parent ON bid_feedbacks(parent_id, created_at);
+
+-- Каждое изменение отзыва, включая удаление, сохраняется отдельной версией
+CREATE TABLE IF NOT EXISTS bid_feedback_versions (
+    id SERIAL PRIMARY KEY,
+    feedback_id UUID REFERENCES bid_feedbacks(id) ON DELETE CASCADE NOT NULL,
+    version INT NOT NULL,
+    description VARCHAR(1000) NOT NULL,
+    rating INT,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    editor_id INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (feedback_id, version)
+);
+
+-- Уже оставленные отзывы получают первую версию
+INSERT INTO bid_feedback_versions (feedback_id, version, description, editor_id, created_at)
+SELECT f.id, 1, f.description, f.author_id, f.created_at
+FROM bid_feedbacks f
+ON CONFLICT (feedback_id, version) DO NOTHING;
@@ -0,0 +1,42 @@
+-- Репутация авторов предложений: сотрудников (предложения от себя) и организаций.
+-- Строка пересчитывается при решениях, отзыве предложения и изменении оценок в отзывах
+CREATE TABLE IF NOT EXISTS bidder_reputation (
+    subject_type bid_author_type NOT NULL,
+    subject_id TEXT NOT NULL,
+    wins INT NOT NULL DEFAULT 0,
+    losses INT NOT NULL DEFAULT 0,
+    canceled INT NOT NULL DEFAULT 0,
+    rating_count INT NOT NULL DEFAULT 0,
+    rating_sum INT NOT NULL DEFAULT 0,
+    decision_count INT NOT NULL DEFAULT 0,
+    decision_seconds BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (subject_type, subject_id)
+);
+
+-- Начальное заполнение по уже существующим предложениям
+INSERT INTO bidder_reputation (subject_type, subject_id, wins, losses, canceled, rating_count, rating_sum, decision_count, decision_seconds)
+SELECT
+    CASE WHEN b.organization_id IS NOT NULL THEN 'Organization' ELSE 'User' END::bid_author_type,
+    CASE WHEN b.organization_id IS NOT NULL THEN b.organization_id::text ELSE b.author_id::text END,
+    COUNT(*) FILTER (WHERE b.status = 'Approved'),
+    COUNT(*) FILTER (WHERE b.status = 'Rejected'
+        OR (b.status = 'Published' AND EXISTS(SELECT 1 FROM bids w WHERE w.tender_id = b.tender_id AND w.status = 'Approved'))),
+    COUNT(*) FILTER (WHERE b.status = 'Canceled'),
+    COALESCE(SUM(r.rating_count), 0),
+    COALESCE(SUM(r.rating_sum), 0),
+    COUNT(d.decided_at),
+    COALESCE(SUM(EXTRACT(EPOCH FROM d.decided_at - b.created_at)), 0)::BIGINT
+FROM bids b
+LEFT JOIN LATERAL (
+    SELECT COUNT(f.rating) AS rating_count, SUM(f.rating) AS rating_sum
+    FROM bid_feedbacks f
+    WHERE f.bid_id = b.id AND f.parent_id IS NULL AND f.deleted_at IS NULL
+) r ON TRUE
+LEFT JOIN LATERAL (
+    SELECT MIN(v.created_at) AS decided_at
+    FROM bid_versions v
+    WHERE v.bid_id = b.id AND v.decision IS NOT NULL
+) d ON TRUE
+GROUP BY 1, 2
+ON CONFLICT (subject_type, subject_id) DO NOTHING;
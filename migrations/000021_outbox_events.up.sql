@@ -0,0 +1,21 @@
+-- Доменные события. Пишутся в той же транзакции, что и изменение, и доставляются диспетчером
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    type VARCHAR(64) NOT NULL,
+    aggregate_type VARCHAR(32) NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Доставлено во все получатели
+    dispatched_at TIMESTAMPTZ,
+    -- Попытки исчерпаны, событие больше не доставляется и не задерживает следующие события агрегата
+    failed_at TIMESTAMPTZ,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(id) WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events(aggregate_id, id) WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched ON outbox_events(dispatched_at) WHERE dispatched_at IS NOT NULL;
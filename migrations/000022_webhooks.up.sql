@@ -0,0 +1,54 @@
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'webhook_delivery_status') THEN
+        -- DeadLetter - попытки исчерпаны, доставку можно повторить только вручную
+        CREATE TYPE webhook_delivery_status AS ENUM ('Pending', 'Delivered', 'DeadLetter');
+    END IF;
+END $$;
+
+-- Подписки организаций на доменные события. Пустой event_types - все события
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret VARCHAR(256) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INT REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_organization ON webhook_subscriptions(organization_id, created_at DESC);
+
+-- Доставка одного события одной подписке. Повтор события из outbox не создает вторую доставку
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID REFERENCES webhook_subscriptions(id) ON DELETE CASCADE NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'Pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC, id);
+
+-- Журнал попыток доставки
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id SERIAL PRIMARY KEY,
+    delivery_id UUID REFERENCES webhook_deliveries(id) ON DELETE CASCADE NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    duration_ms INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);